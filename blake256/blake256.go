@@ -0,0 +1,297 @@
+// Package blake256 implements the BLAKE-256 and BLAKE-224 hash
+// functions, as submitted to the final round of the SHA-3 competition.
+// These are the original 14-round BLAKE, which is not compatible with
+// BLAKE2s.
+package blake256
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/DeedleFake/crypto/internal/partial"
+)
+
+const (
+	// Size is the size of a BLAKE-256 hash in bytes.
+	Size = 32
+
+	// Size224 is the size of a BLAKE-224 hash in bytes.
+	Size224 = 28
+
+	// BlockSize is the blocksize of a hash.
+	BlockSize = 64
+
+	// SaltSize is the size of a salt in bytes.
+	SaltSize = 16
+)
+
+var (
+	iv256 = [8]uint32{
+		0x6A09E667, 0xBB67AE85, 0x3C6EF372, 0xA54FF53A,
+		0x510E527F, 0x9B05688C, 0x1F83D9AB, 0x5BE0CD19,
+	}
+
+	iv224 = [8]uint32{
+		0xC1059ED8, 0x367CD507, 0x3070DD17, 0xF70E5939,
+		0xFFC00B31, 0x68581511, 0x64F98FA7, 0xBEFA4FA4,
+	}
+)
+
+// Digest is the state of a BLAKE hash. It implements hash.Hash. The
+// zero value is ready to use and computes unsalted BLAKE-256 hashes. A
+// Digest for the other digest length or with a salt must come from its
+// constructor.
+type Digest struct {
+	buf    [64]byte
+	offset int
+	state  [8]uint32
+	salt   [4]uint32
+
+	// size is the length of the digest in bytes. It is zero until the
+	// zero Digest is first used.
+	size int
+
+	// count is the number of message bits in the blocks that have been
+	// compressed.
+	count uint64
+
+	// partial is what WriteBits has left of a partially written byte.
+	partial partial.Byte
+}
+
+var (
+	_ hash.Cloner     = (*Digest)(nil)
+	_ io.StringWriter = (*Digest)(nil)
+	_ io.ByteWriter   = (*Digest)(nil)
+)
+
+// New returns a new hash.Hash that computes BLAKE-256 hashes.
+func New() hash.Hash {
+	return newDigest(Size, nil)
+}
+
+// New224 returns a new hash.Hash that computes BLAKE-224 hashes.
+func New224() hash.Hash {
+	return newDigest(Size224, nil)
+}
+
+// NewSalt returns a new hash.Hash that computes BLAKE-256 hashes with
+// the given salt, which must be SaltSize bytes long.
+func NewSalt(salt []byte) (hash.Hash, error) {
+	if len(salt) != SaltSize {
+		return nil, fmt.Errorf("blake256: invalid salt length: %v bytes", len(salt))
+	}
+	return newDigest(Size, salt), nil
+}
+
+// New224Salt is like NewSalt, but computes BLAKE-224 hashes.
+func New224Salt(salt []byte) (hash.Hash, error) {
+	if len(salt) != SaltSize {
+		return nil, fmt.Errorf("blake256: invalid salt length: %v bytes", len(salt))
+	}
+	return newDigest(Size224, salt), nil
+}
+
+func newDigest(size int, salt []byte) *Digest {
+	ctx := &Digest{size: size}
+	ctx.Reset()
+	if salt != nil {
+		for i := range ctx.salt {
+			ctx.salt[i] = binary.BigEndian.Uint32(salt[i<<2:])
+		}
+	}
+	return ctx
+}
+
+// init sets up the zero Digest to compute BLAKE-256 hashes. It does
+// nothing to a Digest that is already set up.
+func (ctx *Digest) init() {
+	if ctx.size == 0 {
+		*ctx = *newDigest(Size, nil)
+	}
+}
+
+func (ctx *Digest) Write(data []byte) (n int, err error) {
+	ctx.init()
+	n = len(data)
+
+	var buf [BlockSize]byte
+	for len(data) > 0 {
+		var chunk []byte
+		chunk, data = ctx.partial.Next(buf[:], data)
+		ctx.write(chunk)
+	}
+
+	return n, nil
+}
+
+// WriteString adds the bytes of s to the running hash without
+// converting it to a byte slice first. It implements io.StringWriter.
+func (ctx *Digest) WriteString(s string) (n int, err error) {
+	ctx.init()
+	n = len(s)
+
+	var buf [BlockSize]byte
+	for len(s) > 0 {
+		var chunk []byte
+		chunk, s = ctx.partial.NextString(buf[:], s)
+		ctx.write(chunk)
+	}
+
+	return n, nil
+}
+
+// WriteByte adds c to the running hash. It implements io.ByteWriter.
+func (ctx *Digest) WriteByte(c byte) error {
+	buf := [1]byte{c}
+	ctx.Write(buf[:])
+	return nil
+}
+
+// WriteBits adds the first bits bits of data to the running hash.
+// Bits are taken from the most significant end of each byte. Unlike
+// Write, the message does not need to be a whole number of bytes long,
+// and calls to WriteBits and Write can be freely interleaved. It
+// panics if bits is greater than 8*len(data).
+func (ctx *Digest) WriteBits(data []byte, bits uint64) {
+	full := bits / 8
+	ctx.Write(data[:full])
+
+	if rem := uint(bits % 8); rem != 0 {
+		if b, ok := ctx.partial.Add(data[full], rem); ok {
+			ctx.write([]byte{b})
+		}
+	}
+}
+
+func (ctx *Digest) write(data []byte) (n int, err error) {
+	n = len(data)
+
+	if ctx.offset > 0 {
+		c := copy(ctx.buf[ctx.offset:], data)
+		ctx.offset += c
+		data = data[c:]
+		if ctx.offset < len(ctx.buf) {
+			return n, nil
+		}
+
+		ctx.blocks(ctx.buf[:])
+		ctx.offset = 0
+	}
+
+	// Compress whole blocks straight out of data and only buffer
+	// what's left over.
+	if len(data) >= len(ctx.buf) {
+		c := len(data) - len(data)%len(ctx.buf)
+		ctx.blocks(data[:c])
+		data = data[c:]
+	}
+	ctx.offset = copy(ctx.buf[:], data)
+
+	return n, nil
+}
+
+// blocks compresses data, the length of which must be a multiple of
+// BlockSize, into the state.
+func (ctx *Digest) blocks(data []byte) {
+	for len(data) >= len(ctx.buf) {
+		ctx.count += BlockSize * 8
+		compress(&ctx.state, &ctx.salt, (*[BlockSize]byte)(data), ctx.count)
+		data = data[len(ctx.buf):]
+	}
+}
+
+func (ctx *Digest) close(dst []byte) {
+	// n is the number of message bits that have not been compressed.
+	n := uint64(ctx.offset)*8 + uint64(ctx.partial.N)
+	total := ctx.count + n
+
+	block := ctx.buf
+	block[ctx.offset] = ctx.partial.Padded()
+	clear(block[ctx.offset+1:])
+
+	// The counter of a block that contains only padding is zero.
+	t := total
+	if n == 0 {
+		t = 0
+	}
+
+	// The padding needs a bit after the message, a bit to mark
+	// BLAKE-256 and eight bytes for the length.
+	if n > 8*BlockSize-2-64 {
+		compress(&ctx.state, &ctx.salt, &block, t)
+		clear(block[:])
+		t = 0
+	}
+	if ctx.size == Size {
+		block[BlockSize-9] |= 1
+	}
+	binary.BigEndian.PutUint64(block[BlockSize-8:], total)
+	compress(&ctx.state, &ctx.salt, &block, t)
+
+	var out [Size]byte
+	for i, v := range ctx.state {
+		binary.BigEndian.PutUint32(out[i<<2:], v)
+	}
+	copy(dst, out[:])
+}
+
+func (ctx *Digest) Sum(prev []byte) []byte {
+	// Finalize a copy so that the running hash can keep being written
+	// to after a call to Sum.
+	c := *ctx
+	c.init()
+	out := append(prev, make([]byte, c.size)...)
+	c.close(out[len(prev):])
+	return out
+}
+
+// Clone returns a copy of the running hash that can be written to and
+// summed independently of ctx. It implements hash.Cloner and never
+// returns an error.
+func (ctx *Digest) Clone() (hash.Cloner, error) {
+	c := *ctx
+	return &c, nil
+}
+
+// Reset resets the hash to its initial state. The salt is kept.
+func (ctx *Digest) Reset() {
+	if ctx.size == 0 {
+		return
+	}
+
+	*ctx = Digest{size: ctx.size, salt: ctx.salt}
+	ctx.state = iv256
+	if ctx.size == Size224 {
+		ctx.state = iv224
+	}
+}
+
+func (ctx *Digest) Size() int {
+	if ctx.size == 0 {
+		return Size
+	}
+	return ctx.size
+}
+
+func (ctx *Digest) BlockSize() int {
+	return BlockSize
+}
+
+// Sum returns the BLAKE-256 hash of data.
+func Sum(data []byte) (out [Size]byte) {
+	ctx := newDigest(Size, nil)
+	ctx.Write(data)
+	ctx.close(out[:])
+	return out
+}
+
+// Sum224 returns the BLAKE-224 hash of data.
+func Sum224(data []byte) (out [Size224]byte) {
+	ctx := newDigest(Size224, nil)
+	ctx.Write(data)
+	ctx.close(out[:])
+	return out
+}
@@ -0,0 +1,322 @@
+package blake256
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/DeedleFake/crypto/internal/hashtest"
+	"github.com/DeedleFake/crypto/internal/kat"
+)
+
+// The first two vectors are the examples from the BLAKE specification.
+// The others cover the padding edge cases and salts, and all but
+// MultiBlock are also in the tests of the Decred BLAKE-256 package.
+// MultiBlock was computed by internal/refgen with
+//
+//	go run ./internal/refgen blake
+var sumTests = []struct {
+	name   string
+	salt   []byte
+	in     []byte
+	out224 string
+	out256 string
+}{
+	{
+		name:   "OneBlock",
+		in:     []byte{0},
+		out224: "4504cb0314fb2a4f7a692e696e487912fe3f2468fe312c73a5278ec5",
+		out256: "0ce8d4ef4dd7cd8d62dfded9d4edb0a774ae6a41929a74da23109e8f11139c87",
+	},
+	{
+		name:   "TwoBlock",
+		in:     make([]byte, 72),
+		out224: "f5aa00dd1cb847e3140372af7b5c46b4888d82c8c0a917913cfb5d04",
+		out256: "d419bad32d504fb7d44d460c42c5593fe544fa4c135dec31e21bd9abdcc22d41",
+	},
+	{
+		name:   "Empty",
+		out224: "7dc5313b1c04512a174bd6503b89607aecbee0903d40a8a569c94eed",
+		out256: "716f6e863f744b9ac22c97ec7b76ea5f5908bc5b2f67c61510bfc4751384ea7a",
+	},
+	{
+		name:   "PaddingOnlyBlock",
+		in:     bytes.Repeat([]byte{3}, 127),
+		out224: "1720e615098f02ff69ca5b4f3f66b4fd9f5f8df95a4e570327c973bc",
+		out256: "9adc25ef2bd7129e6e5d9fb5dc93b91f9b33453b61b5085fbc40b0e44fe69eea",
+	},
+	{
+		name:   "OnePaddingByte",
+		in:     bytes.Repeat([]byte{1}, 55),
+		out224: "7f016a9765c497402c49ecc5d27c76ca59bdd9a36d94e63ebba0c408",
+		out256: "b7d949165810b1a2f018c37392c633031bde4d45e453a3d5e62f2ba73fbf9769",
+	},
+	{
+		name:   "Fox",
+		in:     []byte("The quick brown fox jumps over the lazy dog"),
+		out224: "c8e92d7088ef87c1530aee2ad44dc720cc10589cc2ec58f95a15e51b",
+		out256: "7576698ee9cad30173080678e5965916adbb11cb5245d386bf1ffda1cb26c9d7",
+	},
+	{
+		name:   "BLAKE",
+		in:     []byte("BLAKE"),
+		out224: "cfb6848add73e1cb47994c4765df33b8f973702705a30a71fe4747a3",
+		out256: "07663e00cf96fbc136cf7b1ee099c95346ba3920893d18cc8851f22ee2e36aa6",
+	},
+	{
+		name:   "Hooray",
+		in:     []byte("'BLAKE wins SHA-3! Hooray!!!' (I have time machine)"),
+		out224: "b0d0ca94a288bde157e47687f0a6675bac4858898f3ea59f35a456de",
+		out256: "18a393b4e62b1887a2edf79a5c5a5464daf5bbb976f4007bea16a73e4c1e198e",
+	},
+	{
+		name:   "Go",
+		in:     []byte("Go"),
+		out224: "dde9e442003c24495db607b17e07ec1f67396cc1907642a09a96594e",
+		out256: "fd7282ecc105ef201bb94663fc413db1b7696414682090015f17e309b835f1c2",
+	},
+	{
+		name:   "Trapped",
+		in:     []byte("HELP! I'm trapped in hash!"),
+		out224: "d11963a0dfb571fb54f9051e92489f50de877d7fd1c7d86a06fb4528",
+		out256: "1e75db2a709081f853c2229b65fd1558540aa5e7bd17b04b9a4b31989effa711",
+	},
+	{
+		name: "Lorem",
+		in: []byte("Lorem ipsum dolor sit amet, consectetur adipiscing elit. " +
+			"Donec a diam lectus. Sed sit amet ipsum mauris. Maecenas congu"),
+		out224: "fe312db9138c074964b6dfe347b078bb927074b73481c4d86ecb8b54",
+		out256: "af95fffc7768821b1e08866a2f9f66916762bfc9d71c4acb5fd515f31fd6785a",
+	},
+	{
+		name:   "MultiBlock",
+		in:     hashtest.Seq(200),
+		out256: "c4d944c2b1c00a8ee627726b35d4cd7fe018de090bc637553cc782e25f974cba",
+	},
+	{
+		name:   "SaltedEmpty",
+		salt:   bytes.Repeat([]byte{1}, 16),
+		out224: "8436b12388ccd3041f6bb10777ac5566f0695480da93180c35767909",
+		out256: "f60463bd4d9e3d9275228159b1a87fa5de77a0f9e47fd4a20db8d9ca35460d11",
+	},
+	{
+		name:   "SaltedPaddingOnlyBlock",
+		salt:   bytes.Repeat([]byte{1}, 16),
+		in:     bytes.Repeat([]byte{0xde}, 127),
+		out224: "f0594c9048561be2f2f153019f9f80c13705d28840adaab3f75149b6",
+		out256: "513da045656d5128053c6e75676f9a379cea1eea4cafc6f29578cbb5010b7da4",
+	},
+	{
+		name:   "SaltedTwoBlock",
+		salt:   bytes.Repeat([]byte{0x5f}, 16),
+		in:     bytes.Repeat([]byte{1}, 119),
+		out224: "fe6c61ef2bb29dc42f1e50437c977159f0b478d55b9d589d1619612f",
+		out256: "bdfa19309534dc7cec4c9ef9731374ef18774bf6c18a93ca94297e342946eed0",
+	},
+	{
+		name:   "Salted",
+		salt:   []byte("SALTsaltSaltSALT"),
+		in:     []byte("It's so salty out there!"),
+		out224: "288b80c5de334c0d3283c25ccd691ccee5c842b62ecc49e3dce8edcb",
+		out256: "88cc11889bbbee42095337fe2153c591971f94fbf8fe540d3c7e9f1700ab2d0c",
+	},
+}
+
+func TestSum(t *testing.T) {
+	for _, test := range sumTests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			h, h224 := New(), New224()
+			if test.salt != nil {
+				var err error
+				if h, err = NewSalt(test.salt); err != nil {
+					t.Fatal(err)
+				}
+				if h224, err = New224Salt(test.salt); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			h.Write(test.in)
+			if got := fmt.Sprintf("%x", h.Sum(nil)); got != test.out256 {
+				t.Errorf("BLAKE-256: expected %v", test.out256)
+				t.Errorf("BLAKE-256: got %v", got)
+			}
+
+			if test.out224 != "" {
+				h224.Write(test.in)
+				if got := fmt.Sprintf("%x", h224.Sum(nil)); got != test.out224 {
+					t.Errorf("BLAKE-224: expected %v", test.out224)
+					t.Errorf("BLAKE-224: got %v", got)
+				}
+			}
+
+			if test.salt == nil {
+				if got := fmt.Sprintf("%x", Sum(test.in)); got != test.out256 {
+					t.Errorf("Sum = %v", got)
+				}
+				if got := fmt.Sprintf("%x", Sum224(test.in)); (test.out224 != "") && (got != test.out224) {
+					t.Errorf("Sum224 = %v", got)
+				}
+			}
+		})
+	}
+}
+
+func TestSalt(t *testing.T) {
+	if _, err := NewSalt(make([]byte, SaltSize-1)); err == nil {
+		t.Errorf("Expected error for short salt")
+	}
+	if _, err := New224Salt(make([]byte, SaltSize+1)); err == nil {
+		t.Errorf("Expected error for long salt")
+	}
+
+	// A zero salt is the same as none.
+	h, err := NewSalt(make([]byte, SaltSize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Write([]byte("abc"))
+	if want := Sum([]byte("abc")); !bytes.Equal(h.Sum(nil), want[:]) {
+		t.Errorf("Zero salt does not match unsalted hash")
+	}
+
+	// These vectors are from the tests of the Decred BLAKE-256 package,
+	// github.com/decred/dcrd/crypto/blake256. Each message is written in
+	// two parts and the second is written to a clone, following the
+	// saved state tests there.
+	tests := []struct {
+		salt   string
+		first  string
+		second string
+		out224 string
+		out256 string
+	}{
+		{
+			salt:   "1234567890123456",
+			out224: "e28df478e7e4bc7ab73111f3352f3e9ac10200fa1f00f6717301c019",
+			out256: "561d6d0cfa3d31d5eedaf2d575f3942539b03522befc2a1196ba0e51af8992a8",
+		},
+		{
+			salt:   strings.Repeat("\x01", 16),
+			first:  "03",
+			second: "0102",
+			out224: "568417d5650fbf679e025901e1812db168052c64ed0d551920c816d8",
+			out256: "ee8345617ff51e69f909d1d92dc7be4e3e67fdb00474652847969d17adc0fcdd",
+		},
+		{
+			salt:   "1234567890123456",
+			first:  "201f1e1d1c1b1a191817161514131211100f0e0d0c0b0a090807060504030201",
+			second: "1004f3e3d3c3b3a393837363534333231303f2e2d2c2b2a2928272625242322212",
+			out224: "dfb8a2abc84706d3c634bafcc54f9c2291724c5f5806b5d658071a41",
+			out256: "3119f9fcf7051fe2b55920b733033a92731d9994cb4ad47cfdceb35f829c8497",
+		},
+		{
+			salt: "6543210987654321",
+			first: "1004f3e3d3c3b3a393837363534333231303f2e2d2c2b2a29282726252423222" +
+				"1202f1e1d1c1b1a191817161514131211101f0e0d0c0b0a0908070605040302010",
+			second: "03",
+			out224: "eede890209b36e2438a4cf018e2efe2bcfe6d82ed9d7e045e67799ef",
+			out256: "d22f972d3d9adad090a48cd03f8af2d5bef8134afad4e75f6c548e8005bd607e",
+		},
+		{
+			salt: "aa55aa55aa55aa55",
+			first: "1004f3e3d3c3b3a393837363534333231303f2e2d2c2b2a29282726252423222" +
+				"1202f1e1d1c1b1a191817161514131211101f0e0d0c0b0a0908070605040302010",
+			second: "201004f3e3d3c3b3a393837363534333231303f2e2d2c2b2a292827262524232" +
+				"221202f1e1d1c1b1a191817161514131211101f0e0d0c0b0a09080706050403020",
+			out224: "1de525166e500f8d4ba5c7375e50535ae9410e36d5aabbf3a128db75",
+			out256: "a60ff6286511587e741e808f8ef460c12e61dbe21d0b7dc71cfbf2175ba0f1c4",
+		},
+	}
+	for _, test := range tests {
+		first, _ := hex.DecodeString(test.first)
+		second, _ := hex.DecodeString(test.second)
+		for _, c := range []struct {
+			new func([]byte) (hash.Hash, error)
+			out string
+		}{
+			{new: New224Salt, out: test.out224},
+			{new: NewSalt, out: test.out256},
+		} {
+			h, err := c.new([]byte(test.salt))
+			if err != nil {
+				t.Fatal(err)
+			}
+			h.Write(first)
+			clone, err := h.(hash.Cloner).Clone()
+			if err != nil {
+				t.Fatal(err)
+			}
+			clone.Write(second)
+			if got := fmt.Sprintf("%x", clone.Sum(nil)); got != c.out {
+				t.Errorf("Salt %q: expected %v", test.salt, c.out)
+				t.Errorf("Salt %q: got %v", test.salt, got)
+			}
+		}
+	}
+
+	// Reset keeps the salt.
+	h, err = NewSalt([]byte("SALTsaltSaltSALT"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Write([]byte("garbage"))
+	h.Reset()
+	h.Write([]byte("It's so salty out there!"))
+	if got := fmt.Sprintf("%x", h.Sum(nil)); got != "88cc11889bbbee42095337fe2153c591971f94fbf8fe540d3c7e9f1700ab2d0c" {
+		t.Errorf("Reset lost the salt: got %v", got)
+	}
+}
+
+func TestHash(t *testing.T) {
+	hashtest.Run(t, func() hashtest.Hash { return New().(*Digest) })
+}
+
+func TestZeroDigest(t *testing.T) {
+	hashtest.Run(t, func() hashtest.Hash { return new(Digest) })
+
+	var d Digest
+	if d.Size() != Size {
+		t.Errorf("Expected size %v", Size)
+		t.Errorf("Got %v", d.Size())
+	}
+
+	d.Reset()
+	d.Write([]byte("abc"))
+	if got, want := d.Sum(nil), Sum([]byte("abc")); !bytes.Equal(got, want[:]) {
+		t.Errorf("Expected %x", want)
+		t.Errorf("Got %x", got)
+	}
+}
+
+// The KAT files contain the messages of the SHA-3 competition. The
+// digests were computed by internal/refgen, which also reproduces all
+// of the published BLAKE-512 short message results.
+func TestKAT(t *testing.T) {
+	sizes := []struct {
+		size    string
+		newHash func() kat.BitHash
+	}{
+		{size: "224", newHash: func() kat.BitHash { return New224().(*Digest) }},
+		{size: "256", newHash: func() kat.BitHash { return New().(*Digest) }},
+	}
+
+	for _, size := range sizes {
+		size := size
+		for _, file := range []string{"ShortMsgKAT", "LongMsgKAT"} {
+			name := file + "_" + size.size
+			t.Run(name, func(t *testing.T) {
+				kat.Run(t, filepath.Join("testdata", name+".txt"), size.newHash)
+			})
+		}
+	}
+}
+
+func BenchmarkWrite(b *testing.B) {
+	hashtest.Benchmark(b, New)
+}
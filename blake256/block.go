@@ -0,0 +1,79 @@
+package blake256
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+const rounds = 14
+
+var sigma = [10][16]uint8{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+	{11, 8, 12, 0, 5, 2, 15, 13, 10, 14, 3, 6, 7, 1, 9, 4},
+	{7, 9, 3, 1, 13, 12, 11, 14, 2, 6, 5, 10, 4, 0, 15, 8},
+	{9, 0, 5, 7, 2, 4, 10, 15, 14, 1, 11, 12, 6, 8, 3, 13},
+	{2, 12, 6, 10, 0, 11, 8, 3, 4, 13, 7, 5, 15, 14, 1, 9},
+	{12, 5, 1, 15, 14, 13, 4, 10, 0, 7, 6, 3, 9, 2, 8, 11},
+	{13, 11, 7, 14, 12, 1, 3, 9, 5, 0, 15, 4, 8, 6, 2, 10},
+	{6, 15, 14, 9, 11, 3, 0, 8, 12, 2, 13, 7, 1, 4, 10, 5},
+	{10, 2, 8, 4, 7, 6, 1, 5, 15, 11, 9, 14, 3, 12, 13, 0},
+}
+
+// consts holds the leading digits of the fractional part of pi.
+var consts = [16]uint32{
+	0x243F6A88, 0x85A308D3, 0x13198A2E, 0x03707344,
+	0xA4093822, 0x299F31D0, 0x082EFA98, 0xEC4E6C89,
+	0x452821E6, 0x38D01377, 0xBE5466CF, 0x34E90C6C,
+	0xC0AC29B7, 0xC97C50DD, 0x3F84D5B5, 0xB5470917,
+}
+
+// g is the G function applied to one column or diagonal, with x and y
+// being the message words already combined with the constants.
+func g(a, b, c, d, x, y uint32) (uint32, uint32, uint32, uint32) {
+	a += b + x
+	d = bits.RotateLeft32(d^a, -16)
+	c += d
+	b = bits.RotateLeft32(b^c, -12)
+	a += b + y
+	d = bits.RotateLeft32(d^a, -8)
+	c += d
+	b = bits.RotateLeft32(b^c, -7)
+	return a, b, c, d
+}
+
+// compress compresses block into h using the salt s and the counter t,
+// the number of message bits up to the end of the block, or zero if
+// the block contains only padding.
+func compress(h *[8]uint32, s *[4]uint32, block *[BlockSize]byte, t uint64) {
+	var m [16]uint32
+	for i := range m {
+		m[i] = binary.BigEndian.Uint32(block[i<<2:])
+	}
+
+	v0, v1, v2, v3, v4, v5, v6, v7 := h[0], h[1], h[2], h[3], h[4], h[5], h[6], h[7]
+	v8, v9, v10, v11 := s[0]^consts[0], s[1]^consts[1], s[2]^consts[2], s[3]^consts[3]
+	v12, v13 := uint32(t)^consts[4], uint32(t)^consts[5]
+	v14, v15 := uint32(t>>32)^consts[6], uint32(t>>32)^consts[7]
+
+	for r := range rounds {
+		p := &sigma[r%10]
+		v0, v4, v8, v12 = g(v0, v4, v8, v12, m[p[0]]^consts[p[1]], m[p[1]]^consts[p[0]])
+		v1, v5, v9, v13 = g(v1, v5, v9, v13, m[p[2]]^consts[p[3]], m[p[3]]^consts[p[2]])
+		v2, v6, v10, v14 = g(v2, v6, v10, v14, m[p[4]]^consts[p[5]], m[p[5]]^consts[p[4]])
+		v3, v7, v11, v15 = g(v3, v7, v11, v15, m[p[6]]^consts[p[7]], m[p[7]]^consts[p[6]])
+		v0, v5, v10, v15 = g(v0, v5, v10, v15, m[p[8]]^consts[p[9]], m[p[9]]^consts[p[8]])
+		v1, v6, v11, v12 = g(v1, v6, v11, v12, m[p[10]]^consts[p[11]], m[p[11]]^consts[p[10]])
+		v2, v7, v8, v13 = g(v2, v7, v8, v13, m[p[12]]^consts[p[13]], m[p[13]]^consts[p[12]])
+		v3, v4, v9, v14 = g(v3, v4, v9, v14, m[p[14]]^consts[p[15]], m[p[15]]^consts[p[14]])
+	}
+
+	h[0] ^= s[0] ^ v0 ^ v8
+	h[1] ^= s[1] ^ v1 ^ v9
+	h[2] ^= s[2] ^ v2 ^ v10
+	h[3] ^= s[3] ^ v3 ^ v11
+	h[4] ^= s[0] ^ v4 ^ v12
+	h[5] ^= s[1] ^ v5 ^ v13
+	h[6] ^= s[2] ^ v6 ^ v14
+	h[7] ^= s[3] ^ v7 ^ v15
+}
@@ -0,0 +1,230 @@
+package blake512
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/DeedleFake/crypto/internal/hashtest"
+	"github.com/DeedleFake/crypto/internal/kat"
+)
+
+// lorem is the long message used by the tests of
+// github.com/dchest/blake512.
+const lorem = "Lorem ipsum dolor sit amet, consectetur adipiscing elit. Donec a diam lectus. Sed sit amet ipsum mauris. Maecenas congue ligula ac quam viverra nec consectetur ante hendrerit. Donec et mollis dolor. Praesent et diam eget libero egestas mattis sit amet vitae augue. Nam tincidunt congue enim, ut porta lorem lacinia consectetur. Donec ut libero sed arcu vehicula ultricies a non tortor. Lorem ipsum dolor sit amet, consectetur adipiscing elit. Aenean ut gravida lorem. Ut turpis felis, pulvinar a semper sed, adipiscing id dolor. Pellentesque auctor nisi id magna consequat sagittis. Curabitur dapibus enim sit amet elit pharetra tincidunt feugiat nisl imperdiet. Ut convallis libero in urna ultrices accumsan. Donec sed odio eros. Donec viverra mi quis quam pulvinar at malesuada arcu rhoncus. Cum sociis natoque penatibus et magnis dis parturient montes, nascetur ridiculus mus. In rutrum accumsan ultricies. Mauris vitae nisi at sem facilisis semper ac in est."
+
+// The first two vectors are the examples from the BLAKE specification.
+// Empty, Fox, BLAKE, Hooray, Go, Trapped and the Lorem vectors are from
+// the tests of github.com/dchest/blake512. The others were computed by
+// internal/refgen with
+//
+//	go run ./internal/refgen blake
+var sumTests = []struct {
+	name   string
+	salt   []byte
+	in     []byte
+	out384 string
+	out512 string
+}{
+	{
+		name:   "OneBlock",
+		in:     []byte{0},
+		out512: "97961587f6d970faba6d2478045de6d1fabd09b61ae50932054d52bc29d31be4ff9102b9f69e2bbdb83be13d4b9c06091e5fa0b48bd081b634058be0ec49beb3",
+	},
+	{
+		name:   "TwoBlock",
+		in:     make([]byte, 144),
+		out512: "313717d608e9cf758dcb1eb0f0c3cf9fc150b2d500fb33f51c52afc99d358a2f1374b8a38bba7974e7f6ef79cab16f22ce1e649d6e01ad9589c213045d545dde",
+	},
+	{
+		name:   "Empty",
+		out384: "c6cbd89c926ab525c242e6621f2f5fa73aa4afe3d9e24aed727faaadd6af38b620bdb623dd2b4788b1c8086984af8706",
+		out512: "a8cfbbd73726062df0c6864dda65defe58ef0cc52a5625090fa17601e1eecd1b628e94f396ae402a00acc9eab77b4d4c2e852aaaa25a636d80af3fc7913ef5b8",
+	},
+	{
+		name:   "PaddingOnlyBlock",
+		in:     bytes.Repeat([]byte{3}, 239),
+		out512: "90cd974bf14f7898e6de26bf8f85384bdc6cc4eeec3783ade94228a0315be915991d5613c8525b31331503cba1f445c628af83b8e5ccc95094e5c0ec498637ee",
+	},
+	{
+		name:   "Fox",
+		in:     []byte("The quick brown fox jumps over the lazy dog"),
+		out384: "67c9e8ef665d11b5b57a1d99c96adffb3034d8768c0827d1c6e60b54871e8673651767a2c6c43d0ba2a9bb2500227406",
+		out512: "1f7e26f63b6ad25a0896fd978fd050a1766391d2fd0471a77afb975e5034b7ad2d9ccf8dfb47abbbe656e1b82fbc634ba42ce186e8dc5e1ce09a885d41f43451",
+	},
+	{
+		name:   "BLAKE",
+		in:     []byte("BLAKE"),
+		out384: "f28742f7243990875d07e6afcff962edabdf7e9d19ddea6eae31d094c7fa6d9b00c8213a02ddf1e2d9894f3162345d85",
+		out512: "7bf805d0d8de36802b882e65d0515aa7682a2be97a9d9ec1399f4be2eff7de07684d7099124c8ac81c1c7c200d24ba68c6222e75062e04feb0e9dd589aa6e3b7",
+	},
+	{
+		name:   "Hooray",
+		in:     []byte("'BLAKE wins SHA-3! Hooray!!!' (I have time machine)"),
+		out384: "685c83708eb104ad2be3e9f109f5bbb9054fcf38a5ac5ef7e10fbd2ce7853983002dad66cd07c420795733bf97d6fddf",
+		out512: "19bb3a448f4eef6f0b9374817e96c7c848d96f20c5a3e4b808173d97aede52cb396506ac20e174a1d53d9e51e443e7447855f2c9e8c6e4247fa8e4f54cda5897",
+	},
+	{
+		name:   "Go",
+		in:     []byte("Go"),
+		out384: "9fc155189e04b0325604324bbf11438cb20e05bb634f190ef3e234c6ef0140ec5563303125685aff5690ca1c5a9dbdd5",
+		out512: "8cd8a7bf2953dd236371a07a3c9e70325abd76922dcb434c68532760e536cf2a955fe8c40d90cb38506fcde30b47da8ee8835064e091427d854ce1dfad972634",
+	},
+	{
+		name:   "Trapped",
+		in:     []byte("HELP! I'm trapped in hash!"),
+		out384: "e32f545290b8b5eb2f092cf565854e304e91b5b4b10d9534ed722bcf1da9617f7132a47e37001e63ba833cce5b98ea7d",
+		out512: "465d047d9695f258a47af7b94a03d903cb60ae1286f263aac8628774ee90828bea31fb7fe1d3385af364080a317115c8df8596c3c608d8de77b95bff702a3984",
+	},
+	{
+		name:   "Lorem",
+		in:     []byte(lorem),
+		out384: "018a6daf616874e8a146906295893599d3f65ad9da9f870ae11c3884dcf3c81fe86f24725ce0853f98f0fb335fdc10ce",
+		out512: "68376fe303ee09c3a220ee330bccc9fa9fba6dc41741507f195f5457ffa75864076f71bc07e94620123ec24f70458c2ba3dd1fa31a7fefc036d430c962c0969b",
+	},
+	{
+		name:   "LoremOnePaddingByte",
+		in:     []byte(lorem[:239]),
+		out384: "4dc8aa947b13db364e2eb837ae7f561cb301a443e62c92e5e2c890145e83859b906435d30ae46ee6207c84812c2b4eee",
+		out512: "c805573523a7f386732329b6c001e6fe1e1d1842b8152d8f205b86078e571afbaaf4c560cf084fe297e05aac14ae4ded7fdfa2db461fb05d3add28de3f2293c3",
+	},
+	{
+		name:   "MultiBlock",
+		in:     hashtest.Seq(200),
+		out512: "e327afcd4b6113e8f9571f030f60b4b85ea29df58c35ac1d0daceefe9edb17c6ce5a8bf5934214da6a3746f72c8b96cfce9625f4edf157408d67a6d3071d5980",
+	},
+	{
+		name:   "SaltedEmpty",
+		salt:   hashtest.Seq(32),
+		out384: "a31b31c9d859a47ab8899f5c63160ee3ef23c3800aec341123f9f49b73585132fe54f7bbdcf08cffaf5d63e6979e4849",
+		out512: "7ca7f8fda461ca3d2a441dcf000b3cc086fbd33ea2a87a281f5d9c4cfb32e49eccea143e79e85df64992e406325e88fb3cfd20dbe600ee7066924cafb83bc53c",
+	},
+	{
+		name:   "Salted",
+		salt:   hashtest.Seq(32),
+		in:     []byte("The quick brown fox jumps over the lazy dog"),
+		out512: "6a39f393d4553fadc4ef582515acce6da5ad2437f5e5a2dc9908393c806fd399bbf11b275eccb64e7e6146dccb1a49d095e9f2236bd93189b64b15943f08a85f",
+	},
+}
+
+func TestSum(t *testing.T) {
+	for _, test := range sumTests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			h, h384 := New(), New384()
+			if test.salt != nil {
+				var err error
+				if h, err = NewSalt(test.salt); err != nil {
+					t.Fatal(err)
+				}
+				if h384, err = New384Salt(test.salt); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			h.Write(test.in)
+			if got := fmt.Sprintf("%x", h.Sum(nil)); got != test.out512 {
+				t.Errorf("BLAKE-512: expected %v", test.out512)
+				t.Errorf("BLAKE-512: got %v", got)
+			}
+
+			if test.out384 != "" {
+				h384.Write(test.in)
+				if got := fmt.Sprintf("%x", h384.Sum(nil)); got != test.out384 {
+					t.Errorf("BLAKE-384: expected %v", test.out384)
+					t.Errorf("BLAKE-384: got %v", got)
+				}
+			}
+
+			if test.salt == nil {
+				if got := fmt.Sprintf("%x", Sum(test.in)); got != test.out512 {
+					t.Errorf("Sum = %v", got)
+				}
+				if got := fmt.Sprintf("%x", Sum384(test.in)); (test.out384 != "") && (got != test.out384) {
+					t.Errorf("Sum384 = %v", got)
+				}
+			}
+		})
+	}
+}
+
+func TestSalt(t *testing.T) {
+	if _, err := NewSalt(make([]byte, SaltSize-1)); err == nil {
+		t.Errorf("Expected error for short salt")
+	}
+	if _, err := New384Salt(make([]byte, SaltSize+1)); err == nil {
+		t.Errorf("Expected error for long salt")
+	}
+
+	// A zero salt is the same as none.
+	h, err := NewSalt(make([]byte, SaltSize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Write([]byte("abc"))
+	if want := Sum([]byte("abc")); !bytes.Equal(h.Sum(nil), want[:]) {
+		t.Errorf("Zero salt does not match unsalted hash")
+	}
+
+	// Reset keeps the salt.
+	h, err = NewSalt(hashtest.Seq(32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Write([]byte("garbage"))
+	h.Reset()
+	h.Write([]byte("The quick brown fox jumps over the lazy dog"))
+	if got := fmt.Sprintf("%x", h.Sum(nil)); got != "6a39f393d4553fadc4ef582515acce6da5ad2437f5e5a2dc9908393c806fd399bbf11b275eccb64e7e6146dccb1a49d095e9f2236bd93189b64b15943f08a85f" {
+		t.Errorf("Reset lost the salt: got %v", got)
+	}
+}
+
+func TestHash(t *testing.T) {
+	hashtest.Run(t, func() hashtest.Hash { return New().(*Digest) })
+}
+
+func TestZeroDigest(t *testing.T) {
+	hashtest.Run(t, func() hashtest.Hash { return new(Digest) })
+
+	var d Digest
+	if d.Size() != Size {
+		t.Errorf("Expected size %v", Size)
+		t.Errorf("Got %v", d.Size())
+	}
+
+	d.Reset()
+	d.Write([]byte("abc"))
+	if got, want := d.Sum(nil), Sum([]byte("abc")); !bytes.Equal(got, want[:]) {
+		t.Errorf("Expected %x", want)
+		t.Errorf("Got %x", got)
+	}
+}
+
+// The KAT files contain the messages of the SHA-3 competition. The
+// digests were computed by internal/refgen, except for the BLAKE-512
+// short message digests, which are the published ones and which
+// internal/refgen reproduces.
+func TestKAT(t *testing.T) {
+	sizes := []struct {
+		size    string
+		newHash func() kat.BitHash
+	}{
+		{size: "384", newHash: func() kat.BitHash { return New384().(*Digest) }},
+		{size: "512", newHash: func() kat.BitHash { return New().(*Digest) }},
+	}
+
+	for _, size := range sizes {
+		size := size
+		for _, file := range []string{"ShortMsgKAT", "LongMsgKAT"} {
+			name := file + "_" + size.size
+			t.Run(name, func(t *testing.T) {
+				kat.Run(t, filepath.Join("testdata", name+".txt"), size.newHash)
+			})
+		}
+	}
+}
+
+func BenchmarkWrite(b *testing.B) {
+	hashtest.Benchmark(b, New)
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// options holds the parsed command-line options.
+type options struct {
+	bits    int
+	bitsSet bool
+	check   bool
+	binary  bool
+	text    bool
+	tag     bool
+	zero    bool
+	missing bool
+	quiet   bool
+	status  bool
+	strict  bool
+	warn    bool
+	help    bool
+	version bool
+}
+
+// An option describes a single command-line option. Either short or
+// long may be empty.
+type option struct {
+	short byte
+	long  string
+	arg   string
+	usage string
+
+	// set records the option in o. value is the option's argument if
+	// arg is not empty.
+	set func(o *options, value string) error
+}
+
+func boolOption(short byte, long, usage string, field func(*options) *bool) option {
+	return option{
+		short: short,
+		long:  long,
+		usage: usage,
+		set: func(o *options, value string) error {
+			*field(o) = true
+			return nil
+		},
+	}
+}
+
+var optionTable = []option{
+	{
+		short: 'a',
+		long:  "algorithm",
+		arg:   "BITS",
+		usage: "digest length in bits: 224, 256 (default), 384 or 512",
+		set: func(o *options, value string) error {
+			bits, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid digest length: %q", value)
+			}
+			o.bits, o.bitsSet = bits, true
+			return nil
+		},
+	},
+	boolOption('b', "binary", "read in binary mode", func(o *options) *bool { return &o.binary }),
+	boolOption('c', "check", "read checksums from the files and check them", func(o *options) *bool { return &o.check }),
+	boolOption(0, "tag", "create a BSD-style checksum", func(o *options) *bool { return &o.tag }),
+	boolOption('t', "text", "read in text mode (default)", func(o *options) *bool { return &o.text }),
+	boolOption('z', "zero", "end each output line with NUL, not newline,\nand disable file name escaping", func(o *options) *bool { return &o.zero }),
+	boolOption(0, "ignore-missing", "don't fail or report status for missing files", func(o *options) *bool { return &o.missing }),
+	boolOption(0, "quiet", "don't print OK for each successfully verified file", func(o *options) *bool { return &o.quiet }),
+	boolOption(0, "status", "don't output anything, status code shows success", func(o *options) *bool { return &o.status }),
+	boolOption(0, "strict", "exit non-zero for improperly formatted checksum lines", func(o *options) *bool { return &o.strict }),
+	boolOption('w', "warn", "warn about improperly formatted checksum lines", func(o *options) *bool { return &o.warn }),
+	boolOption(0, "help", "display this help and exit", func(o *options) *bool { return &o.help }),
+	boolOption(0, "version", "output version information and exit", func(o *options) *bool { return &o.version }),
+}
+
+// parseArgs parses args the way GNU getopt_long does. Options and file
+// names may be mixed freely, short options may be bundled together
+// and take their argument either from the rest of the bundle or from
+// the next argument, long options may be abbreviated to any unique
+// prefix and take their argument either after an = or from the next
+// argument, and -- ends the options. A lone - is a file name.
+func parseArgs(args []string) (opts options, files []string, err error) {
+	opts.bits = 256
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			files = append(files, args[i+1:]...)
+			return opts, files, nil
+
+		case strings.HasPrefix(arg, "--"):
+			name, value, hasValue := strings.Cut(arg[2:], "=")
+			opt, err := findLong(name)
+			if err != nil {
+				return opts, nil, err
+			}
+			switch {
+			case (opt.arg == "") && hasValue:
+				return opts, nil, fmt.Errorf("option '--%v' doesn't allow an argument", opt.long)
+			case (opt.arg != "") && !hasValue:
+				if i+1 == len(args) {
+					return opts, nil, fmt.Errorf("option '--%v' requires an argument", opt.long)
+				}
+				i++
+				value = args[i]
+			}
+			if err := opt.set(&opts, value); err != nil {
+				return opts, nil, err
+			}
+
+		case (len(arg) > 1) && (arg[0] == '-'):
+			for j := 1; j < len(arg); j++ {
+				opt, ok := findShort(arg[j])
+				if !ok {
+					return opts, nil, fmt.Errorf("invalid option -- '%c'", arg[j])
+				}
+
+				var value string
+				if opt.arg != "" {
+					switch {
+					case j+1 < len(arg):
+						value = arg[j+1:]
+					case i+1 < len(args):
+						i++
+						value = args[i]
+					default:
+						return opts, nil, fmt.Errorf("option requires an argument -- '%c'", arg[j])
+					}
+					j = len(arg)
+				}
+				if err := opt.set(&opts, value); err != nil {
+					return opts, nil, err
+				}
+			}
+
+		default:
+			files = append(files, arg)
+		}
+	}
+
+	return opts, files, nil
+}
+
+func findShort(c byte) (option, bool) {
+	for _, opt := range optionTable {
+		if (opt.short != 0) && (opt.short == c) {
+			return opt, true
+		}
+	}
+	return option{}, false
+}
+
+// findLong finds the long option called name, which may be an
+// abbreviation of the option's full name as long as it is unambiguous.
+func findLong(name string) (option, error) {
+	var matches []option
+	for _, opt := range optionTable {
+		if opt.long == "" {
+			continue
+		}
+		if opt.long == name {
+			return opt, nil
+		}
+		if (name != "") && strings.HasPrefix(opt.long, name) {
+			matches = append(matches, opt)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return option{}, fmt.Errorf("unrecognized option '--%v'", name)
+	case 1:
+		return matches[0], nil
+	default:
+		var buf strings.Builder
+		for _, opt := range matches {
+			fmt.Fprintf(&buf, " '--%v'", opt.long)
+		}
+		return option{}, fmt.Errorf("option '--%v' is ambiguous; possibilities:%v", name, buf.String())
+	}
+}
+
+// usage returns the help text listing every option in optionTable.
+func usage() string {
+	var buf strings.Builder
+	buf.WriteString("Usage: groestlsum [OPTION]... [FILE]...\n")
+	buf.WriteString("Print or check Groestl checksums.\n\n")
+	buf.WriteString("With no FILE, or when FILE is -, read standard input.\n")
+	for _, opt := range optionTable {
+		var names string
+		switch {
+		case opt.short == 0:
+			names = "    --" + opt.long
+		case opt.long == "":
+			names = "-" + string(opt.short)
+		default:
+			names = "-" + string(opt.short) + ", --" + opt.long
+		}
+		if opt.arg != "" {
+			names += "=" + opt.arg
+		}
+
+		lines := strings.Split(opt.usage, "\n")
+		fmt.Fprintf(&buf, "  %-24v%v\n", names, lines[0])
+		for _, line := range lines[1:] {
+			fmt.Fprintf(&buf, "  %-24v%v\n", "", line)
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,478 @@
+// Command groestlsum prints or checks Groestl checksums.
+//
+// It is a drop-in replacement for coreutils' sha256sum: it accepts the
+// same options, parsed the same GNU way, produces the same output, and
+// reads checksum files in both the default and BSD (--tag) formats.
+//
+// Usage:
+//
+//	groestlsum [options] [file ...]
+//
+// With no file, or when file is -, standard input is read.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"runtime/debug"
+	"strconv"
+	"strings"
+
+	"github.com/DeedleFake/crypto/groestl256"
+	"github.com/DeedleFake/crypto/groestl512"
+)
+
+type algorithm struct {
+	bits int
+	new  func() hash.Hash
+}
+
+var algorithms = []algorithm{
+	{bits: 224, new: groestl256.New224},
+	{bits: 256, new: groestl256.New},
+	{bits: 384, new: groestl512.New384},
+	{bits: 512, new: groestl512.New},
+}
+
+func findAlgorithm(bits int) (algorithm, bool) {
+	for _, alg := range algorithms {
+		if alg.bits == bits {
+			return alg, true
+		}
+	}
+	return algorithm{}, false
+}
+
+func (alg algorithm) tag() string {
+	return "GROESTL-" + strconv.FormatInt(int64(alg.bits), 10)
+}
+
+type command struct {
+	alg     algorithm
+	algSet  bool
+	binary  bool
+	tag     bool
+	zero    bool
+	quiet   bool
+	status  bool
+	strict  bool
+	warn    bool
+	missing bool
+
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	cmd := command{
+		stdin:  stdin,
+		stdout: stdout,
+		stderr: stderr,
+	}
+
+	opts, files, err := parseArgs(args)
+	if err != nil {
+		return cmd.usageError("%v", err)
+	}
+	if opts.help {
+		io.WriteString(stdout, usage())
+		return 0
+	}
+	if opts.version {
+		fmt.Fprintf(stdout, "groestlsum %v\n", version())
+		return 0
+	}
+
+	var ok bool
+	cmd.alg, ok = findAlgorithm(opts.bits)
+	if !ok {
+		return cmd.usageError("invalid digest length: %v", opts.bits)
+	}
+	cmd.algSet = opts.bitsSet
+
+	cmd.binary = opts.binary && !opts.text
+	cmd.tag = opts.tag
+	cmd.zero = opts.zero
+	cmd.quiet = opts.quiet
+	cmd.status = opts.status
+	cmd.strict = opts.strict
+	cmd.warn = opts.warn
+	cmd.missing = opts.missing
+
+	if opts.check {
+		switch {
+		case opts.tag:
+			return cmd.usageError("the --tag option is meaningless when verifying checksums")
+		case opts.binary || opts.text:
+			return cmd.usageError("the --binary and --text options are meaningless when verifying checksums")
+		}
+	} else {
+		switch {
+		case opts.missing:
+			return cmd.usageError("the --ignore-missing option is meaningful only when verifying checksums")
+		case opts.quiet:
+			return cmd.usageError("the --quiet option is meaningful only when verifying checksums")
+		case opts.status:
+			return cmd.usageError("the --status option is meaningful only when verifying checksums")
+		case opts.strict:
+			return cmd.usageError("the --strict option is meaningful only when verifying checksums")
+		case opts.warn:
+			return cmd.usageError("the --warn option is meaningful only when verifying checksums")
+		case opts.tag && opts.text:
+			return cmd.usageError("--tag does not support --text mode")
+		}
+	}
+
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	failed := false
+	for _, name := range files {
+		var ok bool
+		if opts.check {
+			ok = cmd.check(name)
+		} else {
+			ok = cmd.print(name)
+		}
+		failed = failed || !ok
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+// version returns the version of the module that groestlsum was built
+// from, or (devel) if it is not known.
+func version() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || (info.Main.Version == "") {
+		return "(devel)"
+	}
+	return info.Main.Version
+}
+
+func (cmd *command) usageError(format string, args ...any) int {
+	fmt.Fprintf(cmd.stderr, "groestlsum: "+format+"\n", args...)
+	fmt.Fprintf(cmd.stderr, "Try 'groestlsum --help' for more information.\n")
+	return 1
+}
+
+func (cmd *command) errorf(format string, args ...any) {
+	fmt.Fprintf(cmd.stderr, "groestlsum: "+format+"\n", args...)
+}
+
+// open opens the named file, treating - as standard input.
+func (cmd *command) open(name string) (io.ReadCloser, error) {
+	if name == "-" {
+		return io.NopCloser(cmd.stdin), nil
+	}
+	return os.Open(name)
+}
+
+// sum hashes the named file with alg.
+func (cmd *command) sum(alg algorithm, name string) ([]byte, error) {
+	file, err := cmd.open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	h := alg.new()
+	if _, err := io.Copy(h, file); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// print hashes the named file and prints its checksum line.
+func (cmd *command) print(name string) bool {
+	sum, err := cmd.sum(cmd.alg, name)
+	if err != nil {
+		cmd.errorf("%v: %v", name, describe(err))
+		return false
+	}
+
+	escaped := name
+	prefix := ""
+	if !cmd.zero {
+		escaped, prefix = escape(name)
+	}
+
+	end := "\n"
+	if cmd.zero {
+		end = "\x00"
+	}
+
+	if cmd.tag {
+		fmt.Fprintf(cmd.stdout, "%v%v (%v) = %x%v", prefix, cmd.alg.tag(), escaped, sum, end)
+		return true
+	}
+
+	mode := ' '
+	if cmd.binary {
+		mode = '*'
+	}
+	fmt.Fprintf(cmd.stdout, "%v%x %c%v%v", prefix, sum, mode, escaped, end)
+	return true
+}
+
+// check verifies every checksum line in the named file.
+func (cmd *command) check(list string) bool {
+	file, err := cmd.open(list)
+	if err != nil {
+		cmd.errorf("%v: %v", list, describe(err))
+		return false
+	}
+	defer file.Close()
+
+	listName := list
+	if list == "-" {
+		listName = "standard input"
+	}
+
+	var (
+		properly   bool
+		verified   bool
+		improper   int
+		unreadable int
+		mismatched int
+	)
+
+	s := bufio.NewScanner(file)
+	s.Buffer(nil, 1<<20)
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSuffix(s.Text(), "\r")
+		if (text == "") || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		alg, want, name, ok := cmd.parseLine(text)
+		if !ok {
+			improper++
+			if cmd.warn {
+				cmd.errorf("%v: %v: improperly formatted %v checksum line", listName, line, cmd.alg.tag())
+			}
+			continue
+		}
+		properly = true
+
+		got, err := cmd.sum(alg, name)
+		if err != nil {
+			if cmd.missing && errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			unreadable++
+			if !cmd.status {
+				cmd.errorf("%v: %v", name, describe(err))
+				cmd.result(name, "FAILED open or read")
+			}
+			continue
+		}
+		verified = true
+
+		if !bytes.Equal(got, want) {
+			mismatched++
+			if !cmd.status {
+				cmd.result(name, "FAILED")
+			}
+			continue
+		}
+
+		if !cmd.status && !cmd.quiet {
+			cmd.result(name, "OK")
+		}
+	}
+	if err := s.Err(); err != nil {
+		cmd.errorf("%v: %v", listName, describe(err))
+		return false
+	}
+
+	if !properly {
+		cmd.errorf("%v: no properly formatted checksum lines found", listName)
+		return false
+	}
+
+	if !cmd.status {
+		if improper > 0 {
+			cmd.errorf("WARNING: %v", plural(improper, "line is", "lines are", "improperly formatted"))
+		}
+		if unreadable > 0 {
+			cmd.errorf("WARNING: %v", plural(unreadable, "listed file", "listed files", "could not be read"))
+		}
+		if mismatched > 0 {
+			cmd.errorf("WARNING: %v", plural(mismatched, "computed checksum", "computed checksums", "did NOT match"))
+		}
+	}
+
+	if cmd.missing && !verified {
+		if !cmd.status {
+			cmd.errorf("%v: no file was verified", listName)
+		}
+		return false
+	}
+
+	return (unreadable == 0) && (mismatched == 0) && (!cmd.strict || (improper == 0))
+}
+
+// result prints the outcome of checking a single file.
+func (cmd *command) result(name, result string) {
+	escaped, prefix := name, ""
+	if !cmd.zero {
+		escaped, prefix = escape(name)
+	}
+
+	end := "\n"
+	if cmd.zero {
+		end = "\x00"
+	}
+	fmt.Fprintf(cmd.stdout, "%v%v: %v%v", prefix, escaped, result, end)
+}
+
+// parseLine parses a checksum line in either the default or the BSD
+// format. The algorithm of a BSD line is taken from its tag. The
+// algorithm of a default line is the one given with -a or, if -a was
+// not given, the one whose digest length matches the checksum.
+func (cmd *command) parseLine(line string) (alg algorithm, sum []byte, name string, ok bool) {
+	escaped := strings.HasPrefix(line, "\\")
+	if escaped {
+		line = line[1:]
+	}
+
+	if tag, rest, found := strings.Cut(line, " ("); found && strings.HasPrefix(tag, "GROESTL-") {
+		i := strings.LastIndex(rest, ") = ")
+		if i < 0 {
+			return alg, nil, "", false
+		}
+		bits, err := strconv.ParseInt(strings.TrimPrefix(tag, "GROESTL-"), 10, 0)
+		if err != nil {
+			return alg, nil, "", false
+		}
+		alg, ok = findAlgorithm(int(bits))
+		if !ok || (cmd.algSet && (alg.bits != cmd.alg.bits)) {
+			return alg, nil, "", false
+		}
+		name, sum = rest[:i], decodeSum(rest[i+4:], alg)
+	} else {
+		hexSum, rest, found := strings.Cut(line, " ")
+		if !found || (rest == "") || ((rest[0] != ' ') && (rest[0] != '*')) {
+			return alg, nil, "", false
+		}
+		alg = cmd.alg
+		if !cmd.algSet {
+			alg, ok = findAlgorithm(len(hexSum) * 4)
+			if !ok {
+				return alg, nil, "", false
+			}
+		}
+		name, sum = rest[1:], decodeSum(hexSum, alg)
+	}
+	if (sum == nil) || (name == "") {
+		return alg, nil, "", false
+	}
+
+	if escaped {
+		name, ok = unescape(name)
+		if !ok {
+			return alg, nil, "", false
+		}
+	}
+
+	return alg, sum, name, true
+}
+
+// decodeSum decodes a hexadecimal checksum, returning nil if it is not
+// valid for alg.
+func decodeSum(s string, alg algorithm) []byte {
+	if len(s) != alg.bits/4 {
+		return nil
+	}
+	sum, err := hex.DecodeString(s)
+	if err != nil {
+		return nil
+	}
+	return sum
+}
+
+// escape escapes backslashes and line breaks in a file name the way
+// coreutils does. If anything needed escaping, prefix is a backslash
+// that must start the output line.
+func escape(name string) (escaped, prefix string) {
+	if !strings.ContainsAny(name, "\\\n\r") {
+		return name, ""
+	}
+
+	r := strings.NewReplacer("\\", "\\\\", "\n", "\\n", "\r", "\\r")
+	return r.Replace(name), "\\"
+}
+
+// unescape reverses escape.
+func unescape(name string) (string, bool) {
+	var sb strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c != '\\' {
+			sb.WriteByte(c)
+			continue
+		}
+
+		i++
+		if i == len(name) {
+			return "", false
+		}
+		switch name[i] {
+		case '\\':
+			sb.WriteByte('\\')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		default:
+			return "", false
+		}
+	}
+	return sb.String(), true
+}
+
+// describe returns the message that coreutils would print for err.
+func describe(err error) string {
+	var perr *fs.PathError
+	if errors.As(err, &perr) {
+		err = perr.Err
+	}
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return "No such file or directory"
+	case errors.Is(err, fs.ErrPermission):
+		return "Permission denied"
+	}
+
+	msg := err.Error()
+	if strings.HasSuffix(msg, "is a directory") {
+		return "Is a directory"
+	}
+	return msg
+}
+
+func plural(n int, one, many, rest string) string {
+	if n == 1 {
+		return fmt.Sprintf("%v %v %v", n, one, rest)
+	}
+	return fmt.Sprintf("%v %v %v", n, many, rest)
+}
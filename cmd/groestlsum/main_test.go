@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func runTest(t *testing.T, stdin string, args ...string) (stdout, stderr string, code int) {
+	t.Helper()
+
+	var out, errOut bytes.Buffer
+	code = run(args, strings.NewReader(stdin), &out, &errOut)
+	return out.String(), errOut.String(), code
+}
+
+func writeFile(t *testing.T, name, data string) {
+	t.Helper()
+
+	if err := os.WriteFile(name, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPrint(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeFile(t, "empty", "")
+	writeFile(t, "back\\slash", "")
+
+	const empty256 = "1a52d11d550039be16107f9c58db9ebcc417f16f736adb2502567119f0083467"
+	const empty224 = "f2e180fb5947be964cd584e22e496242c6a329c577fc4ce8c36d34c3"
+
+	tests := []struct {
+		name  string
+		stdin string
+		args  []string
+		out   string
+		code  int
+	}{
+		{name: "Default", args: []string{"empty"}, out: empty256 + "  empty\n"},
+		{name: "Stdin", args: nil, out: empty256 + "  -\n"},
+		{name: "Binary", args: []string{"-b", "empty"}, out: empty256 + " *empty\n"},
+		{name: "224", args: []string{"-a", "224", "empty"}, out: empty224 + "  empty\n"},
+		{name: "Tag", args: []string{"--tag", "empty"}, out: "GROESTL-256 (empty) = " + empty256 + "\n"},
+		{name: "Zero", args: []string{"--zero", "empty", "back\\slash"}, out: empty256 + "  empty\x00" + empty256 + "  back\\slash\x00"},
+		{name: "Escaped", args: []string{"back\\slash"}, out: "\\" + empty256 + "  back\\\\slash\n"},
+		{name: "Missing", args: []string{"missing", "empty"}, out: empty256 + "  empty\n", code: 1},
+		{name: "BadSize", args: []string{"-a", "100", "empty"}, code: 1},
+		{name: "TagText", args: []string{"--tag", "-t", "empty"}, code: 1},
+		{name: "QuietWithoutCheck", args: []string{"--quiet", "empty"}, code: 1},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			out, _, code := runTest(t, test.stdin, test.args...)
+			if (out != test.out) || (code != test.code) {
+				t.Errorf("Expected %q, %v", test.out, test.code)
+				t.Errorf("Got %q, %v", out, code)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeFile(t, "a", "hello\n")
+	writeFile(t, "b", "world\n")
+	writeFile(t, "back\\slash", "")
+
+	var list strings.Builder
+	for _, args := range [][]string{
+		{"a"},
+		{"-a", "512", "b"},
+		{"--tag", "-a", "384", "a"},
+		{"back\\slash"},
+	} {
+		out, _, code := runTest(t, "", args...)
+		if code != 0 {
+			t.Fatalf("groestlsum %v failed", args)
+		}
+		list.WriteString(out)
+	}
+	writeFile(t, "sums", list.String())
+
+	out, _, code := runTest(t, "", "-c", "sums")
+	if want := "a: OK\nb: OK\na: OK\n\\back\\\\slash: OK\n"; (out != want) || (code != 0) {
+		t.Errorf("Expected %q, 0", want)
+		t.Errorf("Got %q, %v", out, code)
+	}
+
+	out, _, code = runTest(t, list.String(), "-c", "--quiet")
+	if (out != "") || (code != 0) {
+		t.Errorf("Quiet: got %q, %v", out, code)
+	}
+
+	writeFile(t, "b", "changed\n")
+	out, errOut, code := runTest(t, "", "-c", "sums")
+	if want := "a: OK\nb: FAILED\na: OK\n\\back\\\\slash: OK\n"; (out != want) || (code != 1) {
+		t.Errorf("Expected %q, 1", want)
+		t.Errorf("Got %q, %v", out, code)
+	}
+	if want := "groestlsum: WARNING: 1 computed checksum did NOT match\n"; errOut != want {
+		t.Errorf("Expected stderr %q", want)
+		t.Errorf("Got %q", errOut)
+	}
+
+	out, errOut, code = runTest(t, "", "-c", "--status", "sums")
+	if (out != "") || (errOut != "") || (code != 1) {
+		t.Errorf("Status: got %q, %q, %v", out, errOut, code)
+	}
+}
+
+func TestCheckMalformed(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeFile(t, "a", "hello\n")
+
+	sum, _, _ := runTest(t, "", "a")
+	writeFile(t, "sums", sum+"not a checksum line\n")
+	writeFile(t, "garbage", "garbage\n")
+
+	_, errOut, code := runTest(t, "", "-c", "sums")
+	if code != 0 {
+		t.Errorf("Expected success without --strict, got %v", code)
+	}
+	if want := "groestlsum: WARNING: 1 line is improperly formatted\n"; errOut != want {
+		t.Errorf("Expected stderr %q", want)
+		t.Errorf("Got %q", errOut)
+	}
+
+	_, errOut, code = runTest(t, "", "-c", "-w", "--strict", "sums")
+	if code != 1 {
+		t.Errorf("Expected failure with --strict, got %v", code)
+	}
+	if !strings.Contains(errOut, "sums: 2: improperly formatted GROESTL-256 checksum line") {
+		t.Errorf("Missing warning in %q", errOut)
+	}
+
+	_, errOut, code = runTest(t, "", "-c", "garbage")
+	if (code != 1) || !strings.Contains(errOut, "no properly formatted checksum lines found") {
+		t.Errorf("Garbage: got %q, %v", errOut, code)
+	}
+}
+
+func TestCheckMissing(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeFile(t, "a", "hello\n")
+
+	sum, _, _ := runTest(t, "", "a")
+	writeFile(t, "sums", sum+strings.Replace(sum, "  a", "  gone", 1))
+
+	out, errOut, code := runTest(t, "", "-c", "sums")
+	if want := "a: OK\ngone: FAILED open or read\n"; (out != want) || (code != 1) {
+		t.Errorf("Expected %q, 1", want)
+		t.Errorf("Got %q, %v", out, code)
+	}
+	if !strings.Contains(errOut, "WARNING: 1 listed file could not be read") {
+		t.Errorf("Missing warning in %q", errOut)
+	}
+
+	out, _, code = runTest(t, "", "-c", "--ignore-missing", "sums")
+	if (out != "a: OK\n") || (code != 0) {
+		t.Errorf("Ignore missing: got %q, %v", out, code)
+	}
+}
+
+func TestArgs(t *testing.T) {
+	t.Chdir(t.TempDir())
+	writeFile(t, "a.txt", "hello\n")
+	writeFile(t, "-b", "")
+
+	sum, _, _ := runTest(t, "", "a.txt")
+	writeFile(t, "sums", sum+"not a checksum line\n")
+	tagged, _, _ := runTest(t, "", "--tag", "a.txt")
+	sum512, _, _ := runTest(t, "", "-a", "512", "a.txt")
+	dash, _, _ := runTest(t, "", "--", "-b")
+
+	tests := []struct {
+		name string
+		args []string
+		out  string
+		err  string
+		code int
+	}{
+		{name: "Permuted", args: []string{"a.txt", "--tag"}, out: tagged},
+		{name: "PermutedShort", args: []string{"a.txt", "-a", "512"}, out: sum512},
+		{name: "Bundled", args: []string{"-cw", "--strict", "sums"}, out: "a.txt: OK\n", err: "improperly formatted", code: 1},
+		{name: "BundledZero", args: []string{"-cz", "sums"}, out: "a.txt: OK\x00"},
+		{name: "BundledArg", args: []string{"-ba512", "a.txt"}, out: strings.Replace(sum512, "  ", " *", 1)},
+		{name: "AttachedArg", args: []string{"-a512", "a.txt"}, out: sum512},
+		{name: "Terminator", args: []string{"--", "-b"}, out: dash},
+		{name: "TerminatorAfterFile", args: []string{"a.txt", "--", "--tag"}, out: sum, err: "--tag", code: 1},
+		{name: "LongValue", args: []string{"--algorithm=512", "a.txt"}, out: sum512},
+		{name: "LongSeparateValue", args: []string{"--algorithm", "512", "a.txt"}, out: sum512},
+		{name: "Abbreviated", args: []string{"--ch", "--stat", "sums"}},
+		{name: "Ambiguous", args: []string{"--st", "sums"}, err: "option '--st' is ambiguous; possibilities: '--status' '--strict'", code: 1},
+		{name: "Unrecognized", args: []string{"--bogus"}, err: "unrecognized option '--bogus'", code: 1},
+		{name: "InvalidShort", args: []string{"-cq"}, err: "invalid option -- 'q'", code: 1},
+		{name: "NoShortHelp", args: []string{"-h"}, err: "invalid option -- 'h'", code: 1},
+		{name: "MissingArg", args: []string{"-a"}, err: "option requires an argument -- 'a'", code: 1},
+		{name: "MissingLongArg", args: []string{"--algorithm"}, err: "option '--algorithm' requires an argument", code: 1},
+		{name: "UnexpectedLongArg", args: []string{"--tag=yes"}, err: "option '--tag' doesn't allow an argument", code: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, errOut, code := runTest(t, "", test.args...)
+			if (out != test.out) || (code != test.code) {
+				t.Errorf("Expected %q, %v", test.out, test.code)
+				t.Errorf("Got %q, %v", out, code)
+			}
+			if !strings.Contains(errOut, test.err) {
+				t.Errorf("Expected stderr to contain %q", test.err)
+				t.Errorf("Got %q", errOut)
+			}
+		})
+	}
+}
+
+func TestHelp(t *testing.T) {
+	for _, arg := range []string{"--help", "--he"} {
+		out, _, code := runTest(t, "", arg)
+		if (code != 0) || !strings.Contains(out, "-c, --check") || !strings.Contains(out, "-a, --algorithm=BITS") {
+			t.Errorf("%v: got %q, %v", arg, out, code)
+		}
+	}
+}
+
+func TestVersion(t *testing.T) {
+	for _, arg := range []string{"--version", "--ve"} {
+		out, _, code := runTest(t, "", arg)
+		if (code != 0) || !strings.HasPrefix(out, "groestlsum ") {
+			t.Errorf("%v: got %q, %v", arg, out, code)
+		}
+	}
+}
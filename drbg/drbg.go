@@ -0,0 +1,234 @@
+// Package drbg implements the Hash_DRBG and HMAC_DRBG deterministic
+// random bit generators of NIST SP 800-90A Rev. 1, instantiated with
+// the Groestl hash functions.
+//
+// Given the same inputs, a DRBG always produces the same output, which
+// makes it suitable for reproducible test fixtures and deterministic
+// key generation. Its security rests entirely on the secrecy and
+// entropy of the inputs that it is seeded with.
+package drbg
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/DeedleFake/crypto/groestlhmac"
+)
+
+const (
+	// MaxRequest is the largest number of bytes that can be produced
+	// by a single call to Generate.
+	MaxRequest = 1 << 16
+
+	// MaxReseedInterval is the largest number of requests that SP
+	// 800-90A allows between reseeds.
+	MaxReseedInterval = 1 << 48
+)
+
+var (
+	// ErrReseedRequired is returned by Generate when the reseed
+	// interval has been reached and there is no entropy source to
+	// reseed from.
+	ErrReseedRequired = errors.New("drbg: reseed required")
+
+	// ErrNoEntropy is returned when prediction resistance is requested
+	// without an entropy source.
+	ErrNoEntropy = errors.New("drbg: prediction resistance requires an entropy source")
+
+	// ErrPredictionResistance is returned by Generate when prediction
+	// resistance is requested from a DRBG that was not instantiated
+	// with it.
+	ErrPredictionResistance = errors.New("drbg: prediction resistance was not enabled at instantiation")
+
+	// ErrRequestTooLarge is returned by Generate when more than
+	// MaxRequest bytes are requested.
+	ErrRequestTooLarge = errors.New("drbg: request too large")
+)
+
+// Options configures a DRBG. A nil *Options is the same as the zero
+// value.
+type Options struct {
+	// Entropy, if not nil, is read from whenever the DRBG has to reseed
+	// itself: when prediction resistance is requested and when the
+	// reseed interval has been reached. Without it, Reseed has to be
+	// called with fresh entropy instead.
+	Entropy io.Reader
+
+	// ReseedInterval is the number of requests that can be made before
+	// a reseed is required. Zero means MaxReseedInterval.
+	ReseedInterval uint64
+
+	// PredictionResistance instantiates the DRBG with prediction
+	// resistance, which Generate can then be asked for, and requests it
+	// for every call to Read. It requires Entropy.
+	PredictionResistance bool
+}
+
+// mechanism is one of the DRBG mechanisms, without the bookkeeping
+// common to all of them.
+type mechanism interface {
+	reseed(entropy, additional []byte)
+	generate(out, additional []byte, counter uint64)
+}
+
+// DRBG is a deterministic random bit generator. It is not safe for
+// concurrent use.
+type DRBG struct {
+	mech     mechanism
+	strength int
+	opts     Options
+	counter  uint64
+}
+
+var _ io.Reader = (*DRBG)(nil)
+
+// NewHash instantiates a Hash_DRBG using h. The entropy input must be
+// at least as long as the security strength of h, which is 24 bytes for
+// Groestl-224 and 32 bytes for the others, and the nonce must be at
+// least half that length, as required by SP 800-90A. The personalization
+// string is optional.
+func NewHash(h groestlhmac.Hash, entropy, nonce, personalization []byte, opts *Options) (*DRBG, error) {
+	d, err := newDRBG(h, entropy, nonce, opts)
+	if err != nil {
+		return nil, err
+	}
+	d.mech = newHashDRBG(h, entropy, nonce, personalization)
+	return d, nil
+}
+
+// NewHMAC instantiates an HMAC_DRBG using h. The requirements on its
+// arguments are the same as for NewHash.
+func NewHMAC(h groestlhmac.Hash, entropy, nonce, personalization []byte, opts *Options) (*DRBG, error) {
+	d, err := newDRBG(h, entropy, nonce, opts)
+	if err != nil {
+		return nil, err
+	}
+	d.mech = newHMACDRBG(h, entropy, nonce, personalization)
+	return d, nil
+}
+
+func newDRBG(h groestlhmac.Hash, entropy, nonce []byte, opts *Options) (*DRBG, error) {
+	if !h.Available() {
+		return nil, fmt.Errorf("drbg: unknown hash function %v", h)
+	}
+	if err := selfTest(); err != nil {
+		return nil, err
+	}
+
+	d := DRBG{
+		strength: securityStrength(h),
+		counter:  1,
+	}
+	if opts != nil {
+		d.opts = *opts
+	}
+	if d.opts.ReseedInterval == 0 {
+		d.opts.ReseedInterval = MaxReseedInterval
+	}
+
+	if d.opts.ReseedInterval > MaxReseedInterval {
+		return nil, fmt.Errorf("drbg: reseed interval %v is too large", d.opts.ReseedInterval)
+	}
+	if d.opts.PredictionResistance && (d.opts.Entropy == nil) {
+		return nil, ErrNoEntropy
+	}
+	if err := d.checkEntropy(entropy); err != nil {
+		return nil, err
+	}
+	if len(nonce) < d.strength/2 {
+		return nil, fmt.Errorf("drbg: nonce is %v bytes, need at least %v", len(nonce), d.strength/2)
+	}
+
+	return &d, nil
+}
+
+// securityStrength returns the security strength in bytes that h
+// supports, following the corresponding SHA-2 functions in SP 800-57.
+func securityStrength(h groestlhmac.Hash) int {
+	if h == groestlhmac.Groestl224 {
+		return 24
+	}
+	return 32
+}
+
+func (d *DRBG) checkEntropy(entropy []byte) error {
+	if len(entropy) < d.strength {
+		return fmt.Errorf("drbg: entropy input is %v bytes, need at least %v", len(entropy), d.strength)
+	}
+	return nil
+}
+
+// Reseed reseeds the DRBG with fresh entropy and optional additional
+// input. The entropy input has the same length requirement as when
+// instantiating.
+func (d *DRBG) Reseed(entropy, additional []byte) error {
+	if err := d.checkEntropy(entropy); err != nil {
+		return err
+	}
+
+	d.mech.reseed(entropy, additional)
+	d.counter = 1
+	return nil
+}
+
+// reseedFromSource reseeds the DRBG from the entropy source.
+func (d *DRBG) reseedFromSource(additional []byte) error {
+	entropy := make([]byte, d.strength)
+	if _, err := io.ReadFull(d.opts.Entropy, entropy); err != nil {
+		return fmt.Errorf("drbg: read entropy: %w", err)
+	}
+	return d.Reseed(entropy, additional)
+}
+
+// Generate fills out with pseudorandom bytes, mixing in optional
+// additional input. If predictionResistance is true, the DRBG first
+// reseeds itself from its entropy source, which is only allowed if it
+// was instantiated with prediction resistance. It also does so when the
+// reseed interval has been reached, and fails with ErrReseedRequired
+// if it has no entropy source. At most MaxRequest bytes can be
+// generated at a time.
+func (d *DRBG) Generate(out, additional []byte, predictionResistance bool) error {
+	if len(out) > MaxRequest {
+		return ErrRequestTooLarge
+	}
+
+	if predictionResistance && !d.opts.PredictionResistance {
+		return ErrPredictionResistance
+	}
+
+	if predictionResistance || (d.counter > d.opts.ReseedInterval) {
+		if d.opts.Entropy == nil {
+			return ErrReseedRequired
+		}
+
+		if err := d.reseedFromSource(additional); err != nil {
+			return err
+		}
+		additional = nil
+	}
+
+	d.mech.generate(out, additional, d.counter)
+	d.counter++
+	return nil
+}
+
+// Read fills p with pseudorandom bytes, making as many requests as
+// needed. Prediction resistance is requested for every one of them if
+// the DRBG was configured for it.
+func (d *DRBG) Read(p []byte) (n int, err error) {
+	for n < len(p) {
+		chunk := p[n:min(len(p), n+MaxRequest)]
+		if err := d.Generate(chunk, nil, d.opts.PredictionResistance); err != nil {
+			return n, err
+		}
+		n += len(chunk)
+	}
+	return n, nil
+}
+
+// ReseedCounter returns the number of requests made since the DRBG
+// was last seeded, plus one, as defined by SP 800-90A.
+func (d *DRBG) ReseedCounter() uint64 {
+	return d.counter
+}
@@ -0,0 +1,264 @@
+package drbg
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/DeedleFake/crypto/groestlhmac"
+)
+
+var constructors = map[string]func(groestlhmac.Hash, []byte, []byte, []byte, *Options) (*DRBG, error){
+	"Hash": NewHash,
+	"HMAC": NewHMAC,
+}
+
+// The expected outputs were computed by internal/refgen, whose
+// mechanisms are written from NIST SP 800-90A Rev. 1, with
+//
+//	go run ./internal/refgen drbg
+//
+// The inputs are
+// generated by pattern, each one as long as the security strength of
+// the hash function except for the nonce, which is half as long.
+//
+// In the NoReseed and Reseed tests, two requests are made with
+// additional inputs 6 and 7, and Reseed reseeds with entropy 4 and
+// additional input 5 before them. The PR tests make both requests
+// with prediction resistance, reseeding from entropies 8 and 9 with
+// additional inputs 6 and 7. Only NoReseed lacks a personalization
+// string.
+var tests = []struct {
+	hash    groestlhmac.Hash
+	mech    string
+	variant string
+	out     string
+}{
+	{groestlhmac.Groestl224, "Hash", "NoReseed", "62747c918838fd70470968dab302fb6cb4a248c653bbb8fefa3465295b9b2b89b5740015812d75e3f9aeda14497f17b474a792ac445b486dfda8f4a4f469f2b3b1414d5bf10be14077989f7efb60511dd4a09a288e27847c4c618a235944fdfccce46a3392113493ad2207d945a6be1e"},
+	{groestlhmac.Groestl224, "Hash", "Reseed", "d61c5f609c7ae0b960e2ae92c09ae4ef91c95bd4fe9814fab69cd3ae827d657f4a98ce8e8247d16b0fc7e956ef7d10027fc5c95dfdaf65441b4bc4013fce7d1f39e5000ebb944b7b2b5bdb1addcedc96dc3b715caf69377729a1db40397594698512c2018ac5c61509433f2b95a4b4cc"},
+	{groestlhmac.Groestl224, "Hash", "PR", "42ddfb777ae88e9afa402b91f543758851872b5e59b9d67b2a6f96527fdb1eddf7daf05b72a271ea4245ceeec07793d0d64c1c51dcf634f822b38a33c2275a446c5ffc0672e2af255248308ecd5a3bc3998156b694c18ab4e8a32c9991053f9aca080134ed1adb9c699672403104141a"},
+	{groestlhmac.Groestl224, "HMAC", "NoReseed", "976c46193e72e38f4d659cf7c62236773970ef2c17caab58637201f9d383f45c861bd0a3c78c1562d5aa90c90784d88055863dc904a5ada0536c8c0b7c5f82641fc4f15c2026133d3b980098b2e89fd349212d09a207f94c0e93492300ea1ec8697d82144b01b0856e5a35e74a8e3382"},
+	{groestlhmac.Groestl224, "HMAC", "Reseed", "b204bc695a64c4b5e93f60d9f41841e1bb3fd4e3bbbf6820495e8007a711fd8166adee68ba2476df68f8b84ea9f64733045749daaeec483f38900792bb091aabe3c0629266325f471c8a6552a608ffd66c0db5a6912e1558ab142b001ba27aa427d099709288441e96cefcb5132b581c"},
+	{groestlhmac.Groestl224, "HMAC", "PR", "45aee3e40c3cba7b85d5b395e2bd64a20aba2e17487e972da1c6fcd461feb120437c2119ac3bafca7ecaab012f948df31cc5357d68cdd9688e934545135e02928edbd862200182b5a7ac4594d33c7d03cb5143c208cfb9c1428516d8968e7d04e7c2cd32a73a5d06aa437fe90a27895f"},
+	{groestlhmac.Groestl256, "Hash", "NoReseed", "fbbc4bbd25f6b61ca7b6bd56a901c73f9978cd85e599d83b2a4f73746f9a9ede91af9e74c8d794035c9e6ba1a5293d4d2fd392456419a2c95eb7be5f8ed9ac2c8402f328e3c91e9faf92fd6843b2e96b19c1b6f8509f5d3119feff6c251521c67e050f72432e453660d2fa2f8d8fb3fbea01fbfa452c3183cd816026d4e8a6fc"},
+	{groestlhmac.Groestl256, "Hash", "Reseed", "bee74c62ad79c34e5cc82e41a0e8005d309606582f2814430bbf1dbb38d59c0f547ae7cfcb56e1c214092577cb69a88ce25c458b9bf49aeddbb733cd4a2d28ae656140af1c044825d2b0c1a006e840578f96ed2c81981d9bdc6d441463b96d093eba7507be2d9296c4935f05cb6a3acffcdf8e8f261fdbda864fe55172d22c5f"},
+	{groestlhmac.Groestl256, "Hash", "PR", "bd97717dfd9f9da7a12bcd0c4226e3e2114771ba047300662103b40cad157a9db575701d4069346708f115f0ac550627e2faa2a8c6c3dbf28af1542f72d585e5a1efc7a10bf6d343857e23b784495611a7f04f1776fc3cd96a18d0cd051611e2f5885c2f72ad19b5f24b4b530202219e27417cda30866d5abf9330c8c535bc7b"},
+	{groestlhmac.Groestl256, "HMAC", "NoReseed", "20ce58121b6fe20882de45ef5a91a39492dd7b01835a1aff1bebf52d3edea2a63cd0cda7bf1de08a254600ef87e9eb9a30014c4a6f3fccdea4e1e6b89f7ae525921dd0f9c96ea272de28f4352cb0923ab16d63e0e6b74cb6063fedd13b170bdc4666b58bca9225661234edcadd6384580be083405a08d2b56595d7b92a7549ba"},
+	{groestlhmac.Groestl256, "HMAC", "Reseed", "d2d0442260f739e3c9360f77fa6b17c0294a20254e9f1c9dd4e4e29eea9fbaf08b4e2b683b623f82b10c2ddd8c17b98482b8ed6414e832d0dcb2318f9823b07df2b447d8a5301b8f9564e7710dff125aa373b731c16af4a0f08f74397790a1e5b9a2bd86a966e41cec01539fc98987875c7410f93e7f96d3e04e73ac82425d33"},
+	{groestlhmac.Groestl256, "HMAC", "PR", "cbea1fdbd3b21c74a702bb4a8e826f4aaaf226f61fb0d3f37611c55a0686cf616cc66e2c1005a52ca290d44ddec6b2376d43a35471792410ff702a32f15a8fa2a8d950093648e91f5a6c5d3b669fc0bb0496c69a6650a05df33f05c6763149162f084c6256b4176dd1a98c7cbbc7a6ced3c93d2e6f5b9551227abb65d42400b1"},
+	{groestlhmac.Groestl384, "Hash", "NoReseed", "c327166dc8b6fd1082673818f54f09d033c73d10d81718212aa0d8081abd7c5c6857062eb8957ea8b4e509acd2953a3f5aab2ddfc7f84c088f135b3d4abebc292634f35df64575443f11eecae2f7b7ff7620cac1e9997259763d7bc5f2d9675a7bc1d62b3d45990dbe9f2272515289faa9e8fb2159600c3159b7a0b0c29da29d250aa2ef608724f471aa56953baafda9b6a1ceffcc105ed4c30084058c4ff18d6fe3230980bde1a78ae392dca126102f5d7b24413122489a3a5e673766f5d181"},
+	{groestlhmac.Groestl384, "Hash", "Reseed", "fdd6fb94d1604495c7ce94b3728419344234880d9adc26dc4de70b8fae0dd1a8f0155d182e2c56ef5e9261ed71880bffe65ce15ed7e9a6c7688ba63599ae5d62eee773c7cd551cfa06546033bdff08e6f20b04d8434cdf3400e48b50cb5b50bbab3c6b90c07b8d2c43b80d7c4b4aeb955da06876c0f8cf0751dfe0e993e766be3050a486be4f048b535f3da1e76fd59384be42af1b17ca6da48821ff4003513b07de959ccbc590556ad5c920574a72c8ecec41c2786c6b5e8c86c03485fa4bf8"},
+	{groestlhmac.Groestl384, "Hash", "PR", "e9cfc959b2ec8e8aa526186981b230321a9af657d2cdd2f78226298fae87c6181b1ee14032a24fbde43c46e786293269c22ca44c2a3f81638fc7c3189a4c7ac22da93b40994e31fbe9db90e9cd7d1d0738328eaac40ec43128a9be6338eb76fb715fd6a80ceda24eeb47d17d51139e868fa97d5a189e9fb4a911cd39b6e4e96340b2e0858d55025e5f0f4c725e7ef9c5f448bbf4da554f14778c9978a2d3f99dd08739d83b1b534bbb892a8155b58b16d755afb452fbbfc327dc854888ec1460"},
+	{groestlhmac.Groestl384, "HMAC", "NoReseed", "ca55bdf8b673bc1447eebb254acf304aa3d47d6cd4818975967de68f867d826f63c590d3252903cb531ef651d14b445a72800c5e9acfc7802a1f75e9f1fac573dc5ec75c2100a1ff60c88b879a991ef2ab8a675abdf8f6f1cf0bf868405f3aa4ffff41f0cbf9d0776556998764cb01a1efa44a7ebb0f27f9b90bbb9079cd42e959be66485a9e2cf51029224e426ff43e710db08d0928d3089754758293e58f201edee247f007d2f3ea7ebea9969ba75943ff610dd3ba61e2428b22353e58adac"},
+	{groestlhmac.Groestl384, "HMAC", "Reseed", "b8fc3a5fb3f97cd419873bf8e5482bf1df4ff47d872db497c5e5281ee52554f0655f79b3d80186b7e344ed9c396fa8fcad2e177770bc5f78cd4073e80af0b907dead4a8204503555c22e2be8ab26de1b4c1eeadfb08620109eb4f910f4e845776396df043481de6392c303b8681d0c98395c083ed4b69abef7a957edb0b69099587a91a61133925152b8480dcf1dfd5b607327969add5e76970017d019f43fe71317f5b815f94bfc4ec495b83691b0138e257eddf87d979492faf5532da72259"},
+	{groestlhmac.Groestl384, "HMAC", "PR", "ea732947397a23fe1b8f804b75105b35eb30230af42d0255fcd55046e927b019762b17f508739790404cf892a508168f66ac3d296b6d1e9f0c63c8c19c748d7e3172eca36b7ac7ea651a0d0e2efc5b06c141ddb40dced7e65b388109b661d7a92b87d3df3c447ca8a2eba34642086637be8e4617a9c8e9ff7d4e47fcd4298c6a80025926cf57b57c3c89d22c27794fcb59b7231a2900f6a54b77f7ed9151a54eea7c9379ada3fb59d97083b3d6f68934d9a41d1dd49dc49d85fa02905f9ef734"},
+	{groestlhmac.Groestl512, "Hash", "NoReseed", "e32470df1d35b634d71e1d7773ed7709823b650c18406c8c5da4acf0e4499b4e79edb32510988900b77efb6d70fc1dc737441056088e9850873245812ef0a009b771c729b20447ef66c94ab2b0669bfe0b2a08bdea260ac2e3f0a1d15c5b5c3c294291c2a21aff490b86b9949e5e8da2ce635c42432d556f65390544e3dd415064f23351188e977df890d5061b83abfbe50d55125e07ec6df19e9812364f32fa1f4124951466eb55c597e546e75c4b74546f146aa6fa94da9e49892e43aa06c0f244eed0076ec6f6e51a39916a5e47e207aed4ec09ace938c55506df813dbcc1677b35e52c305a3c4f82f63ceafe37c2a67a294913b903d72af2c506608985a0"},
+	{groestlhmac.Groestl512, "Hash", "Reseed", "221b3a43c90d005cd55a94758b8f0fe290d7aea9374065cbe1615e13f49729f3320d960f790db09c061489a79041de1bec24b3af4916094c8584e2bec94e09a8711209458462cbd7c637a3ea6606670476ae4649b25860f5468f275b617fdba390781472bfb91f833d824faf4f091171a0dab4ba76e739788aa253cf8107129c8847e02c2d2d44afe2b69010b61bb6e286bca5d890fb5b34969bfb0adb86125713314c5f550515cfd69dd3c08489cc4fd4779c9901b56ae3cc7392029439744988f913c8bda084c5181b654616e717242492e0d71297e2533ba27123499759d21df591b58a053e1266e23dcf52cf8bbd5cc84913abe25e0180babcecea51bd93"},
+	{groestlhmac.Groestl512, "Hash", "PR", "cb05a3873e2d307a78d8687ba5a0d709ea583ce801475b13b5c1f7673bdde098acfe7c1ca95c13dc93727de6e4b489a65ec9fc68e4346d4914a6a29226c7b048e0ad284a31e583c68ec9a38bcb0984dca9ae16f7f970b32c64becc6199d981f0b72d4230fc63798d07bc49c571740c0469d8f1a83466e3726a76093031c259c8e0980b555e31aa6e0530b38a4884c60b75f9465c703f4d5dcc4e64229292b4e172057530de5f9f172a466a34fa6fa1a77b2d91c5cd38d43d9ae7dcdad8f53c833f8397e634a6c56906430449fee99783ffd1dfe69739b0c70c99d562597c12c8f4e6ad6f3420e2f3e71fd6c3e312c0c70ac4ec17ace3a1b63471abc6540b7a24"},
+	{groestlhmac.Groestl512, "HMAC", "NoReseed", "cbc9908a5ad80228849ef59a77bb9b423953b35eb305165485db05d52cac0c12ffa6d1187fab714b163e50a6a2d35b42af1f11016452eaba09260bad6d3274ce04e5057c1b3070fba9a13669e1cbcc6773682ae293dccfb64d58769cd83596589e903ee1678dd0a3a5251cc27fc83c8f78c97dbb412c1160703cd4fa94e196892e90c82cd018c2821bc8451809829a02bed848cb92bf3fb81fea01b749de3e22ebb84965f7c30381bc81801f194ef4dd271e3ac82e69f97ce32cff7ada4b282e5a68d25de3a4245795ec9f526056486a9cc64b1f2af89608417a6239f9f2e2eff44271e117f2de348c31bb1685520fb80ed5f7b90b752ad25e3eeecdb38b35d4"},
+	{groestlhmac.Groestl512, "HMAC", "Reseed", "67c5b21009fffc3b48f9c8b073699e754b901148ea420fd4f58bfeaec57e920925c7f6a204b9a6680c76757a02a226789b62e8307355d644c1bd132872fc271171d94a5855c8824d17dc99d4caa72f25b521777912b6598bea930550ebac5b01b8ddfa4bf6455b7fb4e4b67ef42c1040085a1f6e4c19758847de8d29267afac6a1f8235dd1f16f73f4f2b60fdf9a82dd9aaa5f7ee6fc74967b258db3ad67063b9009a453ae2340cda738e3236b31c78e9932d64a97fcf2be7bde05bf026d74b9eed84be3cd2e4dd983a51000dd8842d34840ae3157c1a3135762ac6a26ac4c6715bb7fc576e3d4f244b12466619b837f457333b876eed50f2fc6647e30cfa38c"},
+	{groestlhmac.Groestl512, "HMAC", "PR", "7ac6ed18be185c2ebb813c465e3c372dfb9b7cfd441ce8b24b751f3768ff4206c1329b81a6f64b1d847a541d18d118d9002b0fe80d0ee6a08bcd80a4d71b9660dfea624dfc7b72790878e00901a3ff550f9e5de6e3cb77288caa59b0910d4c7f1bd6e610bc37af9d7ac9ccc8e8739695fc82fb2b759b1d0192605c2b4e719c4e9f06a575ae8f238c9adea9677e8f3d18365d0d460f4920fa15edc8a42a083aef26ec7dee25db2b42a45de1d1712d1d26c44218287b1abd038dc1a800532468d45312e1d5bc94248811cd0ae0dba0caad5e9b14f493e8e7e594206170563fe2f08077e16aaaa52429f86d44d9317bad146a16e7f09b5c91143fd51e35feaafb4c"},
+}
+
+func TestDRBG(t *testing.T) {
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%v/%v/%v", test.hash, test.mech, test.variant), func(t *testing.T) {
+			strength := securityStrength(test.hash)
+			want, _ := hex.DecodeString(test.out)
+
+			var personalization []byte
+			if test.variant != "NoReseed" {
+				personalization = pattern(3, strength)
+			}
+
+			var opts Options
+			if test.variant == "PR" {
+				opts.Entropy = bytes.NewReader(append(pattern(8, strength), pattern(9, strength)...))
+				opts.PredictionResistance = true
+			}
+
+			d, err := constructors[test.mech](test.hash, pattern(1, strength), pattern(2, strength/2), personalization, &opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if test.variant == "Reseed" {
+				if err := d.Reseed(pattern(4, strength), pattern(5, strength)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			out := make([]byte, len(want))
+			pr := test.variant == "PR"
+			if err := d.Generate(out, pattern(6, strength), pr); err != nil {
+				t.Fatal(err)
+			}
+			if err := d.Generate(out, pattern(7, strength), pr); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(out, want) {
+				t.Errorf("Expected %x", want)
+				t.Errorf("Got %x", out)
+			}
+
+			counter := uint64(3)
+			if pr {
+				counter = 2
+			}
+			if c := d.ReseedCounter(); c != counter {
+				t.Errorf("Expected reseed counter %v, got %v", counter, c)
+			}
+		})
+	}
+}
+
+func TestSelfTest(t *testing.T) {
+	if err := SelfTest(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConstantTime(t *testing.T) {
+	for _, h := range []groestlhmac.Hash{groestlhmac.Groestl224, groestlhmac.Groestl256, groestlhmac.Groestl384, groestlhmac.Groestl512} {
+		m := newHashDRBG(h, pattern(1, 32), pattern(2, 16), nil)
+		ct, ok := m.h.(interface{ ConstantTime() bool })
+		if !ok || !ct.ConstantTime() {
+			t.Errorf("%v: Hash_DRBG does not use the constant-time digest", h)
+		}
+	}
+}
+
+func TestRead(t *testing.T) {
+	for name, newDRBG := range constructors {
+		t.Run(name, func(t *testing.T) {
+			d1, err := newDRBG(groestlhmac.Groestl512, pattern(1, 32), pattern(2, 16), nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			d2, err := newDRBG(groestlhmac.Groestl512, pattern(1, 32), pattern(2, 16), nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			buf := make([]byte, 2*MaxRequest+100)
+			if _, err := io.ReadFull(d1, buf); err != nil {
+				t.Fatal(err)
+			}
+
+			want := make([]byte, len(buf))
+			for i := 0; i < len(want); i += MaxRequest {
+				if err := d2.Generate(want[i:min(len(want), i+MaxRequest)], nil, false); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if !bytes.Equal(buf, want) {
+				t.Errorf("Read does not match Generate")
+			}
+			if c := d1.ReseedCounter(); c != 4 {
+				t.Errorf("Expected reseed counter 4, got %v", c)
+			}
+		})
+	}
+}
+
+func TestReseedInterval(t *testing.T) {
+	for name, newDRBG := range constructors {
+		t.Run(name, func(t *testing.T) {
+			opts := Options{ReseedInterval: 2}
+			d, err := newDRBG(groestlhmac.Groestl256, pattern(1, 32), pattern(2, 16), nil, &opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			out := make([]byte, 32)
+			for range 2 {
+				if err := d.Generate(out, nil, false); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := d.Generate(out, nil, false); !errors.Is(err, ErrReseedRequired) {
+				t.Fatalf("Expected ErrReseedRequired, got %v", err)
+			}
+
+			if err := d.Reseed(pattern(4, 32), nil); err != nil {
+				t.Fatal(err)
+			}
+			if err := d.Generate(out, nil, false); err != nil {
+				t.Fatal(err)
+			}
+
+			opts.Entropy = bytes.NewReader(pattern(4, 32))
+			d, err = newDRBG(groestlhmac.Groestl256, pattern(1, 32), pattern(2, 16), nil, &opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for range 3 {
+				if err := d.Generate(out, nil, false); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if c := d.ReseedCounter(); c != 2 {
+				t.Errorf("Expected reseed counter 2 after automatic reseed, got %v", c)
+			}
+			if err := d.Generate(out, nil, false); err != nil {
+				t.Fatal(err)
+			}
+			if err := d.Generate(out, nil, false); err == nil {
+				t.Errorf("Expected error from exhausted entropy source")
+			}
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for name, newDRBG := range constructors {
+		t.Run(name, func(t *testing.T) {
+			if _, err := newDRBG(groestlhmac.Hash(0), pattern(1, 32), pattern(2, 16), nil, nil); err == nil {
+				t.Errorf("Expected error for unknown hash function")
+			}
+			if _, err := newDRBG(groestlhmac.Groestl256, pattern(1, 31), pattern(2, 16), nil, nil); err == nil {
+				t.Errorf("Expected error for short entropy input")
+			}
+			if _, err := newDRBG(groestlhmac.Groestl256, pattern(1, 32), pattern(2, 15), nil, nil); err == nil {
+				t.Errorf("Expected error for short nonce")
+			}
+			if _, err := newDRBG(groestlhmac.Groestl256, pattern(1, 32), nil, nil, nil); err == nil {
+				t.Errorf("Expected error for missing nonce")
+			}
+			if _, err := newDRBG(groestlhmac.Groestl224, pattern(1, 24), pattern(2, 12), nil, nil); err != nil {
+				t.Errorf("Unexpected error for Groestl-224: %v", err)
+			}
+			if _, err := newDRBG(groestlhmac.Groestl256, pattern(1, 32), pattern(2, 16), nil, &Options{PredictionResistance: true}); !errors.Is(err, ErrNoEntropy) {
+				t.Errorf("Expected ErrNoEntropy, got %v", err)
+			}
+
+			d, err := newDRBG(groestlhmac.Groestl256, pattern(1, 32), pattern(2, 16), nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := d.Generate(make([]byte, MaxRequest+1), nil, false); !errors.Is(err, ErrRequestTooLarge) {
+				t.Errorf("Expected ErrRequestTooLarge, got %v", err)
+			}
+			if err := d.Generate(make([]byte, 32), nil, true); !errors.Is(err, ErrPredictionResistance) {
+				t.Errorf("Expected ErrPredictionResistance, got %v", err)
+			}
+			if err := d.Reseed(pattern(4, 16), nil); err == nil {
+				t.Errorf("Expected error for short reseed entropy")
+			}
+
+			// SP 800-90A, section 9.3.1: a DRBG with an entropy source
+			// but without prediction resistance must still refuse it.
+			d, err = newDRBG(groestlhmac.Groestl256, pattern(1, 32), pattern(2, 16), nil, &Options{Entropy: bytes.NewReader(pattern(4, 32))})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := d.Generate(make([]byte, 32), nil, true); !errors.Is(err, ErrPredictionResistance) {
+				t.Errorf("Expected ErrPredictionResistance, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package drbg
+
+import (
+	"encoding/binary"
+	"hash"
+
+	"github.com/DeedleFake/crypto/groestlhmac"
+)
+
+// hashDRBG is the Hash_DRBG mechanism of SP 800-90A, section 10.1.1.
+type hashDRBG struct {
+	h    hash.Hash
+	v, c []byte
+}
+
+// seedLen returns the seed length in bytes that Hash_DRBG uses with h.
+// As with SHA-2, it is 440 bits for the digests that are no longer
+// than 256 bits and 888 bits for the others.
+func seedLen(h groestlhmac.Hash) int {
+	if h.Size() <= 32 {
+		return 55
+	}
+	return 111
+}
+
+// newHashDRBG instantiates Hash_DRBG. h.New returns the constant-time
+// Groestl digests, so the secret state is never used to index a table.
+func newHashDRBG(h groestlhmac.Hash, entropy, nonce, personalization []byte) *hashDRBG {
+	d := hashDRBG{h: h.New()}
+	d.v = d.df(seedLen(h), entropy, nonce, personalization)
+	d.c = d.df(len(d.v), []byte{0}, d.v)
+	return &d
+}
+
+// df is Hash_df. It returns n bytes derived from the concatenation of
+// inputs.
+func (d *hashDRBG) df(n int, inputs ...[]byte) []byte {
+	var prefix [5]byte
+	binary.BigEndian.PutUint32(prefix[1:], uint32(n*8))
+
+	out := make([]byte, 0, n+d.h.Size())
+	for prefix[0] = 1; len(out) < n; prefix[0]++ {
+		d.h.Reset()
+		d.h.Write(prefix[:])
+		for _, input := range inputs {
+			d.h.Write(input)
+		}
+		out = d.h.Sum(out)
+	}
+	return out[:n]
+}
+
+// sum returns the hash of the concatenation of inputs.
+func (d *hashDRBG) sum(inputs ...[]byte) []byte {
+	d.h.Reset()
+	for _, input := range inputs {
+		d.h.Write(input)
+	}
+	return d.h.Sum(nil)
+}
+
+func (d *hashDRBG) reseed(entropy, additional []byte) {
+	d.v = d.df(len(d.v), []byte{1}, d.v, entropy, additional)
+	d.c = d.df(len(d.v), []byte{0}, d.v)
+}
+
+func (d *hashDRBG) generate(out, additional []byte, counter uint64) {
+	if len(additional) > 0 {
+		add(d.v, d.sum([]byte{2}, d.v, additional))
+	}
+
+	data := append([]byte(nil), d.v...)
+	for n := 0; n < len(out); {
+		n += copy(out[n:], d.sum(data))
+		addUint64(data, 1)
+	}
+
+	add(d.v, d.sum([]byte{3}, d.v))
+	add(d.v, d.c)
+	addUint64(d.v, counter)
+}
+
+// add sets dst to dst+src modulo 2^(8*len(dst)), treating both as big
+// endian numbers. src must not be longer than dst.
+func add(dst, src []byte) {
+	var carry uint16
+	for i, j := len(dst)-1, len(src)-1; i >= 0; i, j = i-1, j-1 {
+		sum := uint16(dst[i]) + carry
+		if j >= 0 {
+			sum += uint16(src[j])
+		}
+		dst[i] = byte(sum)
+		carry = sum >> 8
+	}
+}
+
+// addUint64 is like add, but adds v.
+func addUint64(dst []byte, v uint64) {
+	var src [8]byte
+	binary.BigEndian.PutUint64(src[:], v)
+	add(dst, src[:])
+}
@@ -0,0 +1,84 @@
+package drbg
+
+import (
+	"hash"
+
+	"github.com/DeedleFake/crypto/groestlhmac"
+)
+
+// hmacDRBG is the HMAC_DRBG mechanism of SP 800-90A, section 10.1.2.
+type hmacDRBG struct {
+	h    groestlhmac.Hash
+	mac  hash.Hash
+	k, v []byte
+}
+
+func newHMACDRBG(h groestlhmac.Hash, entropy, nonce, personalization []byte) *hmacDRBG {
+	d := hmacDRBG{
+		h: h,
+		k: make([]byte, h.Size()),
+		v: make([]byte, h.Size()),
+	}
+	for i := range d.v {
+		d.v[i] = 1
+	}
+	d.setKey()
+
+	d.update(entropy, nonce, personalization)
+	return &d
+}
+
+// setKey sets up the HMAC with the current key. Like Hash.New,
+// groestlhmac.New uses the constant-time Groestl digests.
+func (d *hmacDRBG) setKey() {
+	d.mac = groestlhmac.New(d.h, d.k)
+}
+
+// next sets V to HMAC(K, V).
+func (d *hmacDRBG) next() {
+	d.mac.Reset()
+	d.mac.Write(d.v)
+	d.v = d.mac.Sum(d.v[:0])
+}
+
+// update is HMAC_DRBG_Update, with the provided data being the
+// concatenation of provided.
+func (d *hmacDRBG) update(provided ...[]byte) {
+	empty := true
+	for _, p := range provided {
+		empty = empty && (len(p) == 0)
+	}
+
+	for i := byte(0); i < 2; i++ {
+		if (i > 0) && empty {
+			return
+		}
+
+		d.mac.Reset()
+		d.mac.Write(d.v)
+		d.mac.Write([]byte{i})
+		for _, p := range provided {
+			d.mac.Write(p)
+		}
+		d.k = d.mac.Sum(d.k[:0])
+		d.setKey()
+		d.next()
+	}
+}
+
+func (d *hmacDRBG) reseed(entropy, additional []byte) {
+	d.update(entropy, additional)
+}
+
+func (d *hmacDRBG) generate(out, additional []byte, counter uint64) {
+	if len(additional) > 0 {
+		d.update(additional)
+	}
+
+	for n := 0; n < len(out); {
+		d.next()
+		n += copy(out[n:], d.v)
+	}
+
+	d.update(additional)
+}
@@ -0,0 +1,69 @@
+package drbg
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"sync"
+
+	"github.com/DeedleFake/crypto/groestlhmac"
+)
+
+// ErrSelfTest is returned when the DRBG mechanisms fail their
+// known-answer tests.
+var ErrSelfTest = errors.New("drbg: self-test failed")
+
+// selfTests exercise instantiation with a personalization string,
+// reseeding and generation with additional input for both mechanisms.
+// They are the Groestl256 Reseed outputs of go run ./internal/refgen
+// drbg.
+var selfTests = []struct {
+	new func(h groestlhmac.Hash, entropy, nonce, personalization []byte) mechanism
+	out string
+}{
+	{
+		new: func(h groestlhmac.Hash, entropy, nonce, personalization []byte) mechanism {
+			return newHashDRBG(h, entropy, nonce, personalization)
+		},
+		out: "bee74c62ad79c34e5cc82e41a0e8005d309606582f2814430bbf1dbb38d59c0f547ae7cfcb56e1c214092577cb69a88ce25c458b9bf49aeddbb733cd4a2d28ae656140af1c044825d2b0c1a006e840578f96ed2c81981d9bdc6d441463b96d093eba7507be2d9296c4935f05cb6a3acffcdf8e8f261fdbda864fe55172d22c5f",
+	},
+	{
+		new: func(h groestlhmac.Hash, entropy, nonce, personalization []byte) mechanism {
+			return newHMACDRBG(h, entropy, nonce, personalization)
+		},
+		out: "d2d0442260f739e3c9360f77fa6b17c0294a20254e9f1c9dd4e4e29eea9fbaf08b4e2b683b623f82b10c2ddd8c17b98482b8ed6414e832d0dcb2318f9823b07df2b447d8a5301b8f9564e7710dff125aa373b731c16af4a0f08f74397790a1e5b9a2bd86a966e41cec01539fc98987875c7410f93e7f96d3e04e73ac82425d33",
+	},
+}
+
+// pattern returns n bytes of a deterministic test pattern.
+func pattern(seed byte, n int) []byte {
+	p := make([]byte, n)
+	for i := range p {
+		p[i] = seed*7 + byte(i)*13
+	}
+	return p
+}
+
+var selfTest = sync.OnceValue(SelfTest)
+
+// SelfTest runs known-answer tests of the Hash_DRBG and HMAC_DRBG
+// mechanisms with Groestl-256. It returns ErrSelfTest if any of them
+// fail. The constructors run it automatically the first time that they
+// are called and refuse to instantiate a DRBG if it fails.
+func SelfTest() error {
+	const h = groestlhmac.Groestl256
+
+	for _, test := range selfTests {
+		want, _ := hex.DecodeString(test.out)
+		out := make([]byte, len(want))
+
+		m := test.new(h, pattern(1, 32), pattern(2, 16), pattern(3, 32))
+		m.reseed(pattern(4, 32), pattern(5, 32))
+		m.generate(out, pattern(6, 32), 1)
+		m.generate(out, pattern(7, 32), 2)
+		if !bytes.Equal(out, want) {
+			return ErrSelfTest
+		}
+	}
+	return nil
+}
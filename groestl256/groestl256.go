@@ -1,72 +1,254 @@
+// Package groestl256 implements the Groestl-256 and Groestl-224 hash
+// functions, and Groestl with any digest length from 8 to 256 bits, as
+// submitted to the final round of the SHA-3 competition.
+//
+// Where AES-NI is not used, the permutations look up eight 2 KiB
+// tables. Building with the groestl_small tag replaces them with a
+// single table and rotations, which saves 14 KiB at some cost in speed.
+// On an x86 server, BenchmarkWriteTable hashes 64 KiB at about 180 MB/s
+// by default and about 140 MB/s with the tag.
 package groestl256
 
 import (
 	"encoding/binary"
+	"errors"
+	"fmt"
 	"hash"
+	"io"
+
+	"github.com/DeedleFake/crypto/internal/partial"
 )
 
+//go:generate go run ../internal/gen -o internal.go
+//go:generate go run ../internal/gen -compact -o internal_compact.go
+
 const (
-	// Size is the size of a hash in bytes.
+	// Size is the size of a Groestl-256 hash in bytes.
 	Size = 32
 
+	// Size224 is the size of a Groestl-224 hash in bytes.
+	Size224 = 28
+
 	// BlockSize is the blocksize of a hash.
 	BlockSize = 64
 )
 
-type context struct {
+// Digest is the state of a Groestl hash. It implements hash.Hash. The
+// zero value is ready to use and computes Groestl-256 hashes.
+type Digest struct {
 	buf    [64]byte
 	offset int
 	state  [8]uint64
 	count  uint64
+
+	// bits is the length of the digest in bits. It is zero until the
+	// zero Digest is first used.
+	bits int
+
+	// partial is what WriteBits has left of a partially written byte.
+	partial partial.Byte
+
+	// ct selects the constant-time permutations.
+	ct bool
 }
 
+var (
+	_ hash.Cloner     = (*Digest)(nil)
+	_ io.StringWriter = (*Digest)(nil)
+	_ io.ByteWriter   = (*Digest)(nil)
+)
+
 // New returns a new hash.Hash that computes Groestl-256 hashes.
 func New() hash.Hash {
-	ctx := &context{}
-	ctx.state[7] = Size * 8
+	return newDigest(Size)
+}
+
+// New224 returns a new hash.Hash that computes Groestl-224 hashes.
+func New224() hash.Hash {
+	return newDigest(Size224)
+}
+
+// NewSize returns a new hash.Hash that computes Groestl hashes with a
+// digest length of the given number of bits, which must be between 8
+// and 256, inclusive. As per the specification, the length is used as
+// the initial value of the state and the output is the trailing bits
+// of the final state. A length that is not a multiple of 8 gives a
+// digest of the next whole number of bytes, with the bits at the most
+// significant end and the unused low bits of the last byte zero, as in
+// the SHA-3 competition test vectors.
+func NewSize(bits int) (hash.Hash, error) {
+	if (bits < 8) || (bits > Size*8) {
+		return nil, fmt.Errorf("groestl256: invalid digest length: %v bits", bits)
+	}
+
+	return newDigestBits(bits), nil
+}
+
+// NewConstantTime returns a new hash.Hash that computes Groestl-256
+// hashes without any memory accesses that depend on the data being
+// hashed. It is several times slower than New, but does not leak
+// information about secret inputs, such as keys, through the cache.
+func NewConstantTime() hash.Hash {
+	ctx := newDigest(Size)
+	ctx.ct = true
 	return ctx
 }
 
-func (ctx *context) Write(data []byte) (n int, err error) {
-	if len(data) < len(ctx.buf)-ctx.offset {
-		copy(ctx.buf[ctx.offset:], data)
-		ctx.offset += len(data)
-		return len(data), nil
+// NewConstantTime224 is NewConstantTime for Groestl-224.
+func NewConstantTime224() hash.Hash {
+	ctx := newDigest(Size224)
+	ctx.ct = true
+	return ctx
+}
+
+func newDigest(size int) *Digest {
+	return newDigestBits(size * 8)
+}
+
+func newDigestBits(bits int) *Digest {
+	ctx := &Digest{bits: bits}
+	ctx.state[7] = uint64(bits)
+	return ctx
+}
+
+// init sets up the zero Digest to compute Groestl-256 hashes. It does
+// nothing to a Digest that is already set up.
+func (ctx *Digest) init() {
+	if ctx.bits == 0 {
+		*ctx = *newDigest(Size)
 	}
+}
+
+func (ctx *Digest) Write(data []byte) (n int, err error) {
+	ctx.init()
+	n = len(data)
 
+	var buf [BlockSize]byte
 	for len(data) > 0 {
-		clen := len(ctx.buf) - ctx.offset
-		if clen > len(data) {
-			clen = len(data)
+		var chunk []byte
+		chunk, data = ctx.partial.Next(buf[:], data)
+		ctx.write(chunk)
+	}
+
+	return n, nil
+}
+
+// WriteString adds the bytes of s to the running hash without
+// converting it to a byte slice first. It implements io.StringWriter.
+func (ctx *Digest) WriteString(s string) (n int, err error) {
+	ctx.init()
+	n = len(s)
+
+	var buf [BlockSize]byte
+	for len(s) > 0 {
+		var chunk []byte
+		chunk, s = ctx.partial.NextString(buf[:], s)
+		ctx.write(chunk)
+	}
+
+	return n, nil
+}
+
+// WriteByte adds c to the running hash. It implements io.ByteWriter.
+func (ctx *Digest) WriteByte(c byte) error {
+	buf := [1]byte{c}
+	ctx.Write(buf[:])
+	return nil
+}
+
+// WriteBits adds the first bits bits of data to the running hash.
+// Bits are taken from the most significant end of each byte. Unlike
+// Write, the message does not need to be a whole number of bytes long,
+// and calls to WriteBits and Write can be freely interleaved. It
+// panics if bits is greater than 8*len(data).
+func (ctx *Digest) WriteBits(data []byte, bits uint64) {
+	full := bits / 8
+	ctx.Write(data[:full])
+
+	if rem := uint(bits % 8); rem != 0 {
+		if b, ok := ctx.partial.Add(data[full], rem); ok {
+			ctx.write([]byte{b})
 		}
+	}
+}
 
-		copy(ctx.buf[ctx.offset:], data[:clen])
-		ctx.offset += clen
-		data = data[clen:]
+func (ctx *Digest) write(data []byte) (n int, err error) {
+	n = len(data)
 
-		if ctx.offset == len(ctx.buf) {
-			var g, m [len(ctx.state)]uint64
-			for u := range ctx.state {
-				m[u] = binary.BigEndian.Uint64(ctx.buf[u<<3:])
-				g[u] = m[u] ^ ctx.state[u]
-			}
+	if ctx.offset > 0 {
+		c := copy(ctx.buf[ctx.offset:], data)
+		ctx.offset += c
+		data = data[c:]
+		if ctx.offset < len(ctx.buf) {
+			return n, nil
+		}
 
-			permSmallP(g[:])
-			permSmallQ(m[:])
+		ctx.blocks(ctx.buf[:])
+		ctx.offset = 0
+	}
 
-			for u := range ctx.state {
-				ctx.state[u] ^= g[u] ^ m[u]
-			}
+	// Compress whole blocks straight out of data and only buffer
+	// what's left over.
+	if len(data) >= len(ctx.buf) {
+		c := len(data) - len(data)%len(ctx.buf)
+		ctx.blocks(data[:c])
+		data = data[c:]
+	}
+	ctx.offset = copy(ctx.buf[:], data)
 
-			ctx.count++
-			ctx.offset = 0
+	return n, nil
+}
+
+// blocks compresses data, the length of which must be a multiple of
+// BlockSize, into the state.
+func (ctx *Digest) blocks(data []byte) {
+	for len(data) >= len(ctx.buf) {
+		var g, m [len(ctx.state)]uint64
+		for u := range ctx.state {
+			m[u] = binary.BigEndian.Uint64(data[u<<3:])
+			g[u] = m[u] ^ ctx.state[u]
+		}
+
+		ctx.perm(&g, &m)
+
+		for u := range ctx.state {
+			ctx.state[u] ^= g[u] ^ m[u]
 		}
+
+		ctx.count++
+		data = data[len(ctx.buf):]
 	}
+}
 
-	return len(data), nil
+// perm applies P to p and Q to q. AES-NI is used when available, even
+// in constant-time mode, as it does not depend on tables in memory.
+func (ctx *Digest) perm(p, q *[8]uint64) {
+	switch {
+	case useAES:
+		permSmallAES(p, q)
+	case ctx.ct:
+		permSmallPCT(p[:])
+		permSmallQCT(q[:])
+	default:
+		permSmallP(p)
+		permSmallQ(q)
+	}
 }
 
-func (ctx *context) close(dst []byte, ub, n uint64) {
+// permP applies only P to p.
+func (ctx *Digest) permP(p *[8]uint64) {
+	switch {
+	case useAES:
+		var q [8]uint64
+		permSmallAES(p, &q)
+	case ctx.ct:
+		permSmallPCT(p[:])
+	default:
+		permSmallP(p)
+	}
+}
+
+func (ctx *Digest) close(dst []byte, ub, n uint64) {
 	var pad [72]byte
 
 	z := uint64(0x80) >> n
@@ -80,43 +262,170 @@ func (ctx *context) close(dst []byte, ub, n uint64) {
 	}
 	binary.BigEndian.PutUint64(pad[padLen-8:], count)
 
-	ctx.Write(pad[:padLen])
+	ctx.write(pad[:padLen])
+	ctx.output(dst)
+}
 
+// output applies the output transformation to the state and copies its
+// trailing ctx.bits bits to dst, which must be (ctx.bits+7)/8 bytes long.
+func (ctx *Digest) output(dst []byte) {
 	x := ctx.state
-	permSmallP(x[:])
+	ctx.permP(&x)
 
 	for u := range x {
 		ctx.state[u] ^= x[u]
 	}
+
+	var out [32]byte
 	for u := 0; u < 4; u++ {
-		binary.BigEndian.PutUint64(pad[u<<3:], ctx.state[u+4])
+		binary.BigEndian.PutUint64(out[u<<3:], ctx.state[u+4])
 	}
 
-	copy(dst, pad[32-len(dst):])
+	copy(dst, out[32-len(dst):])
 
-	*ctx = *New().(*context)
-
-	//ctx.init(uint64(len(dst)) << 3)
+	// The unused bits at the top of a partial first byte are shifted
+	// out, which moves them to the most significant end of dst.
+	if r := uint(ctx.bits % 8); r != 0 {
+		for i := range dst {
+			var next byte
+			if i+1 < len(dst) {
+				next = dst[i+1]
+			}
+			dst[i] = dst[i]<<(8-r) | next>>r
+		}
+	}
 }
 
-func (ctx *context) Sum(prev []byte) []byte {
-	out := append(prev, make([]byte, Size)...)
-	ctx.close(out[len(prev):], 0, 0)
+func (ctx *Digest) Sum(prev []byte) []byte {
+	// Finalize a copy so that the running hash can keep being written
+	// to after a call to Sum.
+	c := *ctx
+	c.init()
+	out := append(prev, make([]byte, c.Size())...)
+	c.close(out[len(prev):], uint64(c.partial.Bits), uint64(c.partial.N))
 	return out
 }
 
-func (ctx *context) Reset() {
-	*ctx = *New().(*context)
+// Clone returns a copy of the running hash that can be written to and
+// summed independently of ctx. It implements hash.Cloner and never
+// returns an error.
+func (ctx *Digest) Clone() (hash.Cloner, error) {
+	c := *ctx
+	return &c, nil
+}
+
+func (ctx *Digest) Reset() {
+	if ctx.bits == 0 {
+		return
+	}
+
+	ct := ctx.ct
+	*ctx = *newDigestBits(ctx.bits)
+	ctx.ct = ct
 }
 
-func (ctx *context) Size() int {
-	return Size
+func (ctx *Digest) Size() int {
+	if ctx.bits == 0 {
+		return Size
+	}
+	return (ctx.bits + 7) / 8
 }
 
-func (ctx *context) BlockSize() int {
+func (ctx *Digest) BlockSize() int {
 	return BlockSize
 }
 
+// ConstantTime reports whether ctx uses the constant-time permutations,
+// as it does if it was created by NewConstantTime or NewConstantTime224.
+func (ctx *Digest) ConstantTime() bool {
+	return ctx.ct
+}
+
+const (
+	magic          = "groestl256"
+	marshalVersion = 2
+
+	// magic, version, digest bits, state, buf, count, offset, partial,
+	// nbits
+	marshaledSize = len(magic) + 1 + 2 + 8*8 + BlockSize + 8 + 8 + 1 + 1
+)
+
+var (
+	errStateIdentifier = errors.New("groestl256: invalid hash state identifier")
+	errStateVersion    = errors.New("groestl256: unsupported hash state version")
+	errStateSize       = errors.New("groestl256: invalid hash state size")
+	errStateVariant    = errors.New("groestl256: hash state is for a different digest length")
+	errStateCorrupt    = errors.New("groestl256: corrupt hash state")
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler. The returned
+// state can be restored with UnmarshalBinary to continue hashing,
+// possibly in a different process.
+func (ctx *Digest) MarshalBinary() ([]byte, error) {
+	return ctx.AppendBinary(make([]byte, 0, marshaledSize))
+}
+
+// AppendBinary implements encoding.BinaryAppender.
+func (ctx *Digest) AppendBinary(b []byte) ([]byte, error) {
+	if ctx.bits == 0 {
+		ctx = newDigest(Size)
+	}
+
+	b = append(b, magic...)
+	b = append(b, marshalVersion)
+	b = binary.BigEndian.AppendUint16(b, uint16(ctx.bits))
+	for _, v := range ctx.state {
+		b = binary.BigEndian.AppendUint64(b, v)
+	}
+	b = append(b, ctx.buf[:]...)
+	b = binary.BigEndian.AppendUint64(b, ctx.count)
+	b = binary.BigEndian.AppendUint64(b, uint64(ctx.offset))
+	b = append(b, ctx.partial.Bits, byte(ctx.partial.N))
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The state
+// must have been produced by a Digest with the same digest length as
+// ctx, unless ctx is the zero Digest, in which case it takes on the
+// digest length stored in the state.
+func (ctx *Digest) UnmarshalBinary(b []byte) error {
+	if (len(b) < len(magic)) || (string(b[:len(magic)]) != magic) {
+		return errStateIdentifier
+	}
+	b = b[len(magic):]
+	if (len(b) < 1) || (b[0] != marshalVersion) {
+		return errStateVersion
+	}
+	if len(b) != marshaledSize-len(magic) {
+		return errStateSize
+	}
+	bits := int(binary.BigEndian.Uint16(b[1:]))
+	if (ctx.bits != 0) && (bits != ctx.bits) {
+		return errStateVariant
+	}
+	b = b[3:]
+
+	var d Digest
+	d.bits = bits
+	d.ct = ctx.ct
+	for u := range d.state {
+		d.state[u] = binary.BigEndian.Uint64(b[u<<3:])
+	}
+	b = b[len(d.state)*8:]
+	b = b[copy(d.buf[:], b):]
+	d.count = binary.BigEndian.Uint64(b)
+	offset := binary.BigEndian.Uint64(b[8:])
+	d.partial = partial.Byte{Bits: b[16], N: uint(b[17])}
+
+	if (bits < 8) || (bits > Size*8) || (offset >= BlockSize) || !d.partial.Valid() {
+		return errStateCorrupt
+	}
+	d.offset = int(offset)
+
+	*ctx = d
+	return nil
+}
+
 // Sum computes the Groestl-256 hash of data.
 func Sum(data []byte) (out [Size]byte) {
 	h := New()
@@ -124,3 +433,19 @@ func Sum(data []byte) (out [Size]byte) {
 	h.Sum(out[:0])
 	return
 }
+
+// Sum224 computes the Groestl-224 hash of data.
+func Sum224(data []byte) (out [Size224]byte) {
+	h := New224()
+	h.Write(data)
+	h.Sum(out[:0])
+	return
+}
+
+// SumBits computes the Groestl-256 hash of the first bitLen bits of data.
+func SumBits(data []byte, bitLen uint64) (out [Size]byte) {
+	h := newDigest(Size)
+	h.WriteBits(data, bitLen)
+	h.Sum(out[:0])
+	return
+}
@@ -1,8 +1,18 @@
 package groestl256
 
 import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
+	"hash"
+	"math/big"
+	"path/filepath"
 	"testing"
+
+	"github.com/DeedleFake/crypto/internal/hashtest"
+	"github.com/DeedleFake/crypto/internal/kat"
 )
 
 func TestSum(t *testing.T) {
@@ -17,9 +27,14 @@ func TestSum(t *testing.T) {
 			out:  "137667957fb50e3567f28f459002f6c52f80d46a0094ba4663b41b43aff53d77",
 		},
 		{
-			name: "Basic2",
-			in:   []byte{0x34, 0x6e, 0x80, 0x88, 0x0e, 0xcc, 0x9e, 0x84, 0xce, 0x60, 0x22, 0xcf, 0x37, 0x56, 0xa1, 0xdf, 0x17, 0x56, 0x84, 0x0e, 0xf7, 0xea, 0x65, 0xc6, 0x44, 0xc9, 0x9f, 0x6d, 0x3d, 0xa3, 0x1f, 0x2b},
-			out:  "137667957fb50e3567f28f459002f6c52f80d46a0094ba4663b41b43aff53d77",
+			name: "TwoBlockPadding",
+			in:   hashtest.Seq(60),
+			out:  "a3985ff2e107806b226da85d2784baf27822efda832cfc3b32451bf237c00c7c",
+		},
+		{
+			name: "MultiBlock",
+			in:   hashtest.Seq(200),
+			out:  "5e4874941276bacd43cf9f5078a5d620143b0b105f633f44d65ed13d27f6a849",
 		},
 	}
 
@@ -29,6 +44,7 @@ func TestSum(t *testing.T) {
 	for _, test := range tests {
 		test := test
 		t.Run(test.name, func(t *testing.T) {
+			h.Reset()
 			h.Write(test.in)
 			h.Sum(out[:0])
 
@@ -40,3 +56,277 @@ func TestSum(t *testing.T) {
 		})
 	}
 }
+
+func TestSum224(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []byte
+		out  string
+	}{
+		{
+			name: "Empty",
+			in:   nil,
+			out:  "f2e180fb5947be964cd584e22e496242c6a329c577fc4ce8c36d34c3",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got := fmt.Sprintf("%x", Sum224(test.in))
+			if got != test.out {
+				t.Errorf("Expected %q", test.out)
+				t.Errorf("Got %q", got)
+			}
+		})
+	}
+}
+
+func TestNewSize(t *testing.T) {
+	in := []byte("The quick brown fox jumps over the lazy dog")
+	sum256 := Sum(in)
+	sum224 := Sum224(in)
+
+	tests := []struct {
+		name string
+		bits int
+		out  []byte
+		err  bool
+	}{
+		{name: "256", bits: 256, out: sum256[:]},
+		{name: "224", bits: 224, out: sum224[:]},
+		{name: "8", bits: 8},
+		{name: "168", bits: 168},
+		{name: "100", bits: 100},
+		{name: "255", bits: 255},
+		{name: "Zero", bits: 0, err: true},
+		{name: "TooShort", bits: 7, err: true},
+		{name: "TooLarge", bits: 257, err: true},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			h, err := NewSize(test.bits)
+			if test.err {
+				if err == nil {
+					t.Fatalf("Expected error for %v bits", test.bits)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			h.Write(in)
+			out := h.Sum(nil)
+			if size := (test.bits + 7) / 8; (len(out) != size) || (h.Size() != size) {
+				t.Fatalf("Expected %v bytes, got %v", size, len(out))
+			}
+			if unused := byte(0xFF) >> (test.bits % 8); (test.bits%8 != 0) && (out[len(out)-1]&unused != 0) {
+				t.Errorf("Unused bits of %x are not zero", out)
+			}
+
+			state, _ := h.(*Digest).MarshalBinary()
+			var r Digest
+			if err := r.UnmarshalBinary(state); (err != nil) || !bytes.Equal(r.Sum(nil), out) {
+				t.Errorf("Restored state does not give the same digest: %v", err)
+			}
+			if (test.out != nil) && !bytes.Equal(out, test.out) {
+				t.Errorf("Expected %x", test.out)
+				t.Errorf("Got %x", out)
+			}
+		})
+	}
+}
+
+func TestNewSizeTruncation(t *testing.T) {
+	in := []byte("The quick brown fox jumps over the lazy dog")
+
+	for _, bits := range []int{9, 100, 161, 255} {
+		h := newDigestBits(bits)
+		h.Write(in)
+		got := h.Sum(nil)
+
+		// The digest must be the trailing bits of the whole output of
+		// the same state, moved to the most significant end.
+		full := *h
+		full.bits = Size * 8
+		mask := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+		mask.Sub(mask, big.NewInt(1))
+		want := new(big.Int).SetBytes(full.Sum(nil))
+		want.And(want, mask).Lsh(want, uint(len(got)*8-bits))
+
+		if w := want.FillBytes(make([]byte, len(got))); !bytes.Equal(got, w) {
+			t.Errorf("%v bits: expected %x", bits, w)
+			t.Errorf("%v bits: got %x", bits, got)
+		}
+	}
+}
+
+func TestHash(t *testing.T) {
+	hashtest.Run(t, func() hashtest.Hash { return New().(*Digest) })
+}
+
+func TestZeroDigest(t *testing.T) {
+	hashtest.Run(t, func() hashtest.Hash { return new(Digest) })
+
+	var d Digest
+	if d.Size() != Size {
+		t.Errorf("Expected size %v", Size)
+		t.Errorf("Got %v", d.Size())
+	}
+
+	// The zero Digest marshals as a fresh Groestl-256 state.
+	got, _ := d.MarshalBinary()
+	want, _ := New().(*Digest).MarshalBinary()
+	if !bytes.Equal(got, want) {
+		t.Errorf("Expected state %x", want)
+		t.Errorf("Got %x", got)
+	}
+
+	d.Reset()
+	d.Write([]byte("abc"))
+	if got, want := d.Sum(nil), Sum([]byte("abc")); !bytes.Equal(got, want[:]) {
+		t.Errorf("Expected %x", want)
+		t.Errorf("Got %x", got)
+	}
+}
+
+func TestSumBits(t *testing.T) {
+	tests := []struct {
+		bits uint64
+		in   string
+		out  string
+	}{
+		{bits: 1, in: "00", out: "de84b8979d9a7cdf4f6f7dc32f3da0bb6329f54e8c3754bc7fb44d86375a8e3b"},
+		{bits: 2, in: "c0", out: "8c1cbe515a88c083c1c3c186954482f5aacb65799b95f2b3f6abb37a75b64e7b"},
+		{bits: 5, in: "48", out: "f0b8bceb19a1964e8a563403d2c41aefc2c6cf067998665a01494fbcc2141c15"},
+		{bits: 7, in: "98", out: "637da31b34a9e44b8aff31044d550a8d18b1ecede65ecc19089388fcc6a050ed"},
+		{bits: 8, in: "cc", out: "15e2671f0eaf66c0de3093ab7b1e39dc68f945d7002fc5dfd52d60527e7228d1"},
+		{bits: 10, in: "2140", out: "4853e64137b67e4bfe56ef3f0a1d51a7dcfe5e4b69030bbc1b084aa150921177"},
+		{bits: 63, in: "0e3ab0e054739b00", out: "578e3389b851a4af96510f1b5b58d2542363547733c155fcb6c739d5a83ce649"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprint(test.bits), func(t *testing.T) {
+			in, _ := hex.DecodeString(test.in)
+
+			got := fmt.Sprintf("%x", SumBits(in, test.bits))
+			if got != test.out {
+				t.Errorf("Expected %q", test.out)
+				t.Errorf("Got %q", got)
+			}
+
+			// Feeding the same bits one at a time, and with the unused
+			// trailing bits set, must not change the result.
+			h := New().(*Digest)
+			for i := uint64(0); i < test.bits; i++ {
+				h.WriteBits([]byte{in[i/8] << (i % 8)}, 1)
+			}
+			got = fmt.Sprintf("%x", h.Sum(nil))
+			if got != test.out {
+				t.Errorf("Bitwise: expected %q", test.out)
+				t.Errorf("Bitwise: got %q", got)
+			}
+		})
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	newHashes := []func() hash.Hash{New, New224}
+	for i, newHash := range newHashes {
+		other := newHashes[1-i]
+		t.Run(fmt.Sprint(newHash().Size()*8), func(t *testing.T) {
+			hashtest.RunMarshal(t, hashtest.MarshalTest{
+				New:           func() hashtest.State { return newHash().(*Digest) },
+				Other:         func() hashtest.State { return other().(*Digest) },
+				Zero:          func() hashtest.State { return new(Digest) },
+				Magic:         magic,
+				ErrIdentifier: errStateIdentifier,
+				ErrVersion:    errStateVersion,
+				ErrSize:       errStateSize,
+				ErrVariant:    errStateVariant,
+				ErrCorrupt:    errStateCorrupt,
+			})
+		})
+	}
+}
+
+// The digest length is stored after the version as a big-endian
+// 16-bit number of bits, as in groestl512.
+func TestMarshalBinaryLength(t *testing.T) {
+	size13, err := NewSize(13)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		h    hash.Hash
+		bits int
+	}{
+		{h: New(), bits: 256},
+		{h: New224(), bits: 224},
+		{h: size13, bits: 13},
+	}
+
+	for _, test := range tests {
+		state, err := test.h.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bits := binary.BigEndian.Uint16(state[len(magic)+1:]); int(bits) != test.bits {
+			t.Errorf("Expected %v bits", test.bits)
+			t.Errorf("Got %v bits", bits)
+		}
+	}
+
+	state, _ := New().(*Digest).MarshalBinary()
+	for _, bits := range []uint16{0, 7, 257} {
+		binary.BigEndian.PutUint16(state[len(magic)+1:], bits)
+		var r Digest
+		if err := r.UnmarshalBinary(state); err != errStateCorrupt {
+			t.Errorf("%v bits: expected %v", bits, errStateCorrupt)
+			t.Errorf("%v bits: got %v", bits, err)
+		}
+	}
+}
+
+// The messages in the KAT files are from the SHA-3 competition, and
+// the digests were computed by internal/refgen. The header of each file
+// says how.
+func TestKAT(t *testing.T) {
+	sizes := []struct {
+		size    string
+		newHash func() kat.BitHash
+	}{
+		{size: "224", newHash: func() kat.BitHash { return New224().(*Digest) }},
+		{size: "256", newHash: func() kat.BitHash { return New().(*Digest) }},
+	}
+
+	for _, size := range sizes {
+		size := size
+		for _, file := range []string{"ShortMsgKAT", "LongMsgKAT", "ExtremelyLongMsgKAT"} {
+			name := file + "_" + size.size
+			t.Run(name, func(t *testing.T) {
+				kat.Run(t, filepath.Join("testdata", name+".txt"), size.newHash)
+			})
+		}
+	}
+}
+
+func BenchmarkWrite(b *testing.B) {
+	hashtest.Benchmark(b, New)
+}
+
+// BenchmarkWriteTable is BenchmarkWrite with the table-driven
+// permutations instead of AES-NI, so that it measures the difference
+// that the groestl_small tag makes.
+func BenchmarkWriteTable(b *testing.B) {
+	aes := useAES
+	useAES = false
+	defer func() { useAES = aes }()
+
+	BenchmarkWrite(b)
+}
@@ -1,75 +1,434 @@
+// Code generated by internal/gen. DO NOT EDIT.
+
+//go:build !groestl_small
+
 package groestl256
 
-func permSmallP(a []uint64) {
-	for r := 0; r < 10; r++ {
-		roundSmallP(a[:], uint64(r))
-	}
-}
+// permSmallP applies the Groestl permutation P to the state x.
+func permSmallP(x *[8]uint64) {
+	a0 := x[0]
+	a1 := x[1]
+	a2 := x[2]
+	a3 := x[3]
+	a4 := x[4]
+	a5 := x[5]
+	a6 := x[6]
+	a7 := x[7]
 
-func permSmallQ(a []uint64) {
-	for r := 0; r < 10; r++ {
-		roundSmallQ(a[:], uint64(r))
-	}
-}
+	// Round 0.
+	a1 ^= 0x1000000000000000
+	a2 ^= 0x2000000000000000
+	a3 ^= 0x3000000000000000
+	a4 ^= 0x4000000000000000
+	a5 ^= 0x5000000000000000
+	a6 ^= 0x6000000000000000
+	a7 ^= 0x7000000000000000
 
-func roundSmallP(a []uint64, r uint64) {
-	var t [8]uint64
-	a[0] ^= pc64(0x00, r)
-	a[1] ^= pc64(0x10, r)
-	a[2] ^= pc64(0x20, r)
-	a[3] ^= pc64(0x30, r)
-	a[4] ^= pc64(0x40, r)
-	a[5] ^= pc64(0x50, r)
-	a[6] ^= pc64(0x60, r)
-	a[7] ^= pc64(0x70, r)
-	rstt(t[:], 0, a, 0, 1, 2, 3, 4, 5, 6, 7)
-	rstt(t[:], 1, a, 1, 2, 3, 4, 5, 6, 7, 0)
-	rstt(t[:], 2, a, 2, 3, 4, 5, 6, 7, 0, 1)
-	rstt(t[:], 3, a, 3, 4, 5, 6, 7, 0, 1, 2)
-	rstt(t[:], 4, a, 4, 5, 6, 7, 0, 1, 2, 3)
-	rstt(t[:], 5, a, 5, 6, 7, 0, 1, 2, 3, 4)
-	rstt(t[:], 6, a, 6, 7, 0, 1, 2, 3, 4, 5)
-	rstt(t[:], 7, a, 7, 0, 1, 2, 3, 4, 5, 6)
+	b0 := t0[a0>>56] ^ t1[byte(a1>>48)] ^ t2[byte(a2>>40)] ^ t3[byte(a3>>32)] ^ t4[byte(a4>>24)] ^ t5[byte(a5>>16)] ^ t6[byte(a6>>8)] ^ t7[byte(a7)]
+	b1 := t0[a1>>56] ^ t1[byte(a2>>48)] ^ t2[byte(a3>>40)] ^ t3[byte(a4>>32)] ^ t4[byte(a5>>24)] ^ t5[byte(a6>>16)] ^ t6[byte(a7>>8)] ^ t7[byte(a0)]
+	b2 := t0[a2>>56] ^ t1[byte(a3>>48)] ^ t2[byte(a4>>40)] ^ t3[byte(a5>>32)] ^ t4[byte(a6>>24)] ^ t5[byte(a7>>16)] ^ t6[byte(a0>>8)] ^ t7[byte(a1)]
+	b3 := t0[a3>>56] ^ t1[byte(a4>>48)] ^ t2[byte(a5>>40)] ^ t3[byte(a6>>32)] ^ t4[byte(a7>>24)] ^ t5[byte(a0>>16)] ^ t6[byte(a1>>8)] ^ t7[byte(a2)]
+	b4 := t0[a4>>56] ^ t1[byte(a5>>48)] ^ t2[byte(a6>>40)] ^ t3[byte(a7>>32)] ^ t4[byte(a0>>24)] ^ t5[byte(a1>>16)] ^ t6[byte(a2>>8)] ^ t7[byte(a3)]
+	b5 := t0[a5>>56] ^ t1[byte(a6>>48)] ^ t2[byte(a7>>40)] ^ t3[byte(a0>>32)] ^ t4[byte(a1>>24)] ^ t5[byte(a2>>16)] ^ t6[byte(a3>>8)] ^ t7[byte(a4)]
+	b6 := t0[a6>>56] ^ t1[byte(a7>>48)] ^ t2[byte(a0>>40)] ^ t3[byte(a1>>32)] ^ t4[byte(a2>>24)] ^ t5[byte(a3>>16)] ^ t6[byte(a4>>8)] ^ t7[byte(a5)]
+	b7 := t0[a7>>56] ^ t1[byte(a0>>48)] ^ t2[byte(a1>>40)] ^ t3[byte(a2>>32)] ^ t4[byte(a3>>24)] ^ t5[byte(a4>>16)] ^ t6[byte(a5>>8)] ^ t7[byte(a6)]
 
-	copy(a, t[:])
-}
+	// Round 1.
+	b0 ^= 0x0100000000000000
+	b1 ^= 0x1100000000000000
+	b2 ^= 0x2100000000000000
+	b3 ^= 0x3100000000000000
+	b4 ^= 0x4100000000000000
+	b5 ^= 0x5100000000000000
+	b6 ^= 0x6100000000000000
+	b7 ^= 0x7100000000000000
 
-func roundSmallQ(a []uint64, r uint64) {
-	var t [8]uint64
-	a[0] ^= qc64(0x00, r)
-	a[1] ^= qc64(0x10, r)
-	a[2] ^= qc64(0x20, r)
-	a[3] ^= qc64(0x30, r)
-	a[4] ^= qc64(0x40, r)
-	a[5] ^= qc64(0x50, r)
-	a[6] ^= qc64(0x60, r)
-	a[7] ^= qc64(0x70, r)
-	rstt(t[:], 0, a, 1, 3, 5, 7, 0, 2, 4, 6)
-	rstt(t[:], 1, a, 2, 4, 6, 0, 1, 3, 5, 7)
-	rstt(t[:], 2, a, 3, 5, 7, 1, 2, 4, 6, 0)
-	rstt(t[:], 3, a, 4, 6, 0, 2, 3, 5, 7, 1)
-	rstt(t[:], 4, a, 5, 7, 1, 3, 4, 6, 0, 2)
-	rstt(t[:], 5, a, 6, 0, 2, 4, 5, 7, 1, 3)
-	rstt(t[:], 6, a, 7, 1, 3, 5, 6, 0, 2, 4)
-	rstt(t[:], 7, a, 0, 2, 4, 6, 7, 1, 3, 5)
+	a0 = t0[b0>>56] ^ t1[byte(b1>>48)] ^ t2[byte(b2>>40)] ^ t3[byte(b3>>32)] ^ t4[byte(b4>>24)] ^ t5[byte(b5>>16)] ^ t6[byte(b6>>8)] ^ t7[byte(b7)]
+	a1 = t0[b1>>56] ^ t1[byte(b2>>48)] ^ t2[byte(b3>>40)] ^ t3[byte(b4>>32)] ^ t4[byte(b5>>24)] ^ t5[byte(b6>>16)] ^ t6[byte(b7>>8)] ^ t7[byte(b0)]
+	a2 = t0[b2>>56] ^ t1[byte(b3>>48)] ^ t2[byte(b4>>40)] ^ t3[byte(b5>>32)] ^ t4[byte(b6>>24)] ^ t5[byte(b7>>16)] ^ t6[byte(b0>>8)] ^ t7[byte(b1)]
+	a3 = t0[b3>>56] ^ t1[byte(b4>>48)] ^ t2[byte(b5>>40)] ^ t3[byte(b6>>32)] ^ t4[byte(b7>>24)] ^ t5[byte(b0>>16)] ^ t6[byte(b1>>8)] ^ t7[byte(b2)]
+	a4 = t0[b4>>56] ^ t1[byte(b5>>48)] ^ t2[byte(b6>>40)] ^ t3[byte(b7>>32)] ^ t4[byte(b0>>24)] ^ t5[byte(b1>>16)] ^ t6[byte(b2>>8)] ^ t7[byte(b3)]
+	a5 = t0[b5>>56] ^ t1[byte(b6>>48)] ^ t2[byte(b7>>40)] ^ t3[byte(b0>>32)] ^ t4[byte(b1>>24)] ^ t5[byte(b2>>16)] ^ t6[byte(b3>>8)] ^ t7[byte(b4)]
+	a6 = t0[b6>>56] ^ t1[byte(b7>>48)] ^ t2[byte(b0>>40)] ^ t3[byte(b1>>32)] ^ t4[byte(b2>>24)] ^ t5[byte(b3>>16)] ^ t6[byte(b4>>8)] ^ t7[byte(b5)]
+	a7 = t0[b7>>56] ^ t1[byte(b0>>48)] ^ t2[byte(b1>>40)] ^ t3[byte(b2>>32)] ^ t4[byte(b3>>24)] ^ t5[byte(b4>>16)] ^ t6[byte(b5>>8)] ^ t7[byte(b6)]
 
-	copy(a, t[:])
-}
+	// Round 2.
+	a0 ^= 0x0200000000000000
+	a1 ^= 0x1200000000000000
+	a2 ^= 0x2200000000000000
+	a3 ^= 0x3200000000000000
+	a4 ^= 0x4200000000000000
+	a5 ^= 0x5200000000000000
+	a6 ^= 0x6200000000000000
+	a7 ^= 0x7200000000000000
 
-func pc64(j, r uint64) uint64 {
-	return (j + r) << 56
-}
+	b0 = t0[a0>>56] ^ t1[byte(a1>>48)] ^ t2[byte(a2>>40)] ^ t3[byte(a3>>32)] ^ t4[byte(a4>>24)] ^ t5[byte(a5>>16)] ^ t6[byte(a6>>8)] ^ t7[byte(a7)]
+	b1 = t0[a1>>56] ^ t1[byte(a2>>48)] ^ t2[byte(a3>>40)] ^ t3[byte(a4>>32)] ^ t4[byte(a5>>24)] ^ t5[byte(a6>>16)] ^ t6[byte(a7>>8)] ^ t7[byte(a0)]
+	b2 = t0[a2>>56] ^ t1[byte(a3>>48)] ^ t2[byte(a4>>40)] ^ t3[byte(a5>>32)] ^ t4[byte(a6>>24)] ^ t5[byte(a7>>16)] ^ t6[byte(a0>>8)] ^ t7[byte(a1)]
+	b3 = t0[a3>>56] ^ t1[byte(a4>>48)] ^ t2[byte(a5>>40)] ^ t3[byte(a6>>32)] ^ t4[byte(a7>>24)] ^ t5[byte(a0>>16)] ^ t6[byte(a1>>8)] ^ t7[byte(a2)]
+	b4 = t0[a4>>56] ^ t1[byte(a5>>48)] ^ t2[byte(a6>>40)] ^ t3[byte(a7>>32)] ^ t4[byte(a0>>24)] ^ t5[byte(a1>>16)] ^ t6[byte(a2>>8)] ^ t7[byte(a3)]
+	b5 = t0[a5>>56] ^ t1[byte(a6>>48)] ^ t2[byte(a7>>40)] ^ t3[byte(a0>>32)] ^ t4[byte(a1>>24)] ^ t5[byte(a2>>16)] ^ t6[byte(a3>>8)] ^ t7[byte(a4)]
+	b6 = t0[a6>>56] ^ t1[byte(a7>>48)] ^ t2[byte(a0>>40)] ^ t3[byte(a1>>32)] ^ t4[byte(a2>>24)] ^ t5[byte(a3>>16)] ^ t6[byte(a4>>8)] ^ t7[byte(a5)]
+	b7 = t0[a7>>56] ^ t1[byte(a0>>48)] ^ t2[byte(a1>>40)] ^ t3[byte(a2>>32)] ^ t4[byte(a3>>24)] ^ t5[byte(a4>>16)] ^ t6[byte(a5>>8)] ^ t7[byte(a6)]
+
+	// Round 3.
+	b0 ^= 0x0300000000000000
+	b1 ^= 0x1300000000000000
+	b2 ^= 0x2300000000000000
+	b3 ^= 0x3300000000000000
+	b4 ^= 0x4300000000000000
+	b5 ^= 0x5300000000000000
+	b6 ^= 0x6300000000000000
+	b7 ^= 0x7300000000000000
+
+	a0 = t0[b0>>56] ^ t1[byte(b1>>48)] ^ t2[byte(b2>>40)] ^ t3[byte(b3>>32)] ^ t4[byte(b4>>24)] ^ t5[byte(b5>>16)] ^ t6[byte(b6>>8)] ^ t7[byte(b7)]
+	a1 = t0[b1>>56] ^ t1[byte(b2>>48)] ^ t2[byte(b3>>40)] ^ t3[byte(b4>>32)] ^ t4[byte(b5>>24)] ^ t5[byte(b6>>16)] ^ t6[byte(b7>>8)] ^ t7[byte(b0)]
+	a2 = t0[b2>>56] ^ t1[byte(b3>>48)] ^ t2[byte(b4>>40)] ^ t3[byte(b5>>32)] ^ t4[byte(b6>>24)] ^ t5[byte(b7>>16)] ^ t6[byte(b0>>8)] ^ t7[byte(b1)]
+	a3 = t0[b3>>56] ^ t1[byte(b4>>48)] ^ t2[byte(b5>>40)] ^ t3[byte(b6>>32)] ^ t4[byte(b7>>24)] ^ t5[byte(b0>>16)] ^ t6[byte(b1>>8)] ^ t7[byte(b2)]
+	a4 = t0[b4>>56] ^ t1[byte(b5>>48)] ^ t2[byte(b6>>40)] ^ t3[byte(b7>>32)] ^ t4[byte(b0>>24)] ^ t5[byte(b1>>16)] ^ t6[byte(b2>>8)] ^ t7[byte(b3)]
+	a5 = t0[b5>>56] ^ t1[byte(b6>>48)] ^ t2[byte(b7>>40)] ^ t3[byte(b0>>32)] ^ t4[byte(b1>>24)] ^ t5[byte(b2>>16)] ^ t6[byte(b3>>8)] ^ t7[byte(b4)]
+	a6 = t0[b6>>56] ^ t1[byte(b7>>48)] ^ t2[byte(b0>>40)] ^ t3[byte(b1>>32)] ^ t4[byte(b2>>24)] ^ t5[byte(b3>>16)] ^ t6[byte(b4>>8)] ^ t7[byte(b5)]
+	a7 = t0[b7>>56] ^ t1[byte(b0>>48)] ^ t2[byte(b1>>40)] ^ t3[byte(b2>>32)] ^ t4[byte(b3>>24)] ^ t5[byte(b4>>16)] ^ t6[byte(b5>>8)] ^ t7[byte(b6)]
+
+	// Round 4.
+	a0 ^= 0x0400000000000000
+	a1 ^= 0x1400000000000000
+	a2 ^= 0x2400000000000000
+	a3 ^= 0x3400000000000000
+	a4 ^= 0x4400000000000000
+	a5 ^= 0x5400000000000000
+	a6 ^= 0x6400000000000000
+	a7 ^= 0x7400000000000000
+
+	b0 = t0[a0>>56] ^ t1[byte(a1>>48)] ^ t2[byte(a2>>40)] ^ t3[byte(a3>>32)] ^ t4[byte(a4>>24)] ^ t5[byte(a5>>16)] ^ t6[byte(a6>>8)] ^ t7[byte(a7)]
+	b1 = t0[a1>>56] ^ t1[byte(a2>>48)] ^ t2[byte(a3>>40)] ^ t3[byte(a4>>32)] ^ t4[byte(a5>>24)] ^ t5[byte(a6>>16)] ^ t6[byte(a7>>8)] ^ t7[byte(a0)]
+	b2 = t0[a2>>56] ^ t1[byte(a3>>48)] ^ t2[byte(a4>>40)] ^ t3[byte(a5>>32)] ^ t4[byte(a6>>24)] ^ t5[byte(a7>>16)] ^ t6[byte(a0>>8)] ^ t7[byte(a1)]
+	b3 = t0[a3>>56] ^ t1[byte(a4>>48)] ^ t2[byte(a5>>40)] ^ t3[byte(a6>>32)] ^ t4[byte(a7>>24)] ^ t5[byte(a0>>16)] ^ t6[byte(a1>>8)] ^ t7[byte(a2)]
+	b4 = t0[a4>>56] ^ t1[byte(a5>>48)] ^ t2[byte(a6>>40)] ^ t3[byte(a7>>32)] ^ t4[byte(a0>>24)] ^ t5[byte(a1>>16)] ^ t6[byte(a2>>8)] ^ t7[byte(a3)]
+	b5 = t0[a5>>56] ^ t1[byte(a6>>48)] ^ t2[byte(a7>>40)] ^ t3[byte(a0>>32)] ^ t4[byte(a1>>24)] ^ t5[byte(a2>>16)] ^ t6[byte(a3>>8)] ^ t7[byte(a4)]
+	b6 = t0[a6>>56] ^ t1[byte(a7>>48)] ^ t2[byte(a0>>40)] ^ t3[byte(a1>>32)] ^ t4[byte(a2>>24)] ^ t5[byte(a3>>16)] ^ t6[byte(a4>>8)] ^ t7[byte(a5)]
+	b7 = t0[a7>>56] ^ t1[byte(a0>>48)] ^ t2[byte(a1>>40)] ^ t3[byte(a2>>32)] ^ t4[byte(a3>>24)] ^ t5[byte(a4>>16)] ^ t6[byte(a5>>8)] ^ t7[byte(a6)]
 
-func qc64(j, r uint64) uint64 {
-	return r ^ (^j)
+	// Round 5.
+	b0 ^= 0x0500000000000000
+	b1 ^= 0x1500000000000000
+	b2 ^= 0x2500000000000000
+	b3 ^= 0x3500000000000000
+	b4 ^= 0x4500000000000000
+	b5 ^= 0x5500000000000000
+	b6 ^= 0x6500000000000000
+	b7 ^= 0x7500000000000000
+
+	a0 = t0[b0>>56] ^ t1[byte(b1>>48)] ^ t2[byte(b2>>40)] ^ t3[byte(b3>>32)] ^ t4[byte(b4>>24)] ^ t5[byte(b5>>16)] ^ t6[byte(b6>>8)] ^ t7[byte(b7)]
+	a1 = t0[b1>>56] ^ t1[byte(b2>>48)] ^ t2[byte(b3>>40)] ^ t3[byte(b4>>32)] ^ t4[byte(b5>>24)] ^ t5[byte(b6>>16)] ^ t6[byte(b7>>8)] ^ t7[byte(b0)]
+	a2 = t0[b2>>56] ^ t1[byte(b3>>48)] ^ t2[byte(b4>>40)] ^ t3[byte(b5>>32)] ^ t4[byte(b6>>24)] ^ t5[byte(b7>>16)] ^ t6[byte(b0>>8)] ^ t7[byte(b1)]
+	a3 = t0[b3>>56] ^ t1[byte(b4>>48)] ^ t2[byte(b5>>40)] ^ t3[byte(b6>>32)] ^ t4[byte(b7>>24)] ^ t5[byte(b0>>16)] ^ t6[byte(b1>>8)] ^ t7[byte(b2)]
+	a4 = t0[b4>>56] ^ t1[byte(b5>>48)] ^ t2[byte(b6>>40)] ^ t3[byte(b7>>32)] ^ t4[byte(b0>>24)] ^ t5[byte(b1>>16)] ^ t6[byte(b2>>8)] ^ t7[byte(b3)]
+	a5 = t0[b5>>56] ^ t1[byte(b6>>48)] ^ t2[byte(b7>>40)] ^ t3[byte(b0>>32)] ^ t4[byte(b1>>24)] ^ t5[byte(b2>>16)] ^ t6[byte(b3>>8)] ^ t7[byte(b4)]
+	a6 = t0[b6>>56] ^ t1[byte(b7>>48)] ^ t2[byte(b0>>40)] ^ t3[byte(b1>>32)] ^ t4[byte(b2>>24)] ^ t5[byte(b3>>16)] ^ t6[byte(b4>>8)] ^ t7[byte(b5)]
+	a7 = t0[b7>>56] ^ t1[byte(b0>>48)] ^ t2[byte(b1>>40)] ^ t3[byte(b2>>32)] ^ t4[byte(b3>>24)] ^ t5[byte(b4>>16)] ^ t6[byte(b5>>8)] ^ t7[byte(b6)]
+
+	// Round 6.
+	a0 ^= 0x0600000000000000
+	a1 ^= 0x1600000000000000
+	a2 ^= 0x2600000000000000
+	a3 ^= 0x3600000000000000
+	a4 ^= 0x4600000000000000
+	a5 ^= 0x5600000000000000
+	a6 ^= 0x6600000000000000
+	a7 ^= 0x7600000000000000
+
+	b0 = t0[a0>>56] ^ t1[byte(a1>>48)] ^ t2[byte(a2>>40)] ^ t3[byte(a3>>32)] ^ t4[byte(a4>>24)] ^ t5[byte(a5>>16)] ^ t6[byte(a6>>8)] ^ t7[byte(a7)]
+	b1 = t0[a1>>56] ^ t1[byte(a2>>48)] ^ t2[byte(a3>>40)] ^ t3[byte(a4>>32)] ^ t4[byte(a5>>24)] ^ t5[byte(a6>>16)] ^ t6[byte(a7>>8)] ^ t7[byte(a0)]
+	b2 = t0[a2>>56] ^ t1[byte(a3>>48)] ^ t2[byte(a4>>40)] ^ t3[byte(a5>>32)] ^ t4[byte(a6>>24)] ^ t5[byte(a7>>16)] ^ t6[byte(a0>>8)] ^ t7[byte(a1)]
+	b3 = t0[a3>>56] ^ t1[byte(a4>>48)] ^ t2[byte(a5>>40)] ^ t3[byte(a6>>32)] ^ t4[byte(a7>>24)] ^ t5[byte(a0>>16)] ^ t6[byte(a1>>8)] ^ t7[byte(a2)]
+	b4 = t0[a4>>56] ^ t1[byte(a5>>48)] ^ t2[byte(a6>>40)] ^ t3[byte(a7>>32)] ^ t4[byte(a0>>24)] ^ t5[byte(a1>>16)] ^ t6[byte(a2>>8)] ^ t7[byte(a3)]
+	b5 = t0[a5>>56] ^ t1[byte(a6>>48)] ^ t2[byte(a7>>40)] ^ t3[byte(a0>>32)] ^ t4[byte(a1>>24)] ^ t5[byte(a2>>16)] ^ t6[byte(a3>>8)] ^ t7[byte(a4)]
+	b6 = t0[a6>>56] ^ t1[byte(a7>>48)] ^ t2[byte(a0>>40)] ^ t3[byte(a1>>32)] ^ t4[byte(a2>>24)] ^ t5[byte(a3>>16)] ^ t6[byte(a4>>8)] ^ t7[byte(a5)]
+	b7 = t0[a7>>56] ^ t1[byte(a0>>48)] ^ t2[byte(a1>>40)] ^ t3[byte(a2>>32)] ^ t4[byte(a3>>24)] ^ t5[byte(a4>>16)] ^ t6[byte(a5>>8)] ^ t7[byte(a6)]
+
+	// Round 7.
+	b0 ^= 0x0700000000000000
+	b1 ^= 0x1700000000000000
+	b2 ^= 0x2700000000000000
+	b3 ^= 0x3700000000000000
+	b4 ^= 0x4700000000000000
+	b5 ^= 0x5700000000000000
+	b6 ^= 0x6700000000000000
+	b7 ^= 0x7700000000000000
+
+	a0 = t0[b0>>56] ^ t1[byte(b1>>48)] ^ t2[byte(b2>>40)] ^ t3[byte(b3>>32)] ^ t4[byte(b4>>24)] ^ t5[byte(b5>>16)] ^ t6[byte(b6>>8)] ^ t7[byte(b7)]
+	a1 = t0[b1>>56] ^ t1[byte(b2>>48)] ^ t2[byte(b3>>40)] ^ t3[byte(b4>>32)] ^ t4[byte(b5>>24)] ^ t5[byte(b6>>16)] ^ t6[byte(b7>>8)] ^ t7[byte(b0)]
+	a2 = t0[b2>>56] ^ t1[byte(b3>>48)] ^ t2[byte(b4>>40)] ^ t3[byte(b5>>32)] ^ t4[byte(b6>>24)] ^ t5[byte(b7>>16)] ^ t6[byte(b0>>8)] ^ t7[byte(b1)]
+	a3 = t0[b3>>56] ^ t1[byte(b4>>48)] ^ t2[byte(b5>>40)] ^ t3[byte(b6>>32)] ^ t4[byte(b7>>24)] ^ t5[byte(b0>>16)] ^ t6[byte(b1>>8)] ^ t7[byte(b2)]
+	a4 = t0[b4>>56] ^ t1[byte(b5>>48)] ^ t2[byte(b6>>40)] ^ t3[byte(b7>>32)] ^ t4[byte(b0>>24)] ^ t5[byte(b1>>16)] ^ t6[byte(b2>>8)] ^ t7[byte(b3)]
+	a5 = t0[b5>>56] ^ t1[byte(b6>>48)] ^ t2[byte(b7>>40)] ^ t3[byte(b0>>32)] ^ t4[byte(b1>>24)] ^ t5[byte(b2>>16)] ^ t6[byte(b3>>8)] ^ t7[byte(b4)]
+	a6 = t0[b6>>56] ^ t1[byte(b7>>48)] ^ t2[byte(b0>>40)] ^ t3[byte(b1>>32)] ^ t4[byte(b2>>24)] ^ t5[byte(b3>>16)] ^ t6[byte(b4>>8)] ^ t7[byte(b5)]
+	a7 = t0[b7>>56] ^ t1[byte(b0>>48)] ^ t2[byte(b1>>40)] ^ t3[byte(b2>>32)] ^ t4[byte(b3>>24)] ^ t5[byte(b4>>16)] ^ t6[byte(b5>>8)] ^ t7[byte(b6)]
+
+	// Round 8.
+	a0 ^= 0x0800000000000000
+	a1 ^= 0x1800000000000000
+	a2 ^= 0x2800000000000000
+	a3 ^= 0x3800000000000000
+	a4 ^= 0x4800000000000000
+	a5 ^= 0x5800000000000000
+	a6 ^= 0x6800000000000000
+	a7 ^= 0x7800000000000000
+
+	b0 = t0[a0>>56] ^ t1[byte(a1>>48)] ^ t2[byte(a2>>40)] ^ t3[byte(a3>>32)] ^ t4[byte(a4>>24)] ^ t5[byte(a5>>16)] ^ t6[byte(a6>>8)] ^ t7[byte(a7)]
+	b1 = t0[a1>>56] ^ t1[byte(a2>>48)] ^ t2[byte(a3>>40)] ^ t3[byte(a4>>32)] ^ t4[byte(a5>>24)] ^ t5[byte(a6>>16)] ^ t6[byte(a7>>8)] ^ t7[byte(a0)]
+	b2 = t0[a2>>56] ^ t1[byte(a3>>48)] ^ t2[byte(a4>>40)] ^ t3[byte(a5>>32)] ^ t4[byte(a6>>24)] ^ t5[byte(a7>>16)] ^ t6[byte(a0>>8)] ^ t7[byte(a1)]
+	b3 = t0[a3>>56] ^ t1[byte(a4>>48)] ^ t2[byte(a5>>40)] ^ t3[byte(a6>>32)] ^ t4[byte(a7>>24)] ^ t5[byte(a0>>16)] ^ t6[byte(a1>>8)] ^ t7[byte(a2)]
+	b4 = t0[a4>>56] ^ t1[byte(a5>>48)] ^ t2[byte(a6>>40)] ^ t3[byte(a7>>32)] ^ t4[byte(a0>>24)] ^ t5[byte(a1>>16)] ^ t6[byte(a2>>8)] ^ t7[byte(a3)]
+	b5 = t0[a5>>56] ^ t1[byte(a6>>48)] ^ t2[byte(a7>>40)] ^ t3[byte(a0>>32)] ^ t4[byte(a1>>24)] ^ t5[byte(a2>>16)] ^ t6[byte(a3>>8)] ^ t7[byte(a4)]
+	b6 = t0[a6>>56] ^ t1[byte(a7>>48)] ^ t2[byte(a0>>40)] ^ t3[byte(a1>>32)] ^ t4[byte(a2>>24)] ^ t5[byte(a3>>16)] ^ t6[byte(a4>>8)] ^ t7[byte(a5)]
+	b7 = t0[a7>>56] ^ t1[byte(a0>>48)] ^ t2[byte(a1>>40)] ^ t3[byte(a2>>32)] ^ t4[byte(a3>>24)] ^ t5[byte(a4>>16)] ^ t6[byte(a5>>8)] ^ t7[byte(a6)]
+
+	// Round 9.
+	b0 ^= 0x0900000000000000
+	b1 ^= 0x1900000000000000
+	b2 ^= 0x2900000000000000
+	b3 ^= 0x3900000000000000
+	b4 ^= 0x4900000000000000
+	b5 ^= 0x5900000000000000
+	b6 ^= 0x6900000000000000
+	b7 ^= 0x7900000000000000
+
+	a0 = t0[b0>>56] ^ t1[byte(b1>>48)] ^ t2[byte(b2>>40)] ^ t3[byte(b3>>32)] ^ t4[byte(b4>>24)] ^ t5[byte(b5>>16)] ^ t6[byte(b6>>8)] ^ t7[byte(b7)]
+	a1 = t0[b1>>56] ^ t1[byte(b2>>48)] ^ t2[byte(b3>>40)] ^ t3[byte(b4>>32)] ^ t4[byte(b5>>24)] ^ t5[byte(b6>>16)] ^ t6[byte(b7>>8)] ^ t7[byte(b0)]
+	a2 = t0[b2>>56] ^ t1[byte(b3>>48)] ^ t2[byte(b4>>40)] ^ t3[byte(b5>>32)] ^ t4[byte(b6>>24)] ^ t5[byte(b7>>16)] ^ t6[byte(b0>>8)] ^ t7[byte(b1)]
+	a3 = t0[b3>>56] ^ t1[byte(b4>>48)] ^ t2[byte(b5>>40)] ^ t3[byte(b6>>32)] ^ t4[byte(b7>>24)] ^ t5[byte(b0>>16)] ^ t6[byte(b1>>8)] ^ t7[byte(b2)]
+	a4 = t0[b4>>56] ^ t1[byte(b5>>48)] ^ t2[byte(b6>>40)] ^ t3[byte(b7>>32)] ^ t4[byte(b0>>24)] ^ t5[byte(b1>>16)] ^ t6[byte(b2>>8)] ^ t7[byte(b3)]
+	a5 = t0[b5>>56] ^ t1[byte(b6>>48)] ^ t2[byte(b7>>40)] ^ t3[byte(b0>>32)] ^ t4[byte(b1>>24)] ^ t5[byte(b2>>16)] ^ t6[byte(b3>>8)] ^ t7[byte(b4)]
+	a6 = t0[b6>>56] ^ t1[byte(b7>>48)] ^ t2[byte(b0>>40)] ^ t3[byte(b1>>32)] ^ t4[byte(b2>>24)] ^ t5[byte(b3>>16)] ^ t6[byte(b4>>8)] ^ t7[byte(b5)]
+	a7 = t0[b7>>56] ^ t1[byte(b0>>48)] ^ t2[byte(b1>>40)] ^ t3[byte(b2>>32)] ^ t4[byte(b3>>24)] ^ t5[byte(b4>>16)] ^ t6[byte(b5>>8)] ^ t7[byte(b6)]
+
+	x[0] = a0
+	x[1] = a1
+	x[2] = a2
+	x[3] = a3
+	x[4] = a4
+	x[5] = a5
+	x[6] = a6
+	x[7] = a7
 }
 
-func rstt(t []uint64, d uint64, a []uint64, b0, b1, b2, b3, b4, b5, b6, b7 uint64) {
-	t[d] = t0[a[b0]>>56] ^ t1[(a[b1]>>48)&0xFF] ^ t2[(a[b2]>>40)&0xFF] ^ t3[(a[b3]>>32)&0xFF] ^ t4[(a[b4]>>24)&0xFF] ^ t5[(a[b5]>>16)&0xFF] ^ t6[(a[b6]>>8)&0xFF] ^ t7[a[b7]&0xFF]
+// permSmallQ applies the Groestl permutation Q to the state x.
+func permSmallQ(x *[8]uint64) {
+	a0 := x[0]
+	a1 := x[1]
+	a2 := x[2]
+	a3 := x[3]
+	a4 := x[4]
+	a5 := x[5]
+	a6 := x[6]
+	a7 := x[7]
+
+	// Round 0.
+	a0 ^= 0xffffffffffffffff
+	a1 ^= 0xffffffffffffffef
+	a2 ^= 0xffffffffffffffdf
+	a3 ^= 0xffffffffffffffcf
+	a4 ^= 0xffffffffffffffbf
+	a5 ^= 0xffffffffffffffaf
+	a6 ^= 0xffffffffffffff9f
+	a7 ^= 0xffffffffffffff8f
+
+	b0 := t0[a1>>56] ^ t1[byte(a3>>48)] ^ t2[byte(a5>>40)] ^ t3[byte(a7>>32)] ^ t4[byte(a0>>24)] ^ t5[byte(a2>>16)] ^ t6[byte(a4>>8)] ^ t7[byte(a6)]
+	b1 := t0[a2>>56] ^ t1[byte(a4>>48)] ^ t2[byte(a6>>40)] ^ t3[byte(a0>>32)] ^ t4[byte(a1>>24)] ^ t5[byte(a3>>16)] ^ t6[byte(a5>>8)] ^ t7[byte(a7)]
+	b2 := t0[a3>>56] ^ t1[byte(a5>>48)] ^ t2[byte(a7>>40)] ^ t3[byte(a1>>32)] ^ t4[byte(a2>>24)] ^ t5[byte(a4>>16)] ^ t6[byte(a6>>8)] ^ t7[byte(a0)]
+	b3 := t0[a4>>56] ^ t1[byte(a6>>48)] ^ t2[byte(a0>>40)] ^ t3[byte(a2>>32)] ^ t4[byte(a3>>24)] ^ t5[byte(a5>>16)] ^ t6[byte(a7>>8)] ^ t7[byte(a1)]
+	b4 := t0[a5>>56] ^ t1[byte(a7>>48)] ^ t2[byte(a1>>40)] ^ t3[byte(a3>>32)] ^ t4[byte(a4>>24)] ^ t5[byte(a6>>16)] ^ t6[byte(a0>>8)] ^ t7[byte(a2)]
+	b5 := t0[a6>>56] ^ t1[byte(a0>>48)] ^ t2[byte(a2>>40)] ^ t3[byte(a4>>32)] ^ t4[byte(a5>>24)] ^ t5[byte(a7>>16)] ^ t6[byte(a1>>8)] ^ t7[byte(a3)]
+	b6 := t0[a7>>56] ^ t1[byte(a1>>48)] ^ t2[byte(a3>>40)] ^ t3[byte(a5>>32)] ^ t4[byte(a6>>24)] ^ t5[byte(a0>>16)] ^ t6[byte(a2>>8)] ^ t7[byte(a4)]
+	b7 := t0[a0>>56] ^ t1[byte(a2>>48)] ^ t2[byte(a4>>40)] ^ t3[byte(a6>>32)] ^ t4[byte(a7>>24)] ^ t5[byte(a1>>16)] ^ t6[byte(a3>>8)] ^ t7[byte(a5)]
+
+	// Round 1.
+	b0 ^= 0xfffffffffffffffe
+	b1 ^= 0xffffffffffffffee
+	b2 ^= 0xffffffffffffffde
+	b3 ^= 0xffffffffffffffce
+	b4 ^= 0xffffffffffffffbe
+	b5 ^= 0xffffffffffffffae
+	b6 ^= 0xffffffffffffff9e
+	b7 ^= 0xffffffffffffff8e
+
+	a0 = t0[b1>>56] ^ t1[byte(b3>>48)] ^ t2[byte(b5>>40)] ^ t3[byte(b7>>32)] ^ t4[byte(b0>>24)] ^ t5[byte(b2>>16)] ^ t6[byte(b4>>8)] ^ t7[byte(b6)]
+	a1 = t0[b2>>56] ^ t1[byte(b4>>48)] ^ t2[byte(b6>>40)] ^ t3[byte(b0>>32)] ^ t4[byte(b1>>24)] ^ t5[byte(b3>>16)] ^ t6[byte(b5>>8)] ^ t7[byte(b7)]
+	a2 = t0[b3>>56] ^ t1[byte(b5>>48)] ^ t2[byte(b7>>40)] ^ t3[byte(b1>>32)] ^ t4[byte(b2>>24)] ^ t5[byte(b4>>16)] ^ t6[byte(b6>>8)] ^ t7[byte(b0)]
+	a3 = t0[b4>>56] ^ t1[byte(b6>>48)] ^ t2[byte(b0>>40)] ^ t3[byte(b2>>32)] ^ t4[byte(b3>>24)] ^ t5[byte(b5>>16)] ^ t6[byte(b7>>8)] ^ t7[byte(b1)]
+	a4 = t0[b5>>56] ^ t1[byte(b7>>48)] ^ t2[byte(b1>>40)] ^ t3[byte(b3>>32)] ^ t4[byte(b4>>24)] ^ t5[byte(b6>>16)] ^ t6[byte(b0>>8)] ^ t7[byte(b2)]
+	a5 = t0[b6>>56] ^ t1[byte(b0>>48)] ^ t2[byte(b2>>40)] ^ t3[byte(b4>>32)] ^ t4[byte(b5>>24)] ^ t5[byte(b7>>16)] ^ t6[byte(b1>>8)] ^ t7[byte(b3)]
+	a6 = t0[b7>>56] ^ t1[byte(b1>>48)] ^ t2[byte(b3>>40)] ^ t3[byte(b5>>32)] ^ t4[byte(b6>>24)] ^ t5[byte(b0>>16)] ^ t6[byte(b2>>8)] ^ t7[byte(b4)]
+	a7 = t0[b0>>56] ^ t1[byte(b2>>48)] ^ t2[byte(b4>>40)] ^ t3[byte(b6>>32)] ^ t4[byte(b7>>24)] ^ t5[byte(b1>>16)] ^ t6[byte(b3>>8)] ^ t7[byte(b5)]
+
+	// Round 2.
+	a0 ^= 0xfffffffffffffffd
+	a1 ^= 0xffffffffffffffed
+	a2 ^= 0xffffffffffffffdd
+	a3 ^= 0xffffffffffffffcd
+	a4 ^= 0xffffffffffffffbd
+	a5 ^= 0xffffffffffffffad
+	a6 ^= 0xffffffffffffff9d
+	a7 ^= 0xffffffffffffff8d
+
+	b0 = t0[a1>>56] ^ t1[byte(a3>>48)] ^ t2[byte(a5>>40)] ^ t3[byte(a7>>32)] ^ t4[byte(a0>>24)] ^ t5[byte(a2>>16)] ^ t6[byte(a4>>8)] ^ t7[byte(a6)]
+	b1 = t0[a2>>56] ^ t1[byte(a4>>48)] ^ t2[byte(a6>>40)] ^ t3[byte(a0>>32)] ^ t4[byte(a1>>24)] ^ t5[byte(a3>>16)] ^ t6[byte(a5>>8)] ^ t7[byte(a7)]
+	b2 = t0[a3>>56] ^ t1[byte(a5>>48)] ^ t2[byte(a7>>40)] ^ t3[byte(a1>>32)] ^ t4[byte(a2>>24)] ^ t5[byte(a4>>16)] ^ t6[byte(a6>>8)] ^ t7[byte(a0)]
+	b3 = t0[a4>>56] ^ t1[byte(a6>>48)] ^ t2[byte(a0>>40)] ^ t3[byte(a2>>32)] ^ t4[byte(a3>>24)] ^ t5[byte(a5>>16)] ^ t6[byte(a7>>8)] ^ t7[byte(a1)]
+	b4 = t0[a5>>56] ^ t1[byte(a7>>48)] ^ t2[byte(a1>>40)] ^ t3[byte(a3>>32)] ^ t4[byte(a4>>24)] ^ t5[byte(a6>>16)] ^ t6[byte(a0>>8)] ^ t7[byte(a2)]
+	b5 = t0[a6>>56] ^ t1[byte(a0>>48)] ^ t2[byte(a2>>40)] ^ t3[byte(a4>>32)] ^ t4[byte(a5>>24)] ^ t5[byte(a7>>16)] ^ t6[byte(a1>>8)] ^ t7[byte(a3)]
+	b6 = t0[a7>>56] ^ t1[byte(a1>>48)] ^ t2[byte(a3>>40)] ^ t3[byte(a5>>32)] ^ t4[byte(a6>>24)] ^ t5[byte(a0>>16)] ^ t6[byte(a2>>8)] ^ t7[byte(a4)]
+	b7 = t0[a0>>56] ^ t1[byte(a2>>48)] ^ t2[byte(a4>>40)] ^ t3[byte(a6>>32)] ^ t4[byte(a7>>24)] ^ t5[byte(a1>>16)] ^ t6[byte(a3>>8)] ^ t7[byte(a5)]
+
+	// Round 3.
+	b0 ^= 0xfffffffffffffffc
+	b1 ^= 0xffffffffffffffec
+	b2 ^= 0xffffffffffffffdc
+	b3 ^= 0xffffffffffffffcc
+	b4 ^= 0xffffffffffffffbc
+	b5 ^= 0xffffffffffffffac
+	b6 ^= 0xffffffffffffff9c
+	b7 ^= 0xffffffffffffff8c
+
+	a0 = t0[b1>>56] ^ t1[byte(b3>>48)] ^ t2[byte(b5>>40)] ^ t3[byte(b7>>32)] ^ t4[byte(b0>>24)] ^ t5[byte(b2>>16)] ^ t6[byte(b4>>8)] ^ t7[byte(b6)]
+	a1 = t0[b2>>56] ^ t1[byte(b4>>48)] ^ t2[byte(b6>>40)] ^ t3[byte(b0>>32)] ^ t4[byte(b1>>24)] ^ t5[byte(b3>>16)] ^ t6[byte(b5>>8)] ^ t7[byte(b7)]
+	a2 = t0[b3>>56] ^ t1[byte(b5>>48)] ^ t2[byte(b7>>40)] ^ t3[byte(b1>>32)] ^ t4[byte(b2>>24)] ^ t5[byte(b4>>16)] ^ t6[byte(b6>>8)] ^ t7[byte(b0)]
+	a3 = t0[b4>>56] ^ t1[byte(b6>>48)] ^ t2[byte(b0>>40)] ^ t3[byte(b2>>32)] ^ t4[byte(b3>>24)] ^ t5[byte(b5>>16)] ^ t6[byte(b7>>8)] ^ t7[byte(b1)]
+	a4 = t0[b5>>56] ^ t1[byte(b7>>48)] ^ t2[byte(b1>>40)] ^ t3[byte(b3>>32)] ^ t4[byte(b4>>24)] ^ t5[byte(b6>>16)] ^ t6[byte(b0>>8)] ^ t7[byte(b2)]
+	a5 = t0[b6>>56] ^ t1[byte(b0>>48)] ^ t2[byte(b2>>40)] ^ t3[byte(b4>>32)] ^ t4[byte(b5>>24)] ^ t5[byte(b7>>16)] ^ t6[byte(b1>>8)] ^ t7[byte(b3)]
+	a6 = t0[b7>>56] ^ t1[byte(b1>>48)] ^ t2[byte(b3>>40)] ^ t3[byte(b5>>32)] ^ t4[byte(b6>>24)] ^ t5[byte(b0>>16)] ^ t6[byte(b2>>8)] ^ t7[byte(b4)]
+	a7 = t0[b0>>56] ^ t1[byte(b2>>48)] ^ t2[byte(b4>>40)] ^ t3[byte(b6>>32)] ^ t4[byte(b7>>24)] ^ t5[byte(b1>>16)] ^ t6[byte(b3>>8)] ^ t7[byte(b5)]
+
+	// Round 4.
+	a0 ^= 0xfffffffffffffffb
+	a1 ^= 0xffffffffffffffeb
+	a2 ^= 0xffffffffffffffdb
+	a3 ^= 0xffffffffffffffcb
+	a4 ^= 0xffffffffffffffbb
+	a5 ^= 0xffffffffffffffab
+	a6 ^= 0xffffffffffffff9b
+	a7 ^= 0xffffffffffffff8b
+
+	b0 = t0[a1>>56] ^ t1[byte(a3>>48)] ^ t2[byte(a5>>40)] ^ t3[byte(a7>>32)] ^ t4[byte(a0>>24)] ^ t5[byte(a2>>16)] ^ t6[byte(a4>>8)] ^ t7[byte(a6)]
+	b1 = t0[a2>>56] ^ t1[byte(a4>>48)] ^ t2[byte(a6>>40)] ^ t3[byte(a0>>32)] ^ t4[byte(a1>>24)] ^ t5[byte(a3>>16)] ^ t6[byte(a5>>8)] ^ t7[byte(a7)]
+	b2 = t0[a3>>56] ^ t1[byte(a5>>48)] ^ t2[byte(a7>>40)] ^ t3[byte(a1>>32)] ^ t4[byte(a2>>24)] ^ t5[byte(a4>>16)] ^ t6[byte(a6>>8)] ^ t7[byte(a0)]
+	b3 = t0[a4>>56] ^ t1[byte(a6>>48)] ^ t2[byte(a0>>40)] ^ t3[byte(a2>>32)] ^ t4[byte(a3>>24)] ^ t5[byte(a5>>16)] ^ t6[byte(a7>>8)] ^ t7[byte(a1)]
+	b4 = t0[a5>>56] ^ t1[byte(a7>>48)] ^ t2[byte(a1>>40)] ^ t3[byte(a3>>32)] ^ t4[byte(a4>>24)] ^ t5[byte(a6>>16)] ^ t6[byte(a0>>8)] ^ t7[byte(a2)]
+	b5 = t0[a6>>56] ^ t1[byte(a0>>48)] ^ t2[byte(a2>>40)] ^ t3[byte(a4>>32)] ^ t4[byte(a5>>24)] ^ t5[byte(a7>>16)] ^ t6[byte(a1>>8)] ^ t7[byte(a3)]
+	b6 = t0[a7>>56] ^ t1[byte(a1>>48)] ^ t2[byte(a3>>40)] ^ t3[byte(a5>>32)] ^ t4[byte(a6>>24)] ^ t5[byte(a0>>16)] ^ t6[byte(a2>>8)] ^ t7[byte(a4)]
+	b7 = t0[a0>>56] ^ t1[byte(a2>>48)] ^ t2[byte(a4>>40)] ^ t3[byte(a6>>32)] ^ t4[byte(a7>>24)] ^ t5[byte(a1>>16)] ^ t6[byte(a3>>8)] ^ t7[byte(a5)]
+
+	// Round 5.
+	b0 ^= 0xfffffffffffffffa
+	b1 ^= 0xffffffffffffffea
+	b2 ^= 0xffffffffffffffda
+	b3 ^= 0xffffffffffffffca
+	b4 ^= 0xffffffffffffffba
+	b5 ^= 0xffffffffffffffaa
+	b6 ^= 0xffffffffffffff9a
+	b7 ^= 0xffffffffffffff8a
+
+	a0 = t0[b1>>56] ^ t1[byte(b3>>48)] ^ t2[byte(b5>>40)] ^ t3[byte(b7>>32)] ^ t4[byte(b0>>24)] ^ t5[byte(b2>>16)] ^ t6[byte(b4>>8)] ^ t7[byte(b6)]
+	a1 = t0[b2>>56] ^ t1[byte(b4>>48)] ^ t2[byte(b6>>40)] ^ t3[byte(b0>>32)] ^ t4[byte(b1>>24)] ^ t5[byte(b3>>16)] ^ t6[byte(b5>>8)] ^ t7[byte(b7)]
+	a2 = t0[b3>>56] ^ t1[byte(b5>>48)] ^ t2[byte(b7>>40)] ^ t3[byte(b1>>32)] ^ t4[byte(b2>>24)] ^ t5[byte(b4>>16)] ^ t6[byte(b6>>8)] ^ t7[byte(b0)]
+	a3 = t0[b4>>56] ^ t1[byte(b6>>48)] ^ t2[byte(b0>>40)] ^ t3[byte(b2>>32)] ^ t4[byte(b3>>24)] ^ t5[byte(b5>>16)] ^ t6[byte(b7>>8)] ^ t7[byte(b1)]
+	a4 = t0[b5>>56] ^ t1[byte(b7>>48)] ^ t2[byte(b1>>40)] ^ t3[byte(b3>>32)] ^ t4[byte(b4>>24)] ^ t5[byte(b6>>16)] ^ t6[byte(b0>>8)] ^ t7[byte(b2)]
+	a5 = t0[b6>>56] ^ t1[byte(b0>>48)] ^ t2[byte(b2>>40)] ^ t3[byte(b4>>32)] ^ t4[byte(b5>>24)] ^ t5[byte(b7>>16)] ^ t6[byte(b1>>8)] ^ t7[byte(b3)]
+	a6 = t0[b7>>56] ^ t1[byte(b1>>48)] ^ t2[byte(b3>>40)] ^ t3[byte(b5>>32)] ^ t4[byte(b6>>24)] ^ t5[byte(b0>>16)] ^ t6[byte(b2>>8)] ^ t7[byte(b4)]
+	a7 = t0[b0>>56] ^ t1[byte(b2>>48)] ^ t2[byte(b4>>40)] ^ t3[byte(b6>>32)] ^ t4[byte(b7>>24)] ^ t5[byte(b1>>16)] ^ t6[byte(b3>>8)] ^ t7[byte(b5)]
+
+	// Round 6.
+	a0 ^= 0xfffffffffffffff9
+	a1 ^= 0xffffffffffffffe9
+	a2 ^= 0xffffffffffffffd9
+	a3 ^= 0xffffffffffffffc9
+	a4 ^= 0xffffffffffffffb9
+	a5 ^= 0xffffffffffffffa9
+	a6 ^= 0xffffffffffffff99
+	a7 ^= 0xffffffffffffff89
+
+	b0 = t0[a1>>56] ^ t1[byte(a3>>48)] ^ t2[byte(a5>>40)] ^ t3[byte(a7>>32)] ^ t4[byte(a0>>24)] ^ t5[byte(a2>>16)] ^ t6[byte(a4>>8)] ^ t7[byte(a6)]
+	b1 = t0[a2>>56] ^ t1[byte(a4>>48)] ^ t2[byte(a6>>40)] ^ t3[byte(a0>>32)] ^ t4[byte(a1>>24)] ^ t5[byte(a3>>16)] ^ t6[byte(a5>>8)] ^ t7[byte(a7)]
+	b2 = t0[a3>>56] ^ t1[byte(a5>>48)] ^ t2[byte(a7>>40)] ^ t3[byte(a1>>32)] ^ t4[byte(a2>>24)] ^ t5[byte(a4>>16)] ^ t6[byte(a6>>8)] ^ t7[byte(a0)]
+	b3 = t0[a4>>56] ^ t1[byte(a6>>48)] ^ t2[byte(a0>>40)] ^ t3[byte(a2>>32)] ^ t4[byte(a3>>24)] ^ t5[byte(a5>>16)] ^ t6[byte(a7>>8)] ^ t7[byte(a1)]
+	b4 = t0[a5>>56] ^ t1[byte(a7>>48)] ^ t2[byte(a1>>40)] ^ t3[byte(a3>>32)] ^ t4[byte(a4>>24)] ^ t5[byte(a6>>16)] ^ t6[byte(a0>>8)] ^ t7[byte(a2)]
+	b5 = t0[a6>>56] ^ t1[byte(a0>>48)] ^ t2[byte(a2>>40)] ^ t3[byte(a4>>32)] ^ t4[byte(a5>>24)] ^ t5[byte(a7>>16)] ^ t6[byte(a1>>8)] ^ t7[byte(a3)]
+	b6 = t0[a7>>56] ^ t1[byte(a1>>48)] ^ t2[byte(a3>>40)] ^ t3[byte(a5>>32)] ^ t4[byte(a6>>24)] ^ t5[byte(a0>>16)] ^ t6[byte(a2>>8)] ^ t7[byte(a4)]
+	b7 = t0[a0>>56] ^ t1[byte(a2>>48)] ^ t2[byte(a4>>40)] ^ t3[byte(a6>>32)] ^ t4[byte(a7>>24)] ^ t5[byte(a1>>16)] ^ t6[byte(a3>>8)] ^ t7[byte(a5)]
+
+	// Round 7.
+	b0 ^= 0xfffffffffffffff8
+	b1 ^= 0xffffffffffffffe8
+	b2 ^= 0xffffffffffffffd8
+	b3 ^= 0xffffffffffffffc8
+	b4 ^= 0xffffffffffffffb8
+	b5 ^= 0xffffffffffffffa8
+	b6 ^= 0xffffffffffffff98
+	b7 ^= 0xffffffffffffff88
+
+	a0 = t0[b1>>56] ^ t1[byte(b3>>48)] ^ t2[byte(b5>>40)] ^ t3[byte(b7>>32)] ^ t4[byte(b0>>24)] ^ t5[byte(b2>>16)] ^ t6[byte(b4>>8)] ^ t7[byte(b6)]
+	a1 = t0[b2>>56] ^ t1[byte(b4>>48)] ^ t2[byte(b6>>40)] ^ t3[byte(b0>>32)] ^ t4[byte(b1>>24)] ^ t5[byte(b3>>16)] ^ t6[byte(b5>>8)] ^ t7[byte(b7)]
+	a2 = t0[b3>>56] ^ t1[byte(b5>>48)] ^ t2[byte(b7>>40)] ^ t3[byte(b1>>32)] ^ t4[byte(b2>>24)] ^ t5[byte(b4>>16)] ^ t6[byte(b6>>8)] ^ t7[byte(b0)]
+	a3 = t0[b4>>56] ^ t1[byte(b6>>48)] ^ t2[byte(b0>>40)] ^ t3[byte(b2>>32)] ^ t4[byte(b3>>24)] ^ t5[byte(b5>>16)] ^ t6[byte(b7>>8)] ^ t7[byte(b1)]
+	a4 = t0[b5>>56] ^ t1[byte(b7>>48)] ^ t2[byte(b1>>40)] ^ t3[byte(b3>>32)] ^ t4[byte(b4>>24)] ^ t5[byte(b6>>16)] ^ t6[byte(b0>>8)] ^ t7[byte(b2)]
+	a5 = t0[b6>>56] ^ t1[byte(b0>>48)] ^ t2[byte(b2>>40)] ^ t3[byte(b4>>32)] ^ t4[byte(b5>>24)] ^ t5[byte(b7>>16)] ^ t6[byte(b1>>8)] ^ t7[byte(b3)]
+	a6 = t0[b7>>56] ^ t1[byte(b1>>48)] ^ t2[byte(b3>>40)] ^ t3[byte(b5>>32)] ^ t4[byte(b6>>24)] ^ t5[byte(b0>>16)] ^ t6[byte(b2>>8)] ^ t7[byte(b4)]
+	a7 = t0[b0>>56] ^ t1[byte(b2>>48)] ^ t2[byte(b4>>40)] ^ t3[byte(b6>>32)] ^ t4[byte(b7>>24)] ^ t5[byte(b1>>16)] ^ t6[byte(b3>>8)] ^ t7[byte(b5)]
+
+	// Round 8.
+	a0 ^= 0xfffffffffffffff7
+	a1 ^= 0xffffffffffffffe7
+	a2 ^= 0xffffffffffffffd7
+	a3 ^= 0xffffffffffffffc7
+	a4 ^= 0xffffffffffffffb7
+	a5 ^= 0xffffffffffffffa7
+	a6 ^= 0xffffffffffffff97
+	a7 ^= 0xffffffffffffff87
+
+	b0 = t0[a1>>56] ^ t1[byte(a3>>48)] ^ t2[byte(a5>>40)] ^ t3[byte(a7>>32)] ^ t4[byte(a0>>24)] ^ t5[byte(a2>>16)] ^ t6[byte(a4>>8)] ^ t7[byte(a6)]
+	b1 = t0[a2>>56] ^ t1[byte(a4>>48)] ^ t2[byte(a6>>40)] ^ t3[byte(a0>>32)] ^ t4[byte(a1>>24)] ^ t5[byte(a3>>16)] ^ t6[byte(a5>>8)] ^ t7[byte(a7)]
+	b2 = t0[a3>>56] ^ t1[byte(a5>>48)] ^ t2[byte(a7>>40)] ^ t3[byte(a1>>32)] ^ t4[byte(a2>>24)] ^ t5[byte(a4>>16)] ^ t6[byte(a6>>8)] ^ t7[byte(a0)]
+	b3 = t0[a4>>56] ^ t1[byte(a6>>48)] ^ t2[byte(a0>>40)] ^ t3[byte(a2>>32)] ^ t4[byte(a3>>24)] ^ t5[byte(a5>>16)] ^ t6[byte(a7>>8)] ^ t7[byte(a1)]
+	b4 = t0[a5>>56] ^ t1[byte(a7>>48)] ^ t2[byte(a1>>40)] ^ t3[byte(a3>>32)] ^ t4[byte(a4>>24)] ^ t5[byte(a6>>16)] ^ t6[byte(a0>>8)] ^ t7[byte(a2)]
+	b5 = t0[a6>>56] ^ t1[byte(a0>>48)] ^ t2[byte(a2>>40)] ^ t3[byte(a4>>32)] ^ t4[byte(a5>>24)] ^ t5[byte(a7>>16)] ^ t6[byte(a1>>8)] ^ t7[byte(a3)]
+	b6 = t0[a7>>56] ^ t1[byte(a1>>48)] ^ t2[byte(a3>>40)] ^ t3[byte(a5>>32)] ^ t4[byte(a6>>24)] ^ t5[byte(a0>>16)] ^ t6[byte(a2>>8)] ^ t7[byte(a4)]
+	b7 = t0[a0>>56] ^ t1[byte(a2>>48)] ^ t2[byte(a4>>40)] ^ t3[byte(a6>>32)] ^ t4[byte(a7>>24)] ^ t5[byte(a1>>16)] ^ t6[byte(a3>>8)] ^ t7[byte(a5)]
+
+	// Round 9.
+	b0 ^= 0xfffffffffffffff6
+	b1 ^= 0xffffffffffffffe6
+	b2 ^= 0xffffffffffffffd6
+	b3 ^= 0xffffffffffffffc6
+	b4 ^= 0xffffffffffffffb6
+	b5 ^= 0xffffffffffffffa6
+	b6 ^= 0xffffffffffffff96
+	b7 ^= 0xffffffffffffff86
+
+	a0 = t0[b1>>56] ^ t1[byte(b3>>48)] ^ t2[byte(b5>>40)] ^ t3[byte(b7>>32)] ^ t4[byte(b0>>24)] ^ t5[byte(b2>>16)] ^ t6[byte(b4>>8)] ^ t7[byte(b6)]
+	a1 = t0[b2>>56] ^ t1[byte(b4>>48)] ^ t2[byte(b6>>40)] ^ t3[byte(b0>>32)] ^ t4[byte(b1>>24)] ^ t5[byte(b3>>16)] ^ t6[byte(b5>>8)] ^ t7[byte(b7)]
+	a2 = t0[b3>>56] ^ t1[byte(b5>>48)] ^ t2[byte(b7>>40)] ^ t3[byte(b1>>32)] ^ t4[byte(b2>>24)] ^ t5[byte(b4>>16)] ^ t6[byte(b6>>8)] ^ t7[byte(b0)]
+	a3 = t0[b4>>56] ^ t1[byte(b6>>48)] ^ t2[byte(b0>>40)] ^ t3[byte(b2>>32)] ^ t4[byte(b3>>24)] ^ t5[byte(b5>>16)] ^ t6[byte(b7>>8)] ^ t7[byte(b1)]
+	a4 = t0[b5>>56] ^ t1[byte(b7>>48)] ^ t2[byte(b1>>40)] ^ t3[byte(b3>>32)] ^ t4[byte(b4>>24)] ^ t5[byte(b6>>16)] ^ t6[byte(b0>>8)] ^ t7[byte(b2)]
+	a5 = t0[b6>>56] ^ t1[byte(b0>>48)] ^ t2[byte(b2>>40)] ^ t3[byte(b4>>32)] ^ t4[byte(b5>>24)] ^ t5[byte(b7>>16)] ^ t6[byte(b1>>8)] ^ t7[byte(b3)]
+	a6 = t0[b7>>56] ^ t1[byte(b1>>48)] ^ t2[byte(b3>>40)] ^ t3[byte(b5>>32)] ^ t4[byte(b6>>24)] ^ t5[byte(b0>>16)] ^ t6[byte(b2>>8)] ^ t7[byte(b4)]
+	a7 = t0[b0>>56] ^ t1[byte(b2>>48)] ^ t2[byte(b4>>40)] ^ t3[byte(b6>>32)] ^ t4[byte(b7>>24)] ^ t5[byte(b1>>16)] ^ t6[byte(b3>>8)] ^ t7[byte(b5)]
+
+	x[0] = a0
+	x[1] = a1
+	x[2] = a2
+	x[3] = a3
+	x[4] = a4
+	x[5] = a5
+	x[6] = a6
+	x[7] = a7
 }
 
+// t0 through t7 combine SubBytes and MixBytes. Entry x of tk is the
+// column that MixBytes produces from S(x) in row k.
 var (
-	t0 = []uint64{
+	t0 = [256]uint64{
 		0xc632f4a5f497a5c6, 0xf86f978497eb84f8,
 		0xee5eb099b0c799ee, 0xf67a8c8d8cf78df6,
 		0xffe8170d17e50dff, 0xd60adcbddcb7bdd6,
@@ -199,8 +558,7 @@ var (
 		0x7b3d46cb46f6cb7b, 0xa8b71ffc1f4bfca8,
 		0x6d0c61d661dad66d, 0x2c624e3a4e583a2c,
 	}
-
-	t1 = []uint64{
+	t1 = [256]uint64{
 		0xc6c632f4a5f497a5, 0xf8f86f978497eb84,
 		0xeeee5eb099b0c799, 0xf6f67a8c8d8cf78d,
 		0xffffe8170d17e50d, 0xd6d60adcbddcb7bd,
@@ -330,8 +688,7 @@ var (
 		0x7b7b3d46cb46f6cb, 0xa8a8b71ffc1f4bfc,
 		0x6d6d0c61d661dad6, 0x2c2c624e3a4e583a,
 	}
-
-	t2 = []uint64{
+	t2 = [256]uint64{
 		0xa5c6c632f4a5f497, 0x84f8f86f978497eb,
 		0x99eeee5eb099b0c7, 0x8df6f67a8c8d8cf7,
 		0x0dffffe8170d17e5, 0xbdd6d60adcbddcb7,
@@ -461,8 +818,7 @@ var (
 		0xcb7b7b3d46cb46f6, 0xfca8a8b71ffc1f4b,
 		0xd66d6d0c61d661da, 0x3a2c2c624e3a4e58,
 	}
-
-	t3 = []uint64{
+	t3 = [256]uint64{
 		0x97a5c6c632f4a5f4, 0xeb84f8f86f978497,
 		0xc799eeee5eb099b0, 0xf78df6f67a8c8d8c,
 		0xe50dffffe8170d17, 0xb7bdd6d60adcbddc,
@@ -592,8 +948,7 @@ var (
 		0xf6cb7b7b3d46cb46, 0x4bfca8a8b71ffc1f,
 		0xdad66d6d0c61d661, 0x583a2c2c624e3a4e,
 	}
-
-	t4 = []uint64{
+	t4 = [256]uint64{
 		0xf497a5c6c632f4a5, 0x97eb84f8f86f9784,
 		0xb0c799eeee5eb099, 0x8cf78df6f67a8c8d,
 		0x17e50dffffe8170d, 0xdcb7bdd6d60adcbd,
@@ -723,8 +1078,7 @@ var (
 		0x46f6cb7b7b3d46cb, 0x1f4bfca8a8b71ffc,
 		0x61dad66d6d0c61d6, 0x4e583a2c2c624e3a,
 	}
-
-	t5 = []uint64{
+	t5 = [256]uint64{
 		0xa5f497a5c6c632f4, 0x8497eb84f8f86f97,
 		0x99b0c799eeee5eb0, 0x8d8cf78df6f67a8c,
 		0x0d17e50dffffe817, 0xbddcb7bdd6d60adc,
@@ -854,8 +1208,7 @@ var (
 		0xcb46f6cb7b7b3d46, 0xfc1f4bfca8a8b71f,
 		0xd661dad66d6d0c61, 0x3a4e583a2c2c624e,
 	}
-
-	t6 = []uint64{
+	t6 = [256]uint64{
 		0xf4a5f497a5c6c632, 0x978497eb84f8f86f,
 		0xb099b0c799eeee5e, 0x8c8d8cf78df6f67a,
 		0x170d17e50dffffe8, 0xdcbddcb7bdd6d60a,
@@ -985,8 +1338,7 @@ var (
 		0x46cb46f6cb7b7b3d, 0x1ffc1f4bfca8a8b7,
 		0x61d661dad66d6d0c, 0x4e3a4e583a2c2c62,
 	}
-
-	t7 = []uint64{
+	t7 = [256]uint64{
 		0x32f4a5f497a5c6c6, 0x6f978497eb84f8f8,
 		0x5eb099b0c799eeee, 0x7a8c8d8cf78df6f6,
 		0xe8170d17e50dffff, 0x0adcbddcb7bdd6d6,
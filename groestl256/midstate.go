@@ -0,0 +1,75 @@
+package groestl256
+
+import "encoding/binary"
+
+// NonceSize is the size in bytes of the nonce appended by
+// Midstate.FinishWithNonce.
+const NonceSize = 4
+
+// Midstate is the state of a hash after a fixed prefix of a message
+// whose only varying part is a trailing nonce, such as a block header
+// being mined. Every whole block of the prefix has already been
+// compressed into it, and the rest of the prefix is kept already
+// padded, so that hashing the message with a new nonce only costs the
+// blocks that contain the nonce and the output transformation.
+//
+// A Midstate is never modified once created, and so is safe for
+// concurrent use.
+type Midstate struct {
+	ctx Digest
+
+	// tail is the rest of the message after the compressed blocks,
+	// including the padding, with a zero nonce at nonceOff.
+	tail     [2 * BlockSize]byte
+	tailLen  int
+	nonceOff int
+}
+
+// Midstate returns the midstate of the data written to ctx so far,
+// treating it as the prefix of a message that ends with a nonce. It
+// panics if a partial byte has been written with WriteBits.
+func (ctx *Digest) Midstate() *Midstate {
+	if ctx.partial.N != 0 {
+		panic("groestl256: Midstate after a partial byte")
+	}
+	ctx.init()
+
+	m := Midstate{
+		ctx:      *ctx,
+		nonceOff: ctx.offset,
+	}
+	m.ctx.offset = 0
+
+	copy(m.tail[:], ctx.buf[:ctx.offset])
+	n := ctx.offset + NonceSize
+	m.tail[n] = 0x80
+
+	// The padding needs a byte for 0x80 and eight for the block count.
+	m.tailLen = BlockSize
+	if n+9 > BlockSize {
+		m.tailLen = 2 * BlockSize
+	}
+	count := ctx.count + uint64(m.tailLen/BlockSize)
+	binary.BigEndian.PutUint64(m.tail[m.tailLen-8:], count)
+
+	return &m
+}
+
+// Size returns the length of the hashes that m produces.
+func (m *Midstate) Size() int {
+	return m.ctx.Size()
+}
+
+// FinishWithNonce returns the hash of the prefix followed by nonce,
+// which is encoded in little-endian byte order. Only the first Size
+// bytes of the returned array are used for digests shorter than
+// Groestl-256.
+func (m *Midstate) FinishWithNonce(nonce uint32) (out [Size]byte) {
+	ctx := m.ctx
+	tail := m.tail
+	binary.LittleEndian.PutUint32(tail[m.nonceOff:], nonce)
+
+	ctx.blocks(tail[:m.tailLen])
+	ctx.output(out[:ctx.Size()])
+	return out
+}
@@ -0,0 +1,69 @@
+package groestl256
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestMidstate(t *testing.T) {
+	msg := make([]byte, 3*BlockSize)
+	for i := range msg {
+		msg[i] = byte(i * 7)
+	}
+
+	for _, size := range []int{Size, Size224} {
+		for prefix := 0; prefix <= len(msg)-NonceSize; prefix++ {
+			ctx := newDigest(size)
+			ctx.Write(msg[:prefix])
+			m := ctx.Midstate()
+			if m.Size() != size {
+				t.Fatalf("Size = %v, expected %v", m.Size(), size)
+			}
+
+			for _, nonce := range []uint32{0, 1, 0xDEADBEEF} {
+				full := bytes.Clone(msg[:prefix+NonceSize])
+				binary.LittleEndian.PutUint32(full[prefix:], nonce)
+				want := newDigest(size)
+				want.Write(full)
+
+				out := m.FinishWithNonce(nonce)
+				if !bytes.Equal(out[:size], want.Sum(nil)) {
+					t.Errorf("Size %v, prefix %v, nonce %#x: expected %x, got %x", size, prefix, nonce, want.Sum(nil), out[:size])
+				}
+			}
+
+			// The midstate must not depend on later writes.
+			before := m.FinishWithNonce(1)
+			ctx.Write([]byte("more"))
+			if m.FinishWithNonce(1) != before {
+				t.Errorf("Midstate aliases its Digest")
+			}
+		}
+	}
+}
+
+func BenchmarkFinishWithNonce(b *testing.B) {
+	ctx := newDigest(Size)
+	ctx.Write(make([]byte, 76))
+	m := ctx.Midstate()
+
+	b.SetBytes(80)
+	var nonce uint32
+	for b.Loop() {
+		m.FinishWithNonce(nonce)
+		nonce++
+	}
+}
+
+func BenchmarkSum80(b *testing.B) {
+	header := make([]byte, 80)
+
+	b.SetBytes(80)
+	var nonce uint32
+	for b.Loop() {
+		binary.LittleEndian.PutUint32(header[76:], nonce)
+		Sum(header)
+		nonce++
+	}
+}
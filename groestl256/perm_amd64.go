@@ -0,0 +1,29 @@
+//go:build amd64 && !purego
+
+package groestl256
+
+import "github.com/DeedleFake/crypto/internal/cpu"
+
+// useAES is true if the permutations can use the AES-NI instructions.
+var useAES = cpu.X86.HasAES && cpu.X86.HasSSSE3
+
+// permSmallAES applies P to p and Q to q using AES-NI. Doing both at
+// once fills the vector registers, so it costs no more than either one
+// alone.
+//
+//go:noescape
+func permSmallAES(p, q *[8]uint64)
+
+// useAVX2 is true if two messages can be hashed at once with
+// permSmallAVX2.
+var useAVX2 = useAES && cpu.X86.HasAVX2
+
+// useVAES is true if the AVX2 permutations can run AESENCLAST on both
+// lanes of a register at once. Without it they fall back to doing each
+// lane separately.
+var useVAES = useAVX2 && cpu.X86.HasVAES
+
+// permSmallAVX2 is permSmallAES for two independent states at once.
+//
+//go:noescape
+func permSmallAVX2(p, q *[2][8]uint64)
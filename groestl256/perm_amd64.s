@@ -0,0 +1,570 @@
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// The state is kept transposed: register Xi holds row i of P in its
+// lower half and row i of Q in its upper half, so that ShiftBytes is a
+// single PSHUFB per row and MixBytes works on whole registers. Within
+// each half, the columns are in the order 0, 2, 4, 6, 1, 3, 5, 7 that
+// TRANSPOSE produces. SubBytes is AESENCLAST with a zero round key; the
+// shuffle masks undo the ShiftRows step that it includes.
+
+// TRANSPOSE transposes the 8x8 byte matrix whose rows are the 64-bit
+// halves of a, b, c and d. The rows of the result end up in c, b, d and
+// t0, two to a register, in the order 7 and 6, 5 and 4, 3 and 2, and 1
+// and 0, with the columns reordered as described above.
+#define TRANSPOSE(a, b, c, d, t0, t1) \
+	MOVO a, t0 \
+	PUNPCKLBW b, t0 \
+	PUNPCKHBW b, a \
+	MOVO c, t1 \
+	PUNPCKLBW d, t1 \
+	PUNPCKHBW d, c \
+	MOVO t0, b \
+	PUNPCKLWL t1, b \
+	PUNPCKHWL t1, t0 \
+	MOVO a, d \
+	PUNPCKLWL c, d \
+	PUNPCKHWL c, a \
+	MOVO b, c \
+	PUNPCKLLQ d, c \
+	PUNPCKHLQ d, b \
+	MOVO t0, d \
+	PUNPCKLLQ a, d \
+	PUNPCKHLQ a, t0
+
+// XTIME multiplies every byte of x by 2 in GF(2^8). X14 must hold the
+// reduction polynomial in every byte.
+#define XTIME(x, t) \
+	PXOR t, t \
+	PCMPGTB x, t \
+	PAND X14, t \
+	PADDB x, x \
+	PXOR t, x
+
+// MIXROW computes one row of MixBytes into dst from the rows saved at
+// o0 through o7 off of DI, where ok is the offset of the row k below
+// the one being computed. The coefficients 2, 2, 3, 4, 5, 3, 5 and 7
+// are split into the rows that are multiplied by 1, 2 and 4.
+#define MIXROW(o0, o1, o2, o3, o4, o5, o6, o7, dst) \
+	MOVOU o2(DI), X8 \
+	PXOR o4(DI), X8 \
+	PXOR o5(DI), X8 \
+	PXOR o6(DI), X8 \
+	PXOR o7(DI), X8 \
+	MOVOU o0(DI), X9 \
+	PXOR o1(DI), X9 \
+	PXOR o2(DI), X9 \
+	PXOR o5(DI), X9 \
+	PXOR o7(DI), X9 \
+	MOVOU o3(DI), X10 \
+	PXOR o4(DI), X10 \
+	PXOR o6(DI), X10 \
+	PXOR o7(DI), X10 \
+	XTIME(X10, X11) \
+	PXOR X10, X9 \
+	XTIME(X9, X11) \
+	PXOR X9, X8 \
+	MOVO X8, dst
+
+// SUBSHIFT applies ShiftBytes and SubBytes to row i in x.
+#define SUBSHIFT(i, x) \
+	MOVOU (i*16)(SI), X12 \
+	PSHUFB X12, x \
+	AESENCLAST X15, x
+
+// func permSmallAES(p, q *[8]uint64)
+TEXT ·permSmallAES(SB), NOSPLIT, $144-16
+	MOVQ p+0(FP), AX
+	MOVQ q+8(FP), BX
+
+	// DI points to a 16-byte aligned area for saving the rows.
+	LEAQ 15(SP), DI
+	ANDQ $~15, DI
+
+	PXOR X15, X15
+	MOVOU xtimeMask<>(SB), X14
+	MOVOU ffHigh<>(SB), X13
+
+	MOVOU 0(AX), X0
+	MOVOU 16(AX), X1
+	MOVOU 32(AX), X2
+	MOVOU 48(AX), X3
+	TRANSPOSE(X0, X1, X2, X3, X8, X9)
+
+	MOVOU 0(BX), X4
+	MOVOU 16(BX), X5
+	MOVOU 32(BX), X6
+	MOVOU 48(BX), X7
+	TRANSPOSE(X4, X5, X6, X7, X9, X10)
+
+	// Pair up the rows of P and Q.
+	MOVO X8, X0
+	PUNPCKHQDQ X9, X0
+	PUNPCKLQDQ X9, X8
+	MOVO X3, X10
+	PUNPCKHQDQ X7, X10
+	PUNPCKLQDQ X7, X3
+	MOVO X1, X4
+	PUNPCKHQDQ X5, X4
+	PUNPCKLQDQ X5, X1
+	MOVO X2, X7
+	PUNPCKLQDQ X6, X7
+	PUNPCKHQDQ X6, X2
+	MOVO X2, X6
+	MOVO X1, X5
+	MOVO X8, X1
+	MOVO X10, X2
+
+	LEAQ shuffleSmall<>(SB), SI
+	LEAQ roundConstSmall0<>(SB), DX
+	LEAQ roundConstSmall7<>(SB), R8
+	XORQ CX, CX
+
+loop:
+	MOVOU (DX)(CX*1), X12
+	PXOR X12, X0
+	PXOR X13, X1
+	PXOR X13, X2
+	PXOR X13, X3
+	PXOR X13, X4
+	PXOR X13, X5
+	PXOR X13, X6
+	MOVOU (R8)(CX*1), X12
+	PXOR X12, X7
+
+	SUBSHIFT(0, X0)
+	SUBSHIFT(1, X1)
+	SUBSHIFT(2, X2)
+	SUBSHIFT(3, X3)
+	SUBSHIFT(4, X4)
+	SUBSHIFT(5, X5)
+	SUBSHIFT(6, X6)
+	SUBSHIFT(7, X7)
+
+	MOVO X0, 0(DI)
+	MOVO X1, 16(DI)
+	MOVO X2, 32(DI)
+	MOVO X3, 48(DI)
+	MOVO X4, 64(DI)
+	MOVO X5, 80(DI)
+	MOVO X6, 96(DI)
+	MOVO X7, 112(DI)
+
+	MIXROW(0, 16, 32, 48, 64, 80, 96, 112, X0)
+	MIXROW(16, 32, 48, 64, 80, 96, 112, 0, X1)
+	MIXROW(32, 48, 64, 80, 96, 112, 0, 16, X2)
+	MIXROW(48, 64, 80, 96, 112, 0, 16, 32, X3)
+	MIXROW(64, 80, 96, 112, 0, 16, 32, 48, X4)
+	MIXROW(80, 96, 112, 0, 16, 32, 48, 64, X5)
+	MIXROW(96, 112, 0, 16, 32, 48, 64, 80, X6)
+	MIXROW(112, 0, 16, 32, 48, 64, 80, 96, X7)
+
+	ADDQ $16, CX
+	CMPQ CX, $160
+	JB loop
+
+	// Split the rows of P and Q back up and transpose them back into
+	// columns.
+	MOVO X7, X8
+	PUNPCKLQDQ X6, X8
+	PUNPCKHQDQ X6, X7
+	MOVO X5, X9
+	PUNPCKLQDQ X4, X9
+	PUNPCKHQDQ X4, X5
+	MOVO X3, X10
+	PUNPCKLQDQ X2, X10
+	PUNPCKHQDQ X2, X3
+	MOVO X1, X11
+	PUNPCKLQDQ X0, X11
+	PUNPCKHQDQ X0, X1
+
+	// P is now in X8, X9, X10 and X11, and Q in X7, X5, X3 and X1.
+	MOVOU outputShuffle<>(SB), X12
+	TRANSPOSE(X8, X9, X10, X11, X0, X2)
+	PSHUFB X12, X10
+	PSHUFB X12, X9
+	PSHUFB X12, X11
+	PSHUFB X12, X0
+	MOVO X10, X2
+	PUNPCKLQDQ X11, X2
+	PUNPCKHQDQ X11, X10
+	MOVO X9, X4
+	PUNPCKLQDQ X0, X4
+	PUNPCKHQDQ X0, X9
+	MOVOU X2, 0(AX)
+	MOVOU X10, 16(AX)
+	MOVOU X4, 32(AX)
+	MOVOU X9, 48(AX)
+
+	TRANSPOSE(X7, X5, X3, X1, X0, X2)
+	PSHUFB X12, X3
+	PSHUFB X12, X5
+	PSHUFB X12, X1
+	PSHUFB X12, X0
+	MOVO X3, X2
+	PUNPCKLQDQ X1, X2
+	PUNPCKHQDQ X1, X3
+	MOVO X5, X4
+	PUNPCKLQDQ X0, X4
+	PUNPCKHQDQ X0, X5
+	MOVOU X2, 0(BX)
+	MOVOU X3, 16(BX)
+	MOVOU X4, 32(BX)
+	MOVOU X5, 48(BX)
+
+	RET
+
+// The AVX2 code hashes two messages at once by running the code above
+// in both 128-bit lanes of the YMM registers. The lower lane holds the
+// first message and the upper lane the second. None of the instructions
+// move bytes between lanes, so the same macros work with V prefixes.
+
+#define VTRANSPOSE(a, b, c, d, t0, t1) \
+	VPUNPCKLBW b, a, t0 \
+	VPUNPCKHBW b, a, a \
+	VPUNPCKLBW d, c, t1 \
+	VPUNPCKHBW d, c, c \
+	VPUNPCKLWD t1, t0, b \
+	VPUNPCKHWD t1, t0, t0 \
+	VPUNPCKLWD c, a, d \
+	VPUNPCKHWD c, a, a \
+	VPUNPCKLDQ d, b, c \
+	VPUNPCKHDQ d, b, b \
+	VPUNPCKLDQ a, t0, d \
+	VPUNPCKHDQ a, t0, t0
+
+#define VXTIME(x, t) \
+	VPCMPGTB x, Y15, t \
+	VPAND Y14, t, t \
+	VPADDB x, x, x \
+	VPXOR t, x, x
+
+#define VSUBSHIFT(i, x) \
+	VBROADCASTI128 (i*16)(SI), Y12 \
+	VPSHUFB Y12, x, x \
+	VAESENCLAST Y15, x, x
+
+// VSUBSHIFT128 is VSUBSHIFT for processors without VAES, which have to
+// run AESENCLAST on each lane separately. x must be the lower half of
+// y. It clobbers Y10 and Y11.
+#define VSUBSHIFT128(i, y, x) \
+	VBROADCASTI128 (i*16)(SI), Y12 \
+	VPSHUFB Y12, y, y \
+	VEXTRACTI128 $1, y, X11 \
+	VAESENCLAST X15, X11, X11 \
+	VAESENCLAST X15, x, X10 \
+	VINSERTI128 $1, X11, Y10, y
+
+// VLOAD loads the 16 bytes at off from both a and b into the lanes of y.
+#define VLOAD(a, b, off, y, x) \
+	VMOVDQU off(a), x \
+	VINSERTI128 $1, off(b), y, y
+
+// VSTORE stores the lanes of y to off in a and b.
+#define VSTORE(y, x, a, b, off) \
+	VMOVDQU x, off(a) \
+	VEXTRACTI128 $1, y, off(b)
+
+// VADDCONST adds the round constants at offset CX to the rows in Y0
+// through Y7.
+#define VADDCONST \
+	VBROADCASTI128 (DX)(CX*1), Y12 \
+	VPXOR Y12, Y0, Y0 \
+	VPXOR Y13, Y1, Y1 \
+	VPXOR Y13, Y2, Y2 \
+	VPXOR Y13, Y3, Y3 \
+	VPXOR Y13, Y4, Y4 \
+	VPXOR Y13, Y5, Y5 \
+	VPXOR Y13, Y6, Y6 \
+	VBROADCASTI128 (R8)(CX*1), Y12 \
+	VPXOR Y12, Y7, Y7
+
+// VSUBSHIFTROWS applies VSUBSHIFT to every row.
+#define VSUBSHIFTROWS \
+	VSUBSHIFT(0, Y0) \
+	VSUBSHIFT(1, Y1) \
+	VSUBSHIFT(2, Y2) \
+	VSUBSHIFT(3, Y3) \
+	VSUBSHIFT(4, Y4) \
+	VSUBSHIFT(5, Y5) \
+	VSUBSHIFT(6, Y6) \
+	VSUBSHIFT(7, Y7)
+
+// VSUBSHIFTROWS128 applies VSUBSHIFT128 to every row.
+#define VSUBSHIFTROWS128 \
+	VSUBSHIFT128(0, Y0, X0) \
+	VSUBSHIFT128(1, Y1, X1) \
+	VSUBSHIFT128(2, Y2, X2) \
+	VSUBSHIFT128(3, Y3, X3) \
+	VSUBSHIFT128(4, Y4, X4) \
+	VSUBSHIFT128(5, Y5, X5) \
+	VSUBSHIFT128(6, Y6, X6) \
+	VSUBSHIFT128(7, Y7, X7)
+
+// VMIXOUT computes a row b of MixBytes into dst from the row x of
+// VMIXBYTES that it depends on and the rows of y at o7 and o4 off of
+// DI, as 2(2x ^ y7) ^ y4. It clobbers Y9.
+#define VMIXOUT(x, dst, o7, o4) \
+	VPCMPGTB x, Y15, Y9 \
+	VPAND Y14, Y9, Y9 \
+	VPADDB x, x, dst \
+	VPXOR Y9, dst, dst \
+	VPXOR o7(DI), dst, dst \
+	VXTIME(dst, Y9) \
+	VPXOR o4(DI), dst, dst
+
+// VMIXBYTES replaces the rows a in Y0 through Y7 with the result of
+// MixBytes, using the 512 bytes at DI as scratch space. Rather than
+// computing every row of the result separately like MIXROW, it shares
+// the work between them: with t[i] = a[i] ^ a[i+1],
+//
+//	x[i] = t[i] ^ t[i+3]
+//	y[i] = t[i] ^ t[i+2] ^ a[i+6]
+//	b[i] = 2(2x[i+3] ^ y[i+7]) ^ y[i+4]
+//
+// which needs about two thirds of the instructions. The rows of y are
+// saved after a, and b[i] is computed into the register that held
+// x[i+3] after x[0] is moved out of the way, so that the results end up
+// in order.
+#define VMIXBYTES \
+	VMOVDQU Y0, 0(DI) \
+	VMOVDQU Y1, 32(DI) \
+	VMOVDQU Y2, 64(DI) \
+	VMOVDQU Y3, 96(DI) \
+	VMOVDQU Y4, 128(DI) \
+	VMOVDQU Y5, 160(DI) \
+	VMOVDQU Y6, 192(DI) \
+	VMOVDQU Y7, 224(DI) \
+	VPXOR Y1, Y0, Y0 \
+	VPXOR Y2, Y1, Y1 \
+	VPXOR Y3, Y2, Y2 \
+	VPXOR Y4, Y3, Y3 \
+	VPXOR Y5, Y4, Y4 \
+	VPXOR Y6, Y5, Y5 \
+	VPXOR Y7, Y6, Y6 \
+	VPXOR 0(DI), Y7, Y7 \
+	VPXOR Y2, Y0, Y8 \
+	VPXOR 192(DI), Y8, Y8 \
+	VMOVDQU Y8, 256(DI) \
+	VPXOR Y3, Y1, Y8 \
+	VPXOR 224(DI), Y8, Y8 \
+	VMOVDQU Y8, 288(DI) \
+	VPXOR Y4, Y2, Y8 \
+	VPXOR 0(DI), Y8, Y8 \
+	VMOVDQU Y8, 320(DI) \
+	VPXOR Y5, Y3, Y8 \
+	VPXOR 32(DI), Y8, Y8 \
+	VMOVDQU Y8, 352(DI) \
+	VPXOR Y6, Y4, Y8 \
+	VPXOR 64(DI), Y8, Y8 \
+	VMOVDQU Y8, 384(DI) \
+	VPXOR Y7, Y5, Y8 \
+	VPXOR 96(DI), Y8, Y8 \
+	VMOVDQU Y8, 416(DI) \
+	VPXOR Y0, Y6, Y8 \
+	VPXOR 128(DI), Y8, Y8 \
+	VMOVDQU Y8, 448(DI) \
+	VPXOR Y1, Y7, Y8 \
+	VPXOR 160(DI), Y8, Y8 \
+	VMOVDQU Y8, 480(DI) \
+	VMOVDQU Y0, Y8 \
+	VMOVDQU Y1, Y9 \
+	VMOVDQU Y2, Y10 \
+	VPXOR Y3, Y0, Y0 \
+	VPXOR Y4, Y1, Y1 \
+	VPXOR Y5, Y2, Y2 \
+	VPXOR Y6, Y3, Y3 \
+	VPXOR Y7, Y4, Y4 \
+	VPXOR Y8, Y5, Y5 \
+	VPXOR Y9, Y6, Y6 \
+	VPXOR Y10, Y7, Y7 \
+	VMOVDQU Y0, Y8 \
+	VMIXOUT(Y3, Y0, 480, 384) \
+	VMIXOUT(Y6, Y3, 320, 480) \
+	VMIXOUT(Y1, Y6, 416, 320) \
+	VMIXOUT(Y4, Y1, 256, 416) \
+	VMIXOUT(Y7, Y4, 352, 256) \
+	VMIXOUT(Y2, Y7, 448, 352) \
+	VMIXOUT(Y5, Y2, 288, 448) \
+	VMIXOUT(Y8, Y5, 384, 288)
+
+// func permSmallAVX2(p, q *[2][8]uint64)
+TEXT ·permSmallAVX2(SB), NOSPLIT, $512-16
+	MOVQ p+0(FP), AX
+	MOVQ q+8(FP), BX
+	LEAQ 64(AX), R9
+	LEAQ 64(BX), R10
+	MOVQ SP, DI
+
+	VPXOR Y15, Y15, Y15
+	VBROADCASTI128 xtimeMask<>(SB), Y14
+	VBROADCASTI128 ffHigh<>(SB), Y13
+
+	VLOAD(AX, R9, 0, Y0, X0)
+	VLOAD(AX, R9, 16, Y1, X1)
+	VLOAD(AX, R9, 32, Y2, X2)
+	VLOAD(AX, R9, 48, Y3, X3)
+	VTRANSPOSE(Y0, Y1, Y2, Y3, Y8, Y9)
+
+	VLOAD(BX, R10, 0, Y4, X4)
+	VLOAD(BX, R10, 16, Y5, X5)
+	VLOAD(BX, R10, 32, Y6, X6)
+	VLOAD(BX, R10, 48, Y7, X7)
+	VTRANSPOSE(Y4, Y5, Y6, Y7, Y9, Y10)
+
+	// Pair up the rows of P and Q.
+	VPUNPCKHQDQ Y9, Y8, Y0
+	VPUNPCKHQDQ Y5, Y1, Y4
+	VPUNPCKLQDQ Y5, Y1, Y5
+	VPUNPCKLQDQ Y9, Y8, Y1
+	VPUNPCKHQDQ Y7, Y3, Y10
+	VPUNPCKLQDQ Y7, Y3, Y3
+	VPUNPCKLQDQ Y6, Y2, Y7
+	VPUNPCKHQDQ Y6, Y2, Y6
+	VMOVDQU Y10, Y2
+
+	LEAQ shuffleSmall<>(SB), SI
+	LEAQ roundConstSmall0<>(SB), DX
+	LEAQ roundConstSmall7<>(SB), R8
+	XORQ CX, CX
+
+	CMPB ·useVAES(SB), $0
+	JEQ loop128
+
+loop:
+	VADDCONST
+	VSUBSHIFTROWS
+	VMIXBYTES
+
+	ADDQ $16, CX
+	CMPQ CX, $160
+	JB loop
+	JMP done
+
+loop128:
+	VADDCONST
+	VSUBSHIFTROWS128
+	VMIXBYTES
+
+	ADDQ $16, CX
+	CMPQ CX, $160
+	JB loop128
+
+done:
+	// Split the rows of P and Q back up and transpose them back into
+	// columns.
+	VPUNPCKLQDQ Y6, Y7, Y8
+	VPUNPCKHQDQ Y6, Y7, Y7
+	VPUNPCKLQDQ Y4, Y5, Y9
+	VPUNPCKHQDQ Y4, Y5, Y5
+	VPUNPCKLQDQ Y2, Y3, Y10
+	VPUNPCKHQDQ Y2, Y3, Y3
+	VPUNPCKLQDQ Y0, Y1, Y11
+	VPUNPCKHQDQ Y0, Y1, Y1
+
+	VBROADCASTI128 outputShuffle<>(SB), Y12
+	VTRANSPOSE(Y8, Y9, Y10, Y11, Y0, Y2)
+	VPSHUFB Y12, Y10, Y10
+	VPSHUFB Y12, Y9, Y9
+	VPSHUFB Y12, Y11, Y11
+	VPSHUFB Y12, Y0, Y0
+	VPUNPCKLQDQ Y11, Y10, Y2
+	VPUNPCKHQDQ Y11, Y10, Y10
+	VPUNPCKLQDQ Y0, Y9, Y4
+	VPUNPCKHQDQ Y0, Y9, Y9
+	VSTORE(Y2, X2, AX, R9, 0)
+	VSTORE(Y10, X10, AX, R9, 16)
+	VSTORE(Y4, X4, AX, R9, 32)
+	VSTORE(Y9, X9, AX, R9, 48)
+
+	VTRANSPOSE(Y7, Y5, Y3, Y1, Y0, Y2)
+	VPSHUFB Y12, Y3, Y3
+	VPSHUFB Y12, Y5, Y5
+	VPSHUFB Y12, Y1, Y1
+	VPSHUFB Y12, Y0, Y0
+	VPUNPCKLQDQ Y1, Y3, Y2
+	VPUNPCKHQDQ Y1, Y3, Y3
+	VPUNPCKLQDQ Y0, Y5, Y4
+	VPUNPCKHQDQ Y0, Y5, Y5
+	VSTORE(Y2, X2, BX, R10, 0)
+	VSTORE(Y3, X3, BX, R10, 16)
+	VSTORE(Y4, X4, BX, R10, 32)
+	VSTORE(Y5, X5, BX, R10, 48)
+
+	VZEROUPPER
+	RET
+
+DATA shuffleSmall<>+0x000(SB)/8, $0x0f0b0104070e0a00
+DATA shuffleSmall<>+0x008(SB)/8, $0x03060d090802050c
+DATA shuffleSmall<>+0x010(SB)/8, $0x0c080501000f0b04
+DATA shuffleSmall<>+0x018(SB)/8, $0x07030e0a0906020d
+DATA shuffleSmall<>+0x020(SB)/8, $0x0d090205040c0801
+DATA shuffleSmall<>+0x028(SB)/8, $0x00070f0b0a03060e
+DATA shuffleSmall<>+0x030(SB)/8, $0x0e0a0602010d0905
+DATA shuffleSmall<>+0x038(SB)/8, $0x04000c080b07030f
+DATA shuffleSmall<>+0x040(SB)/8, $0x0b0e0306050a0d02
+DATA shuffleSmall<>+0x048(SB)/8, $0x0104090c0f000708
+DATA shuffleSmall<>+0x050(SB)/8, $0x080f0703020b0e06
+DATA shuffleSmall<>+0x058(SB)/8, $0x05010a0d0c040009
+DATA shuffleSmall<>+0x060(SB)/8, $0x090c000706080f03
+DATA shuffleSmall<>+0x068(SB)/8, $0x02050b0e0d01040a
+DATA shuffleSmall<>+0x070(SB)/8, $0x0a0d040003090c07
+DATA shuffleSmall<>+0x078(SB)/8, $0x0602080f0e05010b
+GLOBL shuffleSmall<>(SB), (NOPTR+RODATA), $128
+
+DATA roundConstSmall0<>+0x000(SB)/8, $0x7050301060402000
+DATA roundConstSmall0<>+0x008(SB)/8, $0xffffffffffffffff
+DATA roundConstSmall0<>+0x010(SB)/8, $0x7151311161412101
+DATA roundConstSmall0<>+0x018(SB)/8, $0xffffffffffffffff
+DATA roundConstSmall0<>+0x020(SB)/8, $0x7252321262422202
+DATA roundConstSmall0<>+0x028(SB)/8, $0xffffffffffffffff
+DATA roundConstSmall0<>+0x030(SB)/8, $0x7353331363432303
+DATA roundConstSmall0<>+0x038(SB)/8, $0xffffffffffffffff
+DATA roundConstSmall0<>+0x040(SB)/8, $0x7454341464442404
+DATA roundConstSmall0<>+0x048(SB)/8, $0xffffffffffffffff
+DATA roundConstSmall0<>+0x050(SB)/8, $0x7555351565452505
+DATA roundConstSmall0<>+0x058(SB)/8, $0xffffffffffffffff
+DATA roundConstSmall0<>+0x060(SB)/8, $0x7656361666462606
+DATA roundConstSmall0<>+0x068(SB)/8, $0xffffffffffffffff
+DATA roundConstSmall0<>+0x070(SB)/8, $0x7757371767472707
+DATA roundConstSmall0<>+0x078(SB)/8, $0xffffffffffffffff
+DATA roundConstSmall0<>+0x080(SB)/8, $0x7858381868482808
+DATA roundConstSmall0<>+0x088(SB)/8, $0xffffffffffffffff
+DATA roundConstSmall0<>+0x090(SB)/8, $0x7959391969492909
+DATA roundConstSmall0<>+0x098(SB)/8, $0xffffffffffffffff
+GLOBL roundConstSmall0<>(SB), (NOPTR+RODATA), $160
+
+DATA roundConstSmall7<>+0x000(SB)/8, $0x0000000000000000
+DATA roundConstSmall7<>+0x008(SB)/8, $0x8fafcfef9fbfdfff
+DATA roundConstSmall7<>+0x010(SB)/8, $0x0000000000000000
+DATA roundConstSmall7<>+0x018(SB)/8, $0x8eaeceee9ebedefe
+DATA roundConstSmall7<>+0x020(SB)/8, $0x0000000000000000
+DATA roundConstSmall7<>+0x028(SB)/8, $0x8dadcded9dbdddfd
+DATA roundConstSmall7<>+0x030(SB)/8, $0x0000000000000000
+DATA roundConstSmall7<>+0x038(SB)/8, $0x8cacccec9cbcdcfc
+DATA roundConstSmall7<>+0x040(SB)/8, $0x0000000000000000
+DATA roundConstSmall7<>+0x048(SB)/8, $0x8babcbeb9bbbdbfb
+DATA roundConstSmall7<>+0x050(SB)/8, $0x0000000000000000
+DATA roundConstSmall7<>+0x058(SB)/8, $0x8aaacaea9abadafa
+DATA roundConstSmall7<>+0x060(SB)/8, $0x0000000000000000
+DATA roundConstSmall7<>+0x068(SB)/8, $0x89a9c9e999b9d9f9
+DATA roundConstSmall7<>+0x070(SB)/8, $0x0000000000000000
+DATA roundConstSmall7<>+0x078(SB)/8, $0x88a8c8e898b8d8f8
+DATA roundConstSmall7<>+0x080(SB)/8, $0x0000000000000000
+DATA roundConstSmall7<>+0x088(SB)/8, $0x87a7c7e797b7d7f7
+DATA roundConstSmall7<>+0x090(SB)/8, $0x0000000000000000
+DATA roundConstSmall7<>+0x098(SB)/8, $0x86a6c6e696b6d6f6
+GLOBL roundConstSmall7<>(SB), (NOPTR+RODATA), $160
+
+DATA xtimeMask<>+0x000(SB)/8, $0x1b1b1b1b1b1b1b1b
+DATA xtimeMask<>+0x008(SB)/8, $0x1b1b1b1b1b1b1b1b
+GLOBL xtimeMask<>(SB), (NOPTR+RODATA), $16
+
+DATA ffHigh<>+0x000(SB)/8, $0x0000000000000000
+DATA ffHigh<>+0x008(SB)/8, $0xffffffffffffffff
+GLOBL ffHigh<>(SB), (NOPTR+RODATA), $16
+
+DATA outputShuffle<>+0x000(SB)/8, $0x0703060205010400
+DATA outputShuffle<>+0x008(SB)/8, $0x0f0b0e0a0d090c08
+GLOBL outputShuffle<>(SB), (NOPTR+RODATA), $16
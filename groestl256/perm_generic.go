@@ -0,0 +1,23 @@
+//go:build !amd64 || purego
+
+package groestl256
+
+// useAES is a variable, rather than a constant, so that tests can
+// toggle it on every platform.
+var useAES = false
+
+func permSmallAES(p, q *[8]uint64) {
+	permSmallP(p)
+	permSmallQ(q)
+}
+
+const useAVX2 = false
+
+// useVAES is a variable for the same reason as useAES.
+var useVAES = false
+
+func permSmallAVX2(p, q *[2][8]uint64) {
+	for i := range p {
+		permSmallAES(&p[i], &q[i])
+	}
+}
@@ -0,0 +1,106 @@
+package groestl256
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPermAES(t *testing.T) {
+	if !useAES {
+		t.Skip("AES-NI is not available")
+	}
+
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < 1000; i++ {
+		var p, q [8]uint64
+		for u := range p {
+			p[u] = r.Uint64()
+			q[u] = r.Uint64()
+		}
+		wantP, wantQ := p, q
+
+		permSmallP(&wantP)
+		permSmallQ(&wantQ)
+		permSmallAES(&p, &q)
+		if (p != wantP) || (q != wantQ) {
+			t.Fatalf("Expected %x, %x\nGot %x, %x", wantP, wantQ, p, q)
+		}
+	}
+}
+
+// withoutVAES runs f with and without the VAES instructions, if they
+// are available.
+func withoutVAES(t *testing.T, f func(t *testing.T)) {
+	t.Run("VAES", func(t *testing.T) {
+		if !useVAES {
+			t.Skip("VAES is not available")
+		}
+		f(t)
+	})
+
+	t.Run("NoVAES", func(t *testing.T) {
+		vaes := useVAES
+		useVAES = false
+		defer func() { useVAES = vaes }()
+		f(t)
+	})
+}
+
+func TestPermAVX2(t *testing.T) {
+	if !useAVX2 {
+		t.Skip("AVX2 is not available")
+	}
+
+	withoutVAES(t, func(t *testing.T) {
+		r := rand.New(rand.NewSource(4))
+		for i := 0; i < 1000; i++ {
+			var p, q [2][8]uint64
+			for j := range p {
+				for u := range p[j] {
+					p[j][u] = r.Uint64()
+					q[j][u] = r.Uint64()
+				}
+			}
+			wantP, wantQ := p, q
+
+			for j := range wantP {
+				permSmallP(&wantP[j])
+				permSmallQ(&wantQ[j])
+			}
+			permSmallAVX2(&p, &q)
+			if (p != wantP) || (q != wantQ) {
+				t.Fatalf("Expected %x, %x\nGot %x, %x", wantP, wantQ, p, q)
+			}
+		}
+	})
+}
+
+func BenchmarkPermPQ(b *testing.B) {
+	var p, q [8]uint64
+	for i := 0; i < b.N; i++ {
+		permSmallP(&p)
+		permSmallQ(&q)
+	}
+}
+
+func BenchmarkPermPQAES(b *testing.B) {
+	if !useAES {
+		b.Skip("AES-NI is not available")
+	}
+
+	var p, q [8]uint64
+	for i := 0; i < b.N; i++ {
+		permSmallAES(&p, &q)
+	}
+}
+
+func BenchmarkPermPQAVX2(b *testing.B) {
+	if !useAVX2 {
+		b.Skip("AVX2 is not available")
+	}
+
+	var p, q [2][8]uint64
+	for i := 0; i < b.N; i++ {
+		permSmallAVX2(&p, &q)
+	}
+}
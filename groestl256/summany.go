@@ -0,0 +1,131 @@
+package groestl256
+
+import "encoding/binary"
+
+// SumMany sets outs[i] to the Groestl-256 checksum of inputs[i] for
+// every input. The results are identical to calling Sum on each input,
+// but on amd64 processors with AVX2 two messages are hashed at a time,
+// which is considerably faster for many short inputs. It panics if outs
+// is shorter than inputs.
+//
+// The permutations keep the two states in the two 128-bit lanes of the
+// AVX2 registers, one message per lane, so a batch is two messages.
+// Four messages at a time, with a second set of states swapped in and
+// out of the registers through the stack, was tried and was slower per
+// message than two.
+func SumMany(inputs [][]byte, outs [][Size]byte) {
+	if len(outs) < len(inputs) {
+		panic("groestl256: SumMany: output slice too short")
+	}
+
+	i := 0
+	if useAVX2 {
+		for ; i+1 < len(inputs); i += 2 {
+			sumPair((*[2][]byte)(inputs[i:]), (*[2][Size]byte)(outs[i:]))
+		}
+	}
+	for ; i < len(inputs); i++ {
+		outs[i] = Sum(inputs[i])
+	}
+}
+
+// lane is a message being hashed by sumPair. It hands out the whole
+// blocks of the message directly from the input, followed by the
+// padded final blocks.
+type lane struct {
+	ctx  Digest
+	data []byte
+	pad  [2 * BlockSize]byte
+
+	// padLen is the length of the padded final blocks in pad, and
+	// padOff is how much of that has been handed out.
+	padLen int
+	padOff int
+}
+
+func (l *lane) init(msg []byte) {
+	l.ctx = *newDigest(Size)
+
+	n := len(msg) - len(msg)%BlockSize
+	r := copy(l.pad[:], msg[n:])
+	l.pad[r] = 0x80
+
+	l.padLen = BlockSize
+	if r >= BlockSize-8 {
+		l.padLen = 2 * BlockSize
+	}
+	binary.BigEndian.PutUint64(l.pad[l.padLen-8:], uint64((n+l.padLen)/BlockSize))
+
+	l.data = msg[:n]
+}
+
+// next returns the next block of the padded message, or nil if there
+// are none left.
+func (l *lane) next() []byte {
+	if len(l.data) > 0 {
+		b := l.data[:BlockSize]
+		l.data = l.data[BlockSize:]
+		return b
+	}
+
+	if l.padOff == l.padLen {
+		return nil
+	}
+	b := l.pad[l.padOff : l.padOff+BlockSize]
+	l.padOff += BlockSize
+	return b
+}
+
+// sumPair hashes two messages at once. Once the shorter one has run out
+// of blocks, the rest of the longer one is finished on its own.
+func sumPair(in *[2][]byte, out *[2][Size]byte) {
+	var l [2]lane
+	l[0].init(in[0])
+	l[1].init(in[1])
+
+	for {
+		b0, b1 := l[0].next(), l[1].next()
+		if (b0 == nil) || (b1 == nil) {
+			if b0 != nil {
+				l[0].ctx.blocks(b0)
+			}
+			if b1 != nil {
+				l[1].ctx.blocks(b1)
+			}
+			break
+		}
+
+		var g, m [2][8]uint64
+		for i, b := range [2][]byte{b0, b1} {
+			for u := range m[i] {
+				m[i][u] = binary.BigEndian.Uint64(b[u<<3:])
+				g[i][u] = m[i][u] ^ l[i].ctx.state[u]
+			}
+		}
+
+		permSmallAVX2(&g, &m)
+
+		for i := range l {
+			for u := range l[i].ctx.state {
+				l[i].ctx.state[u] ^= g[i][u] ^ m[i][u]
+			}
+		}
+	}
+
+	for i := range l {
+		for b := l[i].next(); b != nil; b = l[i].next() {
+			l[i].ctx.blocks(b)
+		}
+	}
+
+	// Output transformation. Q is unused, so it is left as zeros.
+	var x, y [2][8]uint64
+	x[0], x[1] = l[0].ctx.state, l[1].ctx.state
+	permSmallAVX2(&x, &y)
+
+	for i := range l {
+		for u := 0; u < 4; u++ {
+			binary.BigEndian.PutUint64(out[i][u<<3:], x[i][u+4]^l[i].ctx.state[u+4])
+		}
+	}
+}
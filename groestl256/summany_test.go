@@ -0,0 +1,73 @@
+package groestl256
+
+import (
+	"testing"
+
+	"github.com/DeedleFake/crypto/internal/hashtest"
+)
+
+func TestSumMany(t *testing.T) {
+	withoutVAES(t, testSumMany)
+}
+
+func testSumMany(t *testing.T) {
+	var inputs [][]byte
+	for _, n := range []int{0, 1, 55, 56, 63, 64, 80, 119, 120, 128, 200, 1000, 80, 3} {
+		inputs = append(inputs, hashtest.Seq(n))
+	}
+
+	for count := 0; count <= len(inputs); count++ {
+		outs := make([][Size]byte, count)
+		SumMany(inputs[:count], outs)
+		for i, out := range outs {
+			if want := Sum(inputs[i]); out != want {
+				t.Errorf("Count %v, input %v: expected %x", count, i, want)
+				t.Errorf("Count %v, input %v: got %x", count, i, out)
+			}
+		}
+	}
+}
+
+func TestSumManyShortOutput(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected panic")
+		}
+	}()
+	SumMany(make([][]byte, 2), make([][Size]byte, 1))
+}
+
+func BenchmarkSumMany(b *testing.B) {
+	inputs := make([][]byte, 1000)
+	for i := range inputs {
+		inputs[i] = hashtest.Seq(80)
+	}
+	outs := make([][Size]byte, len(inputs))
+
+	b.Run("Sum", func(b *testing.B) {
+		b.SetBytes(int64(80 * len(inputs)))
+		for i := 0; i < b.N; i++ {
+			for j, in := range inputs {
+				outs[j] = Sum(in)
+			}
+		}
+	})
+
+	b.Run("SumMany", func(b *testing.B) {
+		b.SetBytes(int64(80 * len(inputs)))
+		for i := 0; i < b.N; i++ {
+			SumMany(inputs, outs)
+		}
+	})
+
+	b.Run("SumManyNoVAES", func(b *testing.B) {
+		vaes := useVAES
+		useVAES = false
+		defer func() { useVAES = vaes }()
+
+		b.SetBytes(int64(80 * len(inputs)))
+		for i := 0; i < b.N; i++ {
+			SumMany(inputs, outs)
+		}
+	})
+}
@@ -0,0 +1,306 @@
+package groestl512
+
+import "math/bits"
+
+// The constant-time permutations work on a bitsliced copy of the
+// state: q[h][k] holds bit k of every byte in columns 8*h through
+// 8*h+7 of the state, with the byte in row i of column 8*h+j at bit
+// 8*j+i. The S-box is evaluated as a boolean
+// circuit and MixBytes as shifts and XORs, so no memory access depends
+// on the data being hashed.
+
+const (
+	// rowMask selects row 0 of every column of a bitsliced plane.
+	rowMask = 0x0101010101010101
+)
+
+func permBigPCT(a []uint64) {
+	var q [2][8]uint64
+	toPlanes(&q[0], a[:8])
+	toPlanes(&q[1], a[8:])
+	for r := uint64(0); r < 14; r++ {
+		for k := 0; k < 8; k++ {
+			q[0][k] ^= pcPlane(0, k, r)
+			q[1][k] ^= pcPlane(1, k, r)
+		}
+		sboxCT(&q[0])
+		sboxCT(&q[1])
+		for k := 0; k < 8; k++ {
+			q[0][k], q[1][k] = shiftBytes(q[0][k], q[1][k], &shiftP)
+		}
+		mixBytesCT(&q[0])
+		mixBytesCT(&q[1])
+	}
+	fromPlanes(a[:8], &q[0])
+	fromPlanes(a[8:], &q[1])
+}
+
+func permBigQCT(a []uint64) {
+	var q [2][8]uint64
+	toPlanes(&q[0], a[:8])
+	toPlanes(&q[1], a[8:])
+	for r := uint64(0); r < 14; r++ {
+		for k := 0; k < 8; k++ {
+			q[0][k] ^= qcPlane(0, k, r)
+			q[1][k] ^= qcPlane(1, k, r)
+		}
+		sboxCT(&q[0])
+		sboxCT(&q[1])
+		for k := 0; k < 8; k++ {
+			q[0][k], q[1][k] = shiftBytes(q[0][k], q[1][k], &shiftQ)
+		}
+		mixBytesCT(&q[0])
+		mixBytesCT(&q[1])
+	}
+	fromPlanes(a[:8], &q[0])
+	fromPlanes(a[8:], &q[1])
+}
+
+// pcPlane returns bit k of the P round constant for round r in half h
+// of the state.
+func pcPlane(h, k int, r uint64) uint64 {
+	return colConst[h][k] ^ (-((r >> k) & 1) & rowMask)
+}
+
+// qcPlane returns bit k of the Q round constant for round r in half h
+// of the state.
+func qcPlane(h, k int, r uint64) uint64 {
+	return ^(pcPlane(h, k, r) << 7)
+}
+
+// colConst holds bit k of the column numbers 0x00, 0x10, ..., 0xF0
+// that make up the round constants, placed in row 0 of each column.
+var colConst = func() (c [2][8]uint64) {
+	for j := 0; j < 16; j++ {
+		for k := 0; k < 8; k++ {
+			c[j/8][k] |= uint64((j<<4)>>k&1) << (8 * (j % 8))
+		}
+	}
+	return c
+}()
+
+// sboxCT applies the AES S-box to every byte of a bitsliced state using
+// the circuit by Boyar and Peralta.
+func sboxCT(q *[8]uint64) {
+	x0, x1, x2, x3 := q[7], q[6], q[5], q[4]
+	x4, x5, x6, x7 := q[3], q[2], q[1], q[0]
+
+	y14 := x3 ^ x5
+	y13 := x0 ^ x6
+	y9 := x0 ^ x3
+	y8 := x0 ^ x5
+	t0 := x1 ^ x2
+	y1 := t0 ^ x7
+	y4 := y1 ^ x3
+	y12 := y13 ^ y14
+	y2 := y1 ^ x0
+	y5 := y1 ^ x6
+	y3 := y5 ^ y8
+	t1 := x4 ^ y12
+	y15 := t1 ^ x5
+	y20 := t1 ^ x1
+	y6 := y15 ^ x7
+	y10 := y15 ^ t0
+	y11 := y20 ^ y9
+	y7 := x7 ^ y11
+	y17 := y10 ^ y11
+	y19 := y10 ^ y8
+	y16 := t0 ^ y11
+	y21 := y13 ^ y16
+	y18 := x0 ^ y16
+	t2 := y12 & y15
+	t3 := y3 & y6
+	t4 := t3 ^ t2
+	t5 := y4 & x7
+	t6 := t5 ^ t2
+	t7 := y13 & y16
+	t8 := y5 & y1
+	t9 := t8 ^ t7
+	t10 := y2 & y7
+	t11 := t10 ^ t7
+	t12 := y9 & y11
+	t13 := y14 & y17
+	t14 := t13 ^ t12
+	t15 := y8 & y10
+	t16 := t15 ^ t12
+	t17 := t4 ^ t14
+	t18 := t6 ^ t16
+	t19 := t9 ^ t14
+	t20 := t11 ^ t16
+	t21 := t17 ^ y20
+	t22 := t18 ^ y19
+	t23 := t19 ^ y21
+	t24 := t20 ^ y18
+	t25 := t21 ^ t22
+	t26 := t21 & t23
+	t27 := t24 ^ t26
+	t28 := t25 & t27
+	t29 := t28 ^ t22
+	t30 := t23 ^ t24
+	t31 := t22 ^ t26
+	t32 := t31 & t30
+	t33 := t32 ^ t24
+	t34 := t23 ^ t33
+	t35 := t27 ^ t33
+	t36 := t24 & t35
+	t37 := t36 ^ t34
+	t38 := t27 ^ t36
+	t39 := t29 & t38
+	t40 := t25 ^ t39
+	t41 := t40 ^ t37
+	t42 := t29 ^ t33
+	t43 := t29 ^ t40
+	t44 := t33 ^ t37
+	t45 := t42 ^ t41
+	z0 := t44 & y15
+	z1 := t37 & y6
+	z2 := t33 & x7
+	z3 := t43 & y16
+	z4 := t40 & y1
+	z5 := t29 & y7
+	z6 := t42 & y11
+	z7 := t45 & y17
+	z8 := t41 & y10
+	z9 := t44 & y12
+	z10 := t37 & y3
+	z11 := t33 & y4
+	z12 := t43 & y13
+	z13 := t40 & y5
+	z14 := t29 & y2
+	z15 := t42 & y9
+	z16 := t45 & y14
+	z17 := t41 & y8
+	t46 := z15 ^ z16
+	t47 := z10 ^ z11
+	t48 := z5 ^ z13
+	t49 := z9 ^ z10
+	t50 := z2 ^ z12
+	t51 := z2 ^ z5
+	t52 := z7 ^ z8
+	t53 := z0 ^ z3
+	t54 := z6 ^ z7
+	t55 := z16 ^ z17
+	t56 := z12 ^ t48
+	t57 := t50 ^ t53
+	t58 := z4 ^ t46
+	t59 := z3 ^ t54
+	t60 := t46 ^ t57
+	t61 := z14 ^ t57
+	t62 := t52 ^ t58
+	t63 := t49 ^ t58
+	t64 := z4 ^ t59
+	t65 := t61 ^ t62
+	t66 := z1 ^ t63
+	s0 := t59 ^ t63
+	s6 := t56 ^ ^t62
+	s7 := t48 ^ ^t60
+	t67 := t64 ^ t65
+	s3 := t53 ^ t66
+	s4 := t51 ^ t66
+	s5 := t47 ^ t65
+	s1 := t64 ^ ^s3
+	s2 := t55 ^ ^t67
+
+	q[7], q[6], q[5], q[4] = s0, s1, s2, s3
+	q[3], q[2], q[1], q[0] = s4, s5, s6, s7
+}
+
+// ShiftBytes amounts for P and Q.
+var (
+	shiftP = [8]uint{0, 1, 2, 3, 4, 5, 6, 11}
+	shiftQ = [8]uint{1, 3, 5, 11, 0, 2, 4, 6}
+)
+
+// shiftBytes rotates each row i of a bitsliced plane, split into its
+// lower half lo and upper half hi, left by s[i] columns.
+func shiftBytes(lo, hi uint64, s *[8]uint) (ylo, yhi uint64) {
+	for i, s := range s {
+		l, h := lo&(rowMask<<i), hi&(rowMask<<i)
+		if s >= 8 {
+			l, h = h, l
+			s -= 8
+		}
+		if s == 0 {
+			ylo, yhi = ylo|l, yhi|h
+			continue
+		}
+
+		n := 8 * s
+		ylo |= (l >> n) | (h << (64 - n))
+		yhi |= (h >> n) | (l << (64 - n))
+	}
+	return ylo, yhi
+}
+
+// rotRows rotates the rows of every column of a bitsliced plane up by
+// s, so that row i takes the value of row i+s.
+func rotRows(x uint64, s int) uint64 {
+	lo := uint64(0xFF>>s) * rowMask
+	return ((x >> s) & lo) | ((x << (8 - s)) &^ lo)
+}
+
+// xtime multiplies every byte of a bitsliced state by 2 in GF(2^8).
+func xtime(q *[8]uint64) {
+	hi := q[7]
+	q[7] = q[6]
+	q[6] = q[5]
+	q[5] = q[4]
+	q[4] = q[3] ^ hi
+	q[3] = q[2] ^ hi
+	q[2] = q[1]
+	q[1] = q[0] ^ hi
+	q[0] = hi
+}
+
+func mixBytesCT(q *[8]uint64) {
+	var ones, twos, fours [8]uint64
+	for k, x := range q {
+		a1, a2, a3 := rotRows(x, 1), rotRows(x, 2), rotRows(x, 3)
+		a4, a5, a6, a7 := rotRows(x, 4), rotRows(x, 5), rotRows(x, 6), rotRows(x, 7)
+
+		ones[k] = a2 ^ a4 ^ a5 ^ a6 ^ a7
+		twos[k] = x ^ a1 ^ a2 ^ a5 ^ a7
+		fours[k] = a3 ^ a4 ^ a6 ^ a7
+	}
+
+	xtime(&fours)
+	for k := range twos {
+		twos[k] ^= fours[k]
+	}
+	xtime(&twos)
+	for k := range q {
+		q[k] = ones[k] ^ twos[k]
+	}
+}
+
+// toPlanes bitslices eight columns of the state a into q.
+func toPlanes(q *[8]uint64, a []uint64) {
+	for j, w := range a[:8] {
+		t := transpose8(bits.ReverseBytes64(w))
+		for k := range q {
+			q[k] |= (t >> (8 * k) & 0xFF) << (8 * j)
+		}
+	}
+}
+
+// fromPlanes reverses toPlanes, storing the result in a.
+func fromPlanes(a []uint64, q *[8]uint64) {
+	for j := range a[:8] {
+		var t uint64
+		for k, x := range q {
+			t |= (x >> (8 * j) & 0xFF) << (8 * k)
+		}
+		a[j] = bits.ReverseBytes64(transpose8(t))
+	}
+}
+
+// transpose8 transposes x as an 8x8 matrix of bits.
+func transpose8(x uint64) uint64 {
+	t := (x ^ (x >> 7)) & 0x00AA00AA00AA00AA
+	x ^= t ^ (t << 7)
+	t = (x ^ (x >> 14)) & 0x0000CCCC0000CCCC
+	x ^= t ^ (t << 14)
+	t = (x ^ (x >> 28)) & 0x00000000F0F0F0F0
+	x ^= t ^ (t << 28)
+	return x
+}
@@ -0,0 +1,112 @@
+package groestl512
+
+import (
+	"bytes"
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	"github.com/DeedleFake/crypto/internal/kat"
+)
+
+func TestPermCT(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	perms := []struct {
+		name  string
+		table func(*[16]uint64)
+		ct    func([]uint64)
+	}{
+		{name: "P", table: permBigP, ct: permBigPCT},
+		{name: "Q", table: permBigQ, ct: permBigQCT},
+	}
+
+	for _, perm := range perms {
+		perm := perm
+		t.Run(perm.name, func(t *testing.T) {
+			for i := 0; i < 1000; i++ {
+				var want, got [16]uint64
+				for u := range want {
+					want[u] = r.Uint64()
+				}
+				got = want
+
+				perm.table(&want)
+				perm.ct(got[:])
+				if got != want {
+					t.Fatalf("Expected %x\nGot %x", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestPlanes(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+
+	for i := 0; i < 100; i++ {
+		var a, b [8]uint64
+		for u := range a {
+			a[u] = r.Uint64()
+		}
+
+		var q [8]uint64
+		toPlanes(&q, a[:])
+		fromPlanes(b[:], &q)
+		if a != b {
+			t.Fatalf("Expected %x\nGot %x", a, b)
+		}
+	}
+}
+
+func TestConstantTime(t *testing.T) {
+	// AES-NI takes precedence over the bitsliced permutations, so turn
+	// it off to run them through Write, WriteBits and Sum.
+	aes := useAES
+	useAES = false
+	defer func() { useAES = aes }()
+
+	in := bytes.Repeat([]byte("The quick brown fox jumps over the lazy dog"), 7)
+
+	h := NewConstantTime()
+	h.Write(in)
+	want := Sum(in)
+	if got := h.Sum(nil); !bytes.Equal(got, want[:]) {
+		t.Errorf("Expected %x", want)
+		t.Errorf("Got %x", got)
+	}
+
+	h.Reset()
+	if !h.(*Digest).ct {
+		t.Errorf("Reset lost constant-time mode")
+	}
+
+	h = NewConstantTime384()
+	h.Write(in)
+	want384 := Sum384(in)
+	if got := h.Sum(nil); !bytes.Equal(got, want384[:]) {
+		t.Errorf("Expected %x", want384)
+		t.Errorf("Got %x", got)
+	}
+	if !h.(*Digest).ConstantTime() {
+		t.Errorf("NewConstantTime384 is not constant-time")
+	}
+
+	kat.Run(t, filepath.Join("testdata", "ShortMsgKAT_512.txt"), func() kat.BitHash {
+		return NewConstantTime().(*Digest)
+	})
+}
+
+func BenchmarkPermP(b *testing.B) {
+	var a [16]uint64
+	for i := 0; i < b.N; i++ {
+		permBigP(&a)
+	}
+}
+
+func BenchmarkPermPCT(b *testing.B) {
+	var a [16]uint64
+	for i := 0; i < b.N; i++ {
+		permBigPCT(a[:])
+	}
+}
@@ -0,0 +1,421 @@
+// Package groestl512 implements the Groestl-512 and Groestl-384 hash
+// functions, as submitted to the final round of the SHA-3 competition.
+//
+// Where AES-NI is not used, the permutations look up eight 2 KiB
+// tables. Building with the groestl_small tag replaces them with a
+// single table and rotations, which saves 14 KiB at some cost in speed.
+// On an x86 server, BenchmarkWriteTable hashes 64 KiB at about 115 MB/s
+// by default and about 94 MB/s with the tag.
+package groestl512
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+	"io"
+
+	"github.com/DeedleFake/crypto/internal/partial"
+)
+
+//go:generate go run ../internal/gen -big -o internal.go
+//go:generate go run ../internal/gen -big -compact -o internal_compact.go
+
+const (
+	// Size is the size of a Groestl-512 hash in bytes.
+	Size = 64
+
+	// Size384 is the size of a Groestl-384 hash in bytes.
+	Size384 = 48
+
+	// BlockSize is the blocksize of a hash.
+	BlockSize = 128
+)
+
+// Digest is the state of a Groestl hash. It implements hash.Hash. The
+// zero value is ready to use and computes Groestl-512 hashes.
+type Digest struct {
+	buf    [128]byte
+	offset int
+	state  [16]uint64
+	count  uint64
+
+	// size is the length of the digest in bytes. It is zero until the
+	// zero Digest is first used.
+	size int
+
+	// partial is what WriteBits has left of a partially written byte.
+	partial partial.Byte
+
+	// ct selects the constant-time permutations.
+	ct bool
+}
+
+var (
+	_ hash.Cloner     = (*Digest)(nil)
+	_ io.StringWriter = (*Digest)(nil)
+	_ io.ByteWriter   = (*Digest)(nil)
+)
+
+// New returns a new hash.Hash that computes Groestl-512 hashes.
+func New() hash.Hash {
+	return newDigest(Size)
+}
+
+// New384 returns a new hash.Hash that computes Groestl-384 hashes.
+func New384() hash.Hash {
+	return newDigest(Size384)
+}
+
+// NewConstantTime returns a new hash.Hash that computes Groestl-512
+// hashes without any memory accesses that depend on the data being
+// hashed. It is several times slower than New, but does not leak
+// information about secret inputs, such as keys, through the cache.
+func NewConstantTime() hash.Hash {
+	ctx := newDigest(Size)
+	ctx.ct = true
+	return ctx
+}
+
+// NewConstantTime384 is NewConstantTime for Groestl-384.
+func NewConstantTime384() hash.Hash {
+	ctx := newDigest(Size384)
+	ctx.ct = true
+	return ctx
+}
+
+func newDigest(size int) *Digest {
+	ctx := &Digest{size: size}
+	ctx.state[15] = uint64(size) * 8
+	return ctx
+}
+
+// init sets up the zero Digest to compute Groestl-512 hashes. It does
+// nothing to a Digest that is already set up.
+func (ctx *Digest) init() {
+	if ctx.size == 0 {
+		*ctx = *newDigest(Size)
+	}
+}
+
+func (ctx *Digest) Write(data []byte) (n int, err error) {
+	ctx.init()
+	n = len(data)
+
+	var buf [BlockSize]byte
+	for len(data) > 0 {
+		var chunk []byte
+		chunk, data = ctx.partial.Next(buf[:], data)
+		ctx.write(chunk)
+	}
+
+	return n, nil
+}
+
+// WriteString adds the bytes of s to the running hash without
+// converting it to a byte slice first. It implements io.StringWriter.
+func (ctx *Digest) WriteString(s string) (n int, err error) {
+	ctx.init()
+	n = len(s)
+
+	var buf [BlockSize]byte
+	for len(s) > 0 {
+		var chunk []byte
+		chunk, s = ctx.partial.NextString(buf[:], s)
+		ctx.write(chunk)
+	}
+
+	return n, nil
+}
+
+// WriteByte adds c to the running hash. It implements io.ByteWriter.
+func (ctx *Digest) WriteByte(c byte) error {
+	buf := [1]byte{c}
+	ctx.Write(buf[:])
+	return nil
+}
+
+// WriteBits adds the first bits bits of data to the running hash.
+// Bits are taken from the most significant end of each byte. Unlike
+// Write, the message does not need to be a whole number of bytes long,
+// and calls to WriteBits and Write can be freely interleaved. It
+// panics if bits is greater than 8*len(data).
+func (ctx *Digest) WriteBits(data []byte, bits uint64) {
+	full := bits / 8
+	ctx.Write(data[:full])
+
+	if rem := uint(bits % 8); rem != 0 {
+		if b, ok := ctx.partial.Add(data[full], rem); ok {
+			ctx.write([]byte{b})
+		}
+	}
+}
+
+func (ctx *Digest) write(data []byte) (n int, err error) {
+	n = len(data)
+
+	if ctx.offset > 0 {
+		c := copy(ctx.buf[ctx.offset:], data)
+		ctx.offset += c
+		data = data[c:]
+		if ctx.offset < len(ctx.buf) {
+			return n, nil
+		}
+
+		ctx.blocks(ctx.buf[:])
+		ctx.offset = 0
+	}
+
+	// Compress whole blocks straight out of data and only buffer
+	// what's left over.
+	if len(data) >= len(ctx.buf) {
+		c := len(data) - len(data)%len(ctx.buf)
+		ctx.blocks(data[:c])
+		data = data[c:]
+	}
+	ctx.offset = copy(ctx.buf[:], data)
+
+	return n, nil
+}
+
+// blocks compresses data, the length of which must be a multiple of
+// BlockSize, into the state.
+func (ctx *Digest) blocks(data []byte) {
+	for len(data) >= len(ctx.buf) {
+		var g, m [len(ctx.state)]uint64
+		for u := range ctx.state {
+			m[u] = binary.BigEndian.Uint64(data[u<<3:])
+			g[u] = m[u] ^ ctx.state[u]
+		}
+
+		ctx.permP(&g)
+		ctx.permQ(&m)
+
+		for u := range ctx.state {
+			ctx.state[u] ^= g[u] ^ m[u]
+		}
+
+		ctx.count++
+		data = data[len(ctx.buf):]
+	}
+}
+
+// permP and permQ use AES-NI when available, even in constant-time
+// mode, as it does not depend on tables in memory.
+
+func (ctx *Digest) permP(a *[16]uint64) {
+	switch {
+	case useAES:
+		permBigPAES(a)
+	case ctx.ct:
+		permBigPCT(a[:])
+	default:
+		permBigP(a)
+	}
+}
+
+func (ctx *Digest) permQ(a *[16]uint64) {
+	switch {
+	case useAES:
+		permBigQAES(a)
+	case ctx.ct:
+		permBigQCT(a[:])
+	default:
+		permBigQ(a)
+	}
+}
+
+func (ctx *Digest) close(dst []byte, ub, n uint64) {
+	var pad [136]byte
+
+	z := uint64(0x80) >> n
+	pad[0] = uint8((ub & -z) | z)
+
+	padLen := 256 - ctx.offset
+	count := ctx.count + 2
+	if ctx.offset < 120 {
+		padLen = 128 - ctx.offset
+		count = ctx.count + 1
+	}
+	binary.BigEndian.PutUint64(pad[padLen-8:], count)
+
+	ctx.write(pad[:padLen])
+	ctx.output(dst)
+}
+
+// output applies the output transformation to the state and copies its
+// trailing len(dst) bytes to dst.
+func (ctx *Digest) output(dst []byte) {
+	x := ctx.state
+	ctx.permP(&x)
+
+	for u := range x {
+		ctx.state[u] ^= x[u]
+	}
+
+	var out [64]byte
+	for u := 0; u < 8; u++ {
+		binary.BigEndian.PutUint64(out[u<<3:], ctx.state[u+8])
+	}
+
+	copy(dst, out[64-len(dst):])
+}
+
+func (ctx *Digest) Sum(prev []byte) []byte {
+	// Finalize a copy so that the running hash can keep being written
+	// to after a call to Sum.
+	c := *ctx
+	c.init()
+	out := append(prev, make([]byte, c.size)...)
+	c.close(out[len(prev):], uint64(c.partial.Bits), uint64(c.partial.N))
+	return out
+}
+
+// Clone returns a copy of the running hash that can be written to and
+// summed independently of ctx. It implements hash.Cloner and never
+// returns an error.
+func (ctx *Digest) Clone() (hash.Cloner, error) {
+	c := *ctx
+	return &c, nil
+}
+
+func (ctx *Digest) Reset() {
+	if ctx.size == 0 {
+		return
+	}
+
+	ct := ctx.ct
+	*ctx = *newDigest(ctx.size)
+	ctx.ct = ct
+}
+
+func (ctx *Digest) Size() int {
+	if ctx.size == 0 {
+		return Size
+	}
+	return ctx.size
+}
+
+func (ctx *Digest) BlockSize() int {
+	return BlockSize
+}
+
+// ConstantTime reports whether ctx uses the constant-time permutations,
+// as it does if it was created by NewConstantTime or NewConstantTime384.
+func (ctx *Digest) ConstantTime() bool {
+	return ctx.ct
+}
+
+const (
+	magic          = "groestl512"
+	marshalVersion = 2
+
+	// magic, version, digest bits, state, buf, count, offset, partial,
+	// nbits
+	marshaledSize = len(magic) + 1 + 2 + 16*8 + BlockSize + 8 + 8 + 1 + 1
+)
+
+var (
+	errStateIdentifier = errors.New("groestl512: invalid hash state identifier")
+	errStateVersion    = errors.New("groestl512: unsupported hash state version")
+	errStateSize       = errors.New("groestl512: invalid hash state size")
+	errStateVariant    = errors.New("groestl512: hash state is for a different digest length")
+	errStateCorrupt    = errors.New("groestl512: corrupt hash state")
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler. The returned
+// state can be restored with UnmarshalBinary to continue hashing,
+// possibly in a different process.
+func (ctx *Digest) MarshalBinary() ([]byte, error) {
+	return ctx.AppendBinary(make([]byte, 0, marshaledSize))
+}
+
+// AppendBinary implements encoding.BinaryAppender.
+func (ctx *Digest) AppendBinary(b []byte) ([]byte, error) {
+	if ctx.size == 0 {
+		ctx = newDigest(Size)
+	}
+
+	b = append(b, magic...)
+	b = append(b, marshalVersion)
+	b = binary.BigEndian.AppendUint16(b, uint16(8*ctx.size))
+	for _, v := range ctx.state {
+		b = binary.BigEndian.AppendUint64(b, v)
+	}
+	b = append(b, ctx.buf[:]...)
+	b = binary.BigEndian.AppendUint64(b, ctx.count)
+	b = binary.BigEndian.AppendUint64(b, uint64(ctx.offset))
+	b = append(b, ctx.partial.Bits, byte(ctx.partial.N))
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The state
+// must have been produced by a Digest with the same digest length as
+// ctx, unless ctx is the zero Digest, in which case it takes on the
+// digest length stored in the state.
+func (ctx *Digest) UnmarshalBinary(b []byte) error {
+	if (len(b) < len(magic)) || (string(b[:len(magic)]) != magic) {
+		return errStateIdentifier
+	}
+	b = b[len(magic):]
+	if (len(b) < 1) || (b[0] != marshalVersion) {
+		return errStateVersion
+	}
+	if len(b) != marshaledSize-len(magic) {
+		return errStateSize
+	}
+	bits := int(binary.BigEndian.Uint16(b[1:]))
+	size := bits / 8
+	if (ctx.size != 0) && (bits != 8*ctx.size) {
+		return errStateVariant
+	}
+	b = b[3:]
+
+	var d Digest
+	d.size = size
+	d.ct = ctx.ct
+	for u := range d.state {
+		d.state[u] = binary.BigEndian.Uint64(b[u<<3:])
+	}
+	b = b[len(d.state)*8:]
+	b = b[copy(d.buf[:], b):]
+	d.count = binary.BigEndian.Uint64(b)
+	offset := binary.BigEndian.Uint64(b[8:])
+	d.partial = partial.Byte{Bits: b[16], N: uint(b[17])}
+
+	switch bits {
+	case 8 * Size384, 8 * Size:
+	default:
+		return errStateCorrupt
+	}
+	if (offset >= BlockSize) || !d.partial.Valid() {
+		return errStateCorrupt
+	}
+	d.offset = int(offset)
+
+	*ctx = d
+	return nil
+}
+
+// Sum computes the Groestl-512 hash of data.
+func Sum(data []byte) (out [Size]byte) {
+	h := New()
+	h.Write(data)
+	h.Sum(out[:0])
+	return
+}
+
+// Sum384 computes the Groestl-384 hash of data.
+func Sum384(data []byte) (out [Size384]byte) {
+	h := New384()
+	h.Write(data)
+	h.Sum(out[:0])
+	return
+}
+
+// SumBits computes the Groestl-512 hash of the first bitLen bits of data.
+func SumBits(data []byte, bitLen uint64) (out [Size]byte) {
+	h := newDigest(Size)
+	h.WriteBits(data, bitLen)
+	h.Sum(out[:0])
+	return
+}
@@ -0,0 +1,238 @@
+package groestl512
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"path/filepath"
+	"testing"
+
+	"github.com/DeedleFake/crypto/internal/hashtest"
+	"github.com/DeedleFake/crypto/internal/kat"
+)
+
+func TestSum(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []byte
+		out  string
+	}{
+		{
+			name: "Empty",
+			in:   nil,
+			out:  "6d3ad29d279110eef3adbd66de2a0345a77baede1557f5d099fce0c03d6dc2ba8e6d4a6633dfbd66053c20faa87d1a11f39a7fbe4a6c2f009801370308fc4ad8",
+		},
+		{
+			name: "Fox",
+			in:   []byte("The quick brown fox jumps over the lazy dog"),
+			out:  "badc1f70ccd69e0cf3760c3f93884289da84ec13c70b3d12a53a7a8a4a513f99715d46288f55e1dbf926e6d084a0538e4eebfc91cf2b21452921ccde9131718d",
+		},
+	}
+
+	h := New()
+	var out [Size]byte
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			h.Reset()
+			h.Write(test.in)
+			h.Sum(out[:0])
+
+			got := fmt.Sprintf("%x", out)
+			if got != test.out {
+				t.Errorf("Expected %q", test.out)
+				t.Errorf("Got %q", got)
+			}
+		})
+	}
+}
+
+func TestSum384(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []byte
+		out  string
+	}{
+		{
+			name: "Empty",
+			in:   nil,
+			out:  "ac353c1095ace21439251007862d6c62f829ddbe6de4f78e68d310a9205a736d8b11d99bffe448f57a1cfa2934f044a5",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got := fmt.Sprintf("%x", Sum384(test.in))
+			if got != test.out {
+				t.Errorf("Expected %q", test.out)
+				t.Errorf("Got %q", got)
+			}
+		})
+	}
+}
+
+func TestHash(t *testing.T) {
+	hashtest.Run(t, func() hashtest.Hash { return New().(*Digest) })
+}
+
+func TestZeroDigest(t *testing.T) {
+	hashtest.Run(t, func() hashtest.Hash { return new(Digest) })
+
+	var d Digest
+	if d.Size() != Size {
+		t.Errorf("Expected size %v", Size)
+		t.Errorf("Got %v", d.Size())
+	}
+
+	// The zero Digest marshals as a fresh Groestl-512 state.
+	got, _ := d.MarshalBinary()
+	want, _ := New().(*Digest).MarshalBinary()
+	if !bytes.Equal(got, want) {
+		t.Errorf("Expected state %x", want)
+		t.Errorf("Got %x", got)
+	}
+
+	d.Reset()
+	d.Write([]byte("abc"))
+	if got, want := d.Sum(nil), Sum([]byte("abc")); !bytes.Equal(got, want[:]) {
+		t.Errorf("Expected %x", want)
+		t.Errorf("Got %x", got)
+	}
+}
+
+func TestSumBits(t *testing.T) {
+	tests := []struct {
+		bits uint64
+		in   string
+		out  string
+	}{
+		{bits: 1, in: "00", out: "1b09b54f197a0539dbb9c3936bb4cc00fae65d1446a30529cfcb18f24acca7ba17b6a4e4b514ddd8739106d016df2de4cdd6b341de23fb597aeba22b34a16c38"},
+		{bits: 2, in: "c0", out: "fb084f886e72d5556de8dd85ccbed62d635146fcb48f518b3c7f12398871cf55cd2efd914d775ebe69c427c21c8c7682d7ba807c46ab1ab1cf857c128f75a49d"},
+		{bits: 5, in: "48", out: "af564554dcf79ceac23439475e7d905be88066d0a4e5b198273656e6dccb6a4cce23c19e6a963c2be326256e463094eb8f72f39c35984a2ef9ec3c76232f2a3c"},
+		{bits: 7, in: "98", out: "46523ea86464de469c1cc702b04266447f918cd8452d4d3d687c908c0e237dad51981564fcf9b860c83301c5f4750f47f44ac273001257c50f4185507983d34d"},
+		{bits: 8, in: "cc", out: "b23eeeb675c272c6e37a6ee9ab4dc505c9d6a10020f6bed3948205d04cdd1e90b06e494d186ef4f19266d7da200c89dc009e2b1a538cdea199e773fc076f802e"},
+		{bits: 10, in: "2140", out: "21bb9599eafa9b5392028616702a73caa1eb0f20f0e10e18064743d36495ef1a45689ec731ad50b959b5b9b0a1ee10e68fa5f1472d78242feb0bbe5c73fb6bdd"},
+		{bits: 63, in: "0e3ab0e054739b00", out: "d7c3d2ccca392d47a565a3ccd76a32a3bedd939d90f76ca39d4b09b61a755cae60d79be4e22d4e4188bd9920cd3fe283438fbd17f35e14b8cff62133d87005c2"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprint(test.bits), func(t *testing.T) {
+			in, _ := hex.DecodeString(test.in)
+
+			got := fmt.Sprintf("%x", SumBits(in, test.bits))
+			if got != test.out {
+				t.Errorf("Expected %q", test.out)
+				t.Errorf("Got %q", got)
+			}
+
+			// Feeding the same bits one at a time, and with the unused
+			// trailing bits set, must not change the result.
+			h := New().(*Digest)
+			for i := uint64(0); i < test.bits; i++ {
+				h.WriteBits([]byte{in[i/8] << (i % 8)}, 1)
+			}
+			got = fmt.Sprintf("%x", h.Sum(nil))
+			if got != test.out {
+				t.Errorf("Bitwise: expected %q", test.out)
+				t.Errorf("Bitwise: got %q", got)
+			}
+		})
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	newHashes := []func() hash.Hash{New, New384}
+	for i, newHash := range newHashes {
+		other := newHashes[1-i]
+		t.Run(fmt.Sprint(newHash().Size()*8), func(t *testing.T) {
+			hashtest.RunMarshal(t, hashtest.MarshalTest{
+				New:           func() hashtest.State { return newHash().(*Digest) },
+				Other:         func() hashtest.State { return other().(*Digest) },
+				Zero:          func() hashtest.State { return new(Digest) },
+				Magic:         magic,
+				ErrIdentifier: errStateIdentifier,
+				ErrVersion:    errStateVersion,
+				ErrSize:       errStateSize,
+				ErrVariant:    errStateVariant,
+				ErrCorrupt:    errStateCorrupt,
+			})
+		})
+	}
+}
+
+// The digest length is stored after the version as a big-endian
+// 16-bit number of bits, as in groestl256.
+func TestMarshalBinaryLength(t *testing.T) {
+	tests := []struct {
+		h    hash.Hash
+		bits int
+	}{
+		{h: New(), bits: 512},
+		{h: New384(), bits: 384},
+	}
+
+	for _, test := range tests {
+		state, err := test.h.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bits := binary.BigEndian.Uint16(state[len(magic)+1:]); int(bits) != test.bits {
+			t.Errorf("Expected %v bits", test.bits)
+			t.Errorf("Got %v bits", bits)
+		}
+	}
+
+	state, _ := New().(*Digest).MarshalBinary()
+	for _, bits := range []uint16{0, 64, 385, 513} {
+		binary.BigEndian.PutUint16(state[len(magic)+1:], bits)
+		var r Digest
+		if err := r.UnmarshalBinary(state); err != errStateCorrupt {
+			t.Errorf("%v bits: expected %v", bits, errStateCorrupt)
+			t.Errorf("%v bits: got %v", bits, err)
+		}
+	}
+}
+
+// The header of each KAT file says where its digests come from. Only
+// the Groestl-512 short message digests are the published results, and
+// the others were computed by internal/refgen.
+func TestKAT(t *testing.T) {
+	sizes := []struct {
+		size    string
+		newHash func() kat.BitHash
+	}{
+		{size: "384", newHash: func() kat.BitHash { return New384().(*Digest) }},
+		{size: "512", newHash: func() kat.BitHash { return New().(*Digest) }},
+	}
+
+	for _, size := range sizes {
+		size := size
+		for _, file := range []string{"ShortMsgKAT", "LongMsgKAT", "ExtremelyLongMsgKAT"} {
+			name := file + "_" + size.size
+			t.Run(name, func(t *testing.T) {
+				kat.Run(t, filepath.Join("testdata", name+".txt"), size.newHash)
+			})
+		}
+	}
+}
+
+func BenchmarkWrite(b *testing.B) {
+	hashtest.Benchmark(b, New)
+}
+
+// BenchmarkWriteTable is BenchmarkWrite with the table-driven
+// permutations instead of AES-NI, so that it measures the difference
+// that the groestl_small tag makes.
+func BenchmarkWriteTable(b *testing.B) {
+	aes := useAES
+	useAES = false
+	defer func() { useAES = aes }()
+
+	BenchmarkWrite(b)
+}
@@ -0,0 +1,33 @@
+//go:build amd64 && !purego
+
+package groestl512
+
+import "github.com/DeedleFake/crypto/internal/cpu"
+
+// useAES is true if the permutations can use the AES-NI instructions.
+var useAES = cpu.X86.HasAES && cpu.X86.HasSSSE3
+
+//go:noescape
+func permBigPAES(a *[16]uint64)
+
+//go:noescape
+func permBigQAES(a *[16]uint64)
+
+// useAVX2 is true if two messages can be hashed at once with
+// permBigPAVX2 and permBigQAVX2.
+var useAVX2 = useAES && cpu.X86.HasAVX2
+
+// useVAES is true if the AVX2 permutations can run AESENCLAST on both
+// lanes of a register at once. Without it they fall back to doing each
+// lane separately.
+var useVAES = useAVX2 && cpu.X86.HasVAES
+
+// permBigPAVX2 is permBigPAES for two independent states at once.
+//
+//go:noescape
+func permBigPAVX2(a *[2][16]uint64)
+
+// permBigQAVX2 is permBigQAES for two independent states at once.
+//
+//go:noescape
+func permBigQAVX2(a *[2][16]uint64)
@@ -0,0 +1,32 @@
+//go:build !amd64 || purego
+
+package groestl512
+
+// useAES is a variable, rather than a constant, so that tests can
+// toggle it on every platform.
+var useAES = false
+
+func permBigPAES(a *[16]uint64) {
+	permBigP(a)
+}
+
+func permBigQAES(a *[16]uint64) {
+	permBigQ(a)
+}
+
+const useAVX2 = false
+
+// useVAES is a variable for the same reason as useAES.
+var useVAES = false
+
+func permBigPAVX2(a *[2][16]uint64) {
+	for i := range a {
+		permBigPAES(&a[i])
+	}
+}
+
+func permBigQAVX2(a *[2][16]uint64) {
+	for i := range a {
+		permBigQAES(&a[i])
+	}
+}
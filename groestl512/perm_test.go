@@ -0,0 +1,122 @@
+package groestl512
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPermAES(t *testing.T) {
+	if !useAES {
+		t.Skip("AES-NI is not available")
+	}
+
+	r := rand.New(rand.NewSource(3))
+
+	perms := []struct {
+		name  string
+		table func(*[16]uint64)
+		aes   func(*[16]uint64)
+	}{
+		{name: "P", table: permBigP, aes: permBigPAES},
+		{name: "Q", table: permBigQ, aes: permBigQAES},
+	}
+
+	for _, perm := range perms {
+		perm := perm
+		t.Run(perm.name, func(t *testing.T) {
+			for i := 0; i < 1000; i++ {
+				var want, got [16]uint64
+				for u := range want {
+					want[u] = r.Uint64()
+				}
+				got = want
+
+				perm.table(&want)
+				perm.aes(&got)
+				if got != want {
+					t.Fatalf("Expected %x\nGot %x", want, got)
+				}
+			}
+		})
+	}
+}
+
+// withoutVAES runs f with and without the VAES instructions, if they
+// are available.
+func withoutVAES(t *testing.T, f func(t *testing.T)) {
+	t.Run("VAES", func(t *testing.T) {
+		if !useVAES {
+			t.Skip("VAES is not available")
+		}
+		f(t)
+	})
+
+	t.Run("NoVAES", func(t *testing.T) {
+		vaes := useVAES
+		useVAES = false
+		defer func() { useVAES = vaes }()
+		f(t)
+	})
+}
+
+func TestPermAVX2(t *testing.T) {
+	if !useAVX2 {
+		t.Skip("AVX2 is not available")
+	}
+
+	perms := []struct {
+		name  string
+		table func(*[16]uint64)
+		avx2  func(*[2][16]uint64)
+	}{
+		{name: "P", table: permBigP, avx2: permBigPAVX2},
+		{name: "Q", table: permBigQ, avx2: permBigQAVX2},
+	}
+
+	withoutVAES(t, func(t *testing.T) {
+		r := rand.New(rand.NewSource(4))
+		for _, perm := range perms {
+			t.Run(perm.name, func(t *testing.T) {
+				for i := 0; i < 1000; i++ {
+					var want, got [2][16]uint64
+					for j := range want {
+						for u := range want[j] {
+							want[j][u] = r.Uint64()
+						}
+					}
+					got = want
+
+					for j := range want {
+						perm.table(&want[j])
+					}
+					perm.avx2(&got)
+					if got != want {
+						t.Fatalf("Expected %x\nGot %x", want, got)
+					}
+				}
+			})
+		}
+	})
+}
+
+func BenchmarkPermPAES(b *testing.B) {
+	if !useAES {
+		b.Skip("AES-NI is not available")
+	}
+
+	var a [16]uint64
+	for i := 0; i < b.N; i++ {
+		permBigPAES(&a)
+	}
+}
+
+func BenchmarkPermPAVX2(b *testing.B) {
+	if !useAVX2 {
+		b.Skip("AVX2 is not available")
+	}
+
+	var a [2][16]uint64
+	for i := 0; i < b.N; i++ {
+		permBigPAVX2(&a)
+	}
+}
@@ -0,0 +1,135 @@
+package groestlcoin
+
+import "fmt"
+
+// Network holds the Base58Check version bytes of a Groestlcoin
+// network.
+type Network struct {
+	Name string
+
+	PubKeyHashAddrID byte
+	ScriptHashAddrID byte
+	PrivateKeyID     byte
+}
+
+var (
+	// MainNet is the Groestlcoin main network. Its P2PKH addresses
+	// start with 'F' and its P2SH addresses with '3'.
+	MainNet = &Network{
+		Name:             "mainnet",
+		PubKeyHashAddrID: 36,
+		ScriptHashAddrID: 5,
+		PrivateKeyID:     128,
+	}
+
+	// TestNet is the Groestlcoin test network.
+	TestNet = &Network{
+		Name:             "testnet",
+		PubKeyHashAddrID: 111,
+		ScriptHashAddrID: 196,
+		PrivateKeyID:     239,
+	}
+)
+
+// AddressType is the kind of script that an address pays to.
+type AddressType int
+
+const (
+	// PubKeyHash is a pay-to-public-key-hash (P2PKH) address.
+	PubKeyHash AddressType = iota
+
+	// ScriptHash is a pay-to-script-hash (P2SH) address.
+	ScriptHash
+)
+
+func (t AddressType) String() string {
+	switch t {
+	case PubKeyHash:
+		return "P2PKH"
+	case ScriptHash:
+		return "P2SH"
+	default:
+		return fmt.Sprintf("AddressType(%d)", int(t))
+	}
+}
+
+// Address is a Base58Check encoded P2PKH or P2SH address.
+type Address struct {
+	Network *Network
+	Type    AddressType
+
+	// Hash is the RIPEMD-160 of the SHA-256 of the public key or script.
+	Hash [20]byte
+}
+
+func (a Address) version() byte {
+	if a.Type == ScriptHash {
+		return a.Network.ScriptHashAddrID
+	}
+	return a.Network.PubKeyHashAddrID
+}
+
+// String returns the encoded address.
+func (a Address) String() string {
+	return EncodeCheck(a.version(), a.Hash[:])
+}
+
+// DecodeAddress decodes an address on net. It returns ErrVersion if the
+// address is not a P2PKH or P2SH address on net, ErrChecksum if its
+// checksum does not match and an *InvalidCharError if it contains a
+// character outside of the Base58 alphabet.
+func DecodeAddress(s string, net *Network) (Address, error) {
+	version, payload, err := DecodeCheck(s)
+	if err != nil {
+		return Address{}, err
+	}
+
+	a := Address{Network: net}
+	switch version {
+	case net.PubKeyHashAddrID:
+		a.Type = PubKeyHash
+	case net.ScriptHashAddrID:
+		a.Type = ScriptHash
+	default:
+		return Address{}, fmt.Errorf("%w: %v is not an address version on %v", ErrVersion, version, net.Name)
+	}
+
+	if len(payload) != len(a.Hash) {
+		return Address{}, fmt.Errorf("%w: %v byte address hash", ErrLength, len(payload))
+	}
+	copy(a.Hash[:], payload)
+	return a, nil
+}
+
+// EncodeWIF encodes a secp256k1 private key in Wallet Import Format for
+// net. If compressed is true, the key is marked as corresponding to a
+// compressed public key.
+func EncodeWIF(net *Network, key [32]byte, compressed bool) string {
+	if compressed {
+		return EncodeCheck(net.PrivateKeyID, append(key[:], 1))
+	}
+	return EncodeCheck(net.PrivateKeyID, key[:])
+}
+
+// DecodeWIF decodes a private key encoded by EncodeWIF. It returns the
+// same errors as DecodeAddress.
+func DecodeWIF(s string, net *Network) (key [32]byte, compressed bool, err error) {
+	version, payload, err := DecodeCheck(s)
+	if err != nil {
+		return key, false, err
+	}
+	if version != net.PrivateKeyID {
+		return key, false, fmt.Errorf("%w: %v is not a private key version on %v", ErrVersion, version, net.Name)
+	}
+
+	switch {
+	case len(payload) == len(key):
+	case (len(payload) == len(key)+1) && (payload[len(key)] == 1):
+		compressed = true
+	default:
+		return key, false, fmt.Errorf("%w: %v byte private key", ErrLength, len(payload))
+	}
+
+	copy(key[:], payload)
+	return key, compressed, nil
+}
@@ -0,0 +1,119 @@
+package groestlcoin
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// The expected encodings were computed by internal/refgen, with go run
+// ./internal/refgen groestlcoin.
+var (
+	pkHash     = mustDecodeHex("751e76e8199196d454941c45d1b3a323f1433bd6")
+	scriptHash = mustDecodeHex("bfb2a3c2c7a4ed1e9b1b66e7a8d2f6c3e5d4a1b0")
+	privateKey = mustDecodeHex("0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d")
+)
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestAddress(t *testing.T) {
+	tests := []struct {
+		net  *Network
+		typ  AddressType
+		hash []byte
+		enc  string
+	}{
+		{MainNet, PubKeyHash, pkHash, "Ffqz14cyvZYJavD76t6oHNDJnGiWcZMVxR"},
+		{MainNet, ScriptHash, scriptHash, "3KAczHaf1xdLFwoYGuxDgr6WqYPL2w1JnY"},
+		{TestNet, PubKeyHash, pkHash, "mrCDrCybB6J1vRfbwM5hemdJz73FuCDVWf"},
+		{TestNet, ScriptHash, scriptHash, "2NAiq42WgdR8gTjS5x3a6Jo5n3tbVsEwzK3"},
+	}
+
+	for _, test := range tests {
+		a := Address{Network: test.net, Type: test.typ, Hash: [20]byte(test.hash)}
+		if enc := a.String(); enc != test.enc {
+			t.Errorf("%v %v: expected %v, got %v", test.net.Name, test.typ, test.enc, enc)
+		}
+
+		dec, err := DecodeAddress(test.enc, test.net)
+		if err != nil {
+			t.Errorf("DecodeAddress(%q): %v", test.enc, err)
+			continue
+		}
+		if dec != a {
+			t.Errorf("DecodeAddress(%q) = %+v, expected %+v", test.enc, dec, a)
+		}
+	}
+}
+
+func TestDecodeAddressErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		enc  string
+		net  *Network
+		err  error
+	}{
+		{"WrongNetwork", "Ffqz14cyvZYJavD76t6oHNDJnGiWcZMVxR", TestNet, ErrVersion},
+		{"PrivateKey", "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbRuerSS", MainNet, ErrVersion},
+		{"Checksum", "Ffqz14cyvZYJavD76t6oHNDJnGiWcZMVxS", MainNet, ErrChecksum},
+		{"BitcoinChecksum", "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", MainNet, ErrChecksum},
+		{"Length", EncodeCheck(36, pkHash[:19]), MainNet, ErrLength},
+	}
+
+	for _, test := range tests {
+		if _, err := DecodeAddress(test.enc, test.net); !errors.Is(err, test.err) {
+			t.Errorf("%v: expected %v, got %v", test.name, test.err, err)
+		}
+	}
+
+	var charErr *InvalidCharError
+	if _, err := DecodeAddress("Ffqz14cyvZYJavD76t6oHNDJnGiWcZMVx0", MainNet); !errors.As(err, &charErr) {
+		t.Errorf("Expected InvalidCharError, got %v", err)
+	} else if (charErr.Char != '0') || (charErr.Offset != 33) {
+		t.Errorf("Unexpected error %v", err)
+	}
+}
+
+func TestWIF(t *testing.T) {
+	tests := []struct {
+		net        *Network
+		compressed bool
+		enc        string
+	}{
+		{MainNet, false, "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbRuerSS"},
+		{MainNet, true, "KwdMAjGmerYanjeui5SHS7JkmpZvVipYvB2LJGU1ZxJwYvLSkrFZ"},
+		{TestNet, false, "91gGn1HgSap6CbU12F6z3pJri26xzp7Ay1VW6NHCoEayNXqe8zK"},
+		{TestNet, true, "cMzLdeGd5vEqxB8B6VFQoRopQ3sLAAvEzDAoQgvX54xwofUX3owk"},
+	}
+
+	for _, test := range tests {
+		if enc := EncodeWIF(test.net, [32]byte(privateKey), test.compressed); enc != test.enc {
+			t.Errorf("%v compressed=%v: expected %v, got %v", test.net.Name, test.compressed, test.enc, enc)
+		}
+
+		key, compressed, err := DecodeWIF(test.enc, test.net)
+		if err != nil {
+			t.Errorf("DecodeWIF(%q): %v", test.enc, err)
+			continue
+		}
+		if (key != [32]byte(privateKey)) || (compressed != test.compressed) {
+			t.Errorf("DecodeWIF(%q) = %x, %v", test.enc, key, compressed)
+		}
+	}
+
+	if _, _, err := DecodeWIF(tests[0].enc, TestNet); !errors.Is(err, ErrVersion) {
+		t.Errorf("Expected ErrVersion, got %v", err)
+	}
+	if _, _, err := DecodeWIF("Ffqz14cyvZYJavD76t6oHNDJnGiWcZMVxR", MainNet); !errors.Is(err, ErrVersion) {
+		t.Errorf("Expected ErrVersion for address, got %v", err)
+	}
+	if _, _, err := DecodeWIF(EncodeCheck(128, append(privateKey[:32:32], 2)), MainNet); !errors.Is(err, ErrLength) {
+		t.Errorf("Expected ErrLength, got %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package groestlcoin
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/DeedleFake/crypto/groestl512"
+)
+
+const alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var decodeMap = func() (m [256]int8) {
+	for i := range m {
+		m[i] = -1
+	}
+	for i, c := range alphabet {
+		m[c] = int8(i)
+	}
+	return m
+}()
+
+var (
+	// ErrChecksum is returned when the checksum of a Base58Check string
+	// does not match its contents.
+	ErrChecksum = errors.New("groestlcoin: checksum mismatch")
+
+	// ErrVersion is returned when a Base58Check string has a version
+	// byte other than the expected ones.
+	ErrVersion = errors.New("groestlcoin: unexpected version")
+
+	// ErrLength is returned when the payload of a Base58Check string has
+	// the wrong length for its kind, or is too short to hold a version
+	// and checksum.
+	ErrLength = errors.New("groestlcoin: invalid length")
+)
+
+// InvalidCharError is returned when a string contains a character that
+// is not in the Base58 alphabet.
+type InvalidCharError struct {
+	Char   byte
+	Offset int
+}
+
+func (err *InvalidCharError) Error() string {
+	return fmt.Sprintf("groestlcoin: invalid Base58 character %q at offset %v", err.Char, err.Offset)
+}
+
+var b58 = big.NewInt(58)
+
+// EncodeBase58 encodes data with the Base58 alphabet used by Bitcoin.
+// Each leading zero byte is encoded as a leading '1'.
+func EncodeBase58(data []byte) string {
+	zeros := len(data) - len(bytes.TrimLeft(data, "\x00"))
+
+	// Every byte needs at most log(256)/log(58) < 1.37 digits.
+	out := make([]byte, 0, zeros+len(data)*137/100+1)
+	n := new(big.Int).SetBytes(data)
+	var mod big.Int
+	for n.Sign() > 0 {
+		n.DivMod(n, b58, &mod)
+		out = append(out, alphabet[mod.Int64()])
+	}
+	for range zeros {
+		out = append(out, alphabet[0])
+	}
+
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return string(out)
+}
+
+// DecodeBase58 decodes a string encoded by EncodeBase58. It returns an
+// *InvalidCharError if s contains a character outside of the alphabet.
+func DecodeBase58(s string) ([]byte, error) {
+	n := new(big.Int)
+	var digit big.Int
+	for i := 0; i < len(s); i++ {
+		v := decodeMap[s[i]]
+		if v < 0 {
+			return nil, &InvalidCharError{Char: s[i], Offset: i}
+		}
+		n.Mul(n, b58)
+		n.Add(n, digit.SetInt64(int64(v)))
+	}
+
+	zeros := len(s) - len(bytes.TrimLeft([]byte(s), alphabet[:1]))
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
+
+// checksum returns the Groestlcoin Base58Check checksum of data, the
+// first four bytes of its double Groestl-512 hash.
+func checksum(data []byte) (sum [4]byte) {
+	first := groestl512.Sum(data)
+	second := groestl512.Sum(first[:])
+	return [4]byte(second[:4])
+}
+
+// EncodeCheck encodes a version byte and payload with Base58Check,
+// appending a checksum of double Groestl-512 rather than Bitcoin's
+// double SHA-256.
+func EncodeCheck(version byte, payload []byte) string {
+	data := make([]byte, 0, 1+len(payload)+4)
+	data = append(data, version)
+	data = append(data, payload...)
+	sum := checksum(data)
+	return EncodeBase58(append(data, sum[:]...))
+}
+
+// DecodeCheck decodes a string encoded by EncodeCheck and returns its
+// version byte and payload. It returns ErrChecksum if the checksum does
+// not match and ErrLength if the string is too short to have one.
+func DecodeCheck(s string) (version byte, payload []byte, err error) {
+	data, err := DecodeBase58(s)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(data) < 5 {
+		return 0, nil, ErrLength
+	}
+
+	data, sum := data[:len(data)-4], data[len(data)-4:]
+	if want := checksum(data); !bytes.Equal(sum, want[:]) {
+		return 0, nil, ErrChecksum
+	}
+	return data[0], data[1:], nil
+}
@@ -0,0 +1,72 @@
+package groestlcoin
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestBase58(t *testing.T) {
+	tests := []struct {
+		data string
+		enc  string
+	}{
+		{"", ""},
+		{"61", "2g"},
+		{"626262", "a3gV"},
+		{"636363", "aPEr"},
+		{"73696d706c792061206c6f6e6720737472696e67", "2cFupjhnEsSn59qHXstmK2ffpLv2"},
+		{"00eb15231dfceb60925886b67d065299925915aeb172c06647", "1NS17iag9jJgTHD1VXjvLCEnZuQ3rJDE9L"},
+		{"516b6fcd0f", "ABnLTmg"},
+		{"bf4f89001e670274dd", "3SEo3LWLoPntC"},
+		{"572e4794", "3EFU7m"},
+		{"ecac89cad93923c02321", "EJDM8drfXA6uyA"},
+		{"10c8511e", "Rt5zm"},
+		{"00000000000000000000", "1111111111"},
+	}
+
+	for _, test := range tests {
+		data, _ := hex.DecodeString(test.data)
+		if enc := EncodeBase58(data); enc != test.enc {
+			t.Errorf("EncodeBase58(%v) = %q, expected %q", test.data, enc, test.enc)
+		}
+
+		dec, err := DecodeBase58(test.enc)
+		if err != nil {
+			t.Errorf("DecodeBase58(%q): %v", test.enc, err)
+			continue
+		}
+		if !bytes.Equal(dec, data) {
+			t.Errorf("DecodeBase58(%q) = %x, expected %v", test.enc, dec, test.data)
+		}
+	}
+}
+
+func TestDecodeBase58Invalid(t *testing.T) {
+	for _, s := range []string{"0", "O", "I", "l", "3mJr0", "3mJr7AoUXx2Wqd+", " 3mJr"} {
+		_, err := DecodeBase58(s)
+		var charErr *InvalidCharError
+		if !errors.As(err, &charErr) {
+			t.Errorf("DecodeBase58(%q): expected InvalidCharError, got %v", s, err)
+			continue
+		}
+		if s[charErr.Offset] != charErr.Char {
+			t.Errorf("DecodeBase58(%q): error %v has wrong offset", s, err)
+		}
+	}
+}
+
+func TestDecodeCheck(t *testing.T) {
+	version, payload, err := DecodeCheck("16wcSYp")
+	if (version != 0) || (len(payload) != 0) || (err != nil) {
+		t.Errorf("DecodeCheck = %v, %x, %v", version, payload, err)
+	}
+
+	if _, _, err := DecodeCheck("16wcSYq"); !errors.Is(err, ErrChecksum) {
+		t.Errorf("Expected ErrChecksum, got %v", err)
+	}
+	if _, _, err := DecodeCheck("1111"); !errors.Is(err, ErrLength) {
+		t.Errorf("Expected ErrLength, got %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+// Package groestlcoin implements the block header hashing,
+// proof-of-work checks and Base58Check encodings of Groestlcoin.
+//
+// A Groestlcoin block hash is Groestl-512 applied twice to the 80-byte
+// block header, truncated to its first 256 bits. As in Bitcoin, hashes
+// are treated as little-endian numbers, and so are conventionally
+// displayed with their bytes reversed.
+//
+// Groestlcoin's Base58Check also differs from Bitcoin's in its
+// checksum, which is taken from double Groestl-512 instead of double
+// SHA-256. Addresses and private keys encoded for one can't be decoded
+// as the other.
+package groestlcoin
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/DeedleFake/crypto/groestl512"
+)
+
+// HeaderSize is the size of a serialized block header in bytes.
+const HeaderSize = 80
+
+// HashSize is the size of a block hash in bytes.
+const HashSize = 32
+
+// Hash is a block hash, or any other 256-bit hash, in its serialized
+// byte order.
+type Hash [HashSize]byte
+
+// String returns the hash in the conventional byte-reversed hex form.
+func (h Hash) String() string {
+	var r Hash
+	for i, c := range h {
+		r[len(r)-1-i] = c
+	}
+	return hex.EncodeToString(r[:])
+}
+
+// ParseHash parses a hash in the form returned by String.
+func ParseHash(s string) (Hash, error) {
+	var h Hash
+	if hex.DecodedLen(len(s)) != len(h) {
+		return h, fmt.Errorf("groestlcoin: hash %q has wrong length", s)
+	}
+	if _, err := hex.Decode(h[:], []byte(s)); err != nil {
+		return h, fmt.Errorf("groestlcoin: parse hash: %w", err)
+	}
+	for i, j := 0, len(h)-1; i < j; i, j = i+1, j-1 {
+		h[i], h[j] = h[j], h[i]
+	}
+	return h, nil
+}
+
+// HeaderHash returns the hash of a serialized block header.
+func HeaderHash(header [HeaderSize]byte) Hash {
+	first := groestl512.Sum(header[:])
+	second := groestl512.Sum(first[:])
+	return Hash(second[:HashSize])
+}
+
+// Header is a block header.
+type Header struct {
+	Version    int32
+	PrevBlock  Hash
+	MerkleRoot Hash
+	Timestamp  uint32
+	Bits       uint32
+	Nonce      uint32
+}
+
+var errHeaderSize = errors.New("groestlcoin: block header must be 80 bytes")
+
+// ParseHeader parses a serialized block header.
+func ParseHeader(data []byte) (Header, error) {
+	if len(data) != HeaderSize {
+		return Header{}, errHeaderSize
+	}
+
+	var h Header
+	h.Version = int32(binary.LittleEndian.Uint32(data[0:]))
+	copy(h.PrevBlock[:], data[4:36])
+	copy(h.MerkleRoot[:], data[36:68])
+	h.Timestamp = binary.LittleEndian.Uint32(data[68:])
+	h.Bits = binary.LittleEndian.Uint32(data[72:])
+	h.Nonce = binary.LittleEndian.Uint32(data[76:])
+	return h, nil
+}
+
+// Bytes returns the serialized header.
+func (h *Header) Bytes() (data [HeaderSize]byte) {
+	binary.LittleEndian.PutUint32(data[0:], uint32(h.Version))
+	copy(data[4:36], h.PrevBlock[:])
+	copy(data[36:68], h.MerkleRoot[:])
+	binary.LittleEndian.PutUint32(data[68:], h.Timestamp)
+	binary.LittleEndian.PutUint32(data[72:], h.Bits)
+	binary.LittleEndian.PutUint32(data[76:], h.Nonce)
+	return data
+}
+
+// Hash returns the block hash of the header.
+func (h *Header) Hash() Hash {
+	return HeaderHash(h.Bytes())
+}
+
+// CheckProofOfWork checks that the header's hash satisfies the target
+// encoded in its Bits field, and that the target is no easier than
+// powLimit. See the package-level CheckProofOfWork.
+func (h *Header) CheckProofOfWork(powLimit Hash) error {
+	return CheckProofOfWork(h.Hash(), h.Bits, powLimit)
+}
@@ -0,0 +1,142 @@
+package groestlcoin
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+// genesis is the Groestlcoin main network genesis block header.
+const (
+	genesis     = "700000000000000000000000000000000000000000000000000000000000000000000000bb2866aaca46c4428ad08b57bc9d1493abaf64724b6c3052a7c8f958df68e93ced3d2b53ffff0f1e835b0300"
+	genesisHash = "00000ac5927c594d49cc0bdb81759d0da8297eb614683d3acb62f0703b639023"
+)
+
+func genesisHeader(t *testing.T) [HeaderSize]byte {
+	data, err := hex.DecodeString(genesis)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return [HeaderSize]byte(data)
+}
+
+func TestHeaderHash(t *testing.T) {
+	hash := HeaderHash(genesisHeader(t))
+	if s := hash.String(); s != genesisHash {
+		t.Errorf("Expected %v", genesisHash)
+		t.Errorf("Got %v", s)
+	}
+
+	parsed, err := ParseHash(genesisHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed != hash {
+		t.Errorf("ParseHash = %x, expected %x", parsed, hash)
+	}
+}
+
+func TestParseHeader(t *testing.T) {
+	data := genesisHeader(t)
+	h, err := ParseHeader(data[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Header{
+		Version:    112,
+		MerkleRoot: mustParseHash("3ce968df58f9c8a752306c4b7264afab93149dbc578bd08a42c446caaa6628bb"),
+		Timestamp:  1395342829,
+		Bits:       0x1e0fffff,
+		Nonce:      220035,
+	}
+	if h != want {
+		t.Errorf("Expected %+v", want)
+		t.Errorf("Got %+v", h)
+	}
+
+	if h.Bytes() != data {
+		t.Errorf("Bytes does not round-trip")
+	}
+	if s := h.Hash().String(); s != genesisHash {
+		t.Errorf("Hash = %v", s)
+	}
+
+	if _, err := ParseHeader(data[:79]); err == nil {
+		t.Errorf("Expected error for short header")
+	}
+}
+
+func TestTarget(t *testing.T) {
+	tests := []struct {
+		bits   uint32
+		target string
+		err    error
+	}{
+		{bits: 0x1e0fffff, target: "fffff000000000000000000000000000000000000000000000000000000"},
+		{bits: 0x1d00ffff, target: "ffff0000000000000000000000000000000000000000000000000000"},
+		{bits: 0x05009234, target: "92340000"},
+		{bits: 0x04923456, err: ErrTarget},
+		{bits: 0x03123456, target: "123456"},
+		{bits: 0x02123456, target: "1234"},
+		{bits: 0x01123456, target: "12"},
+		{bits: 0x01003456, target: "0"},
+		{bits: 0x00123456, target: "0"},
+		{bits: 0x01fedcba, err: ErrTarget},
+		{bits: 0x20123456, target: "1234560000000000000000000000000000000000000000000000000000000000"},
+		{bits: 0x2100ffff, target: "ffff000000000000000000000000000000000000000000000000000000000000"},
+		{bits: 0x21010000, err: ErrTarget},
+		{bits: 0x22010000, err: ErrTarget},
+		{bits: 0xff123456, err: ErrTarget},
+	}
+
+	for _, test := range tests {
+		target, err := Target(test.bits)
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("Target(%#08x): expected %v, got %v", test.bits, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Target(%#08x): %v", test.bits, err)
+			continue
+		}
+
+		want, _ := new(big.Int).SetString(test.target, 16)
+		if target.Cmp(want) != 0 {
+			t.Errorf("Target(%#08x) = %x, expected %x", test.bits, target, want)
+		}
+	}
+}
+
+func TestCheckProofOfWork(t *testing.T) {
+	data := genesisHeader(t)
+	h, err := ParseHeader(data[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := h.CheckProofOfWork(MainPowLimit); err != nil {
+		t.Errorf("Genesis: %v", err)
+	}
+
+	bad := h
+	bad.Nonce++
+	if err := bad.CheckProofOfWork(MainPowLimit); !errors.Is(err, ErrProofOfWork) {
+		t.Errorf("Expected ErrProofOfWork, got %v", err)
+	}
+
+	easy := h
+	easy.Bits = 0x1f0fffff
+	if err := easy.CheckProofOfWork(MainPowLimit); !errors.Is(err, ErrTarget) {
+		t.Errorf("Expected ErrTarget for target above limit, got %v", err)
+	}
+
+	zero := h
+	zero.Bits = 0
+	if err := zero.CheckProofOfWork(MainPowLimit); !errors.Is(err, ErrTarget) {
+		t.Errorf("Expected ErrTarget for zero target, got %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package groestlcoin
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// MainPowLimit is the easiest target allowed on the Groestlcoin main
+// network, 2^236-1.
+var MainPowLimit = mustParseHash("00000fffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+
+var (
+	// ErrTarget is returned when a compact target is negative, zero,
+	// does not fit in 256 bits or is easier than the limit.
+	ErrTarget = errors.New("groestlcoin: invalid target")
+
+	// ErrProofOfWork is returned when a hash is above its target.
+	ErrProofOfWork = errors.New("groestlcoin: hash does not meet target")
+)
+
+func mustParseHash(s string) Hash {
+	h, err := ParseHash(s)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+// Big returns h interpreted as a little-endian number.
+func (h Hash) Big() *big.Int {
+	var r Hash
+	for i, c := range h {
+		r[len(r)-1-i] = c
+	}
+	return new(big.Int).SetBytes(r[:])
+}
+
+// Target decodes a target from its compact representation, as used in
+// the Bits field of a block header. The top byte of bits is the length
+// of the target in bytes and the low 23 bits are its most significant
+// bits. Bit 23 is a sign bit. An error wrapping ErrTarget is returned
+// if the target is negative or does not fit in 256 bits.
+func Target(bits uint32) (*big.Int, error) {
+	size := bits >> 24
+	word := bits & 0x007fffff
+
+	target := big.NewInt(int64(word))
+	if size <= 3 {
+		target.Rsh(target, uint(8*(3-size)))
+	} else {
+		target.Lsh(target, uint(8*(size-3)))
+	}
+
+	if (word != 0) && (bits&0x00800000 != 0) {
+		return nil, fmt.Errorf("%w: %#08x is negative", ErrTarget, bits)
+	}
+	if target.BitLen() > 256 {
+		return nil, fmt.Errorf("%w: %#08x overflows", ErrTarget, bits)
+	}
+	return target, nil
+}
+
+// CheckProofOfWork checks that hash is no greater than the target
+// encoded in bits, and that the target is positive and no greater than
+// powLimit. It returns an error wrapping ErrTarget if the target is
+// invalid, or ErrProofOfWork if the hash does not meet it.
+func CheckProofOfWork(hash Hash, bits uint32, powLimit Hash) error {
+	target, err := Target(bits)
+	if err != nil {
+		return err
+	}
+	if (target.Sign() == 0) || (target.Cmp(powLimit.Big()) > 0) {
+		return fmt.Errorf("%w: %#08x is out of range", ErrTarget, bits)
+	}
+
+	if hash.Big().Cmp(target) > 0 {
+		return ErrProofOfWork
+	}
+	return nil
+}
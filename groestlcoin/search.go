@@ -0,0 +1,134 @@
+package groestlcoin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"runtime"
+	"sync"
+
+	"github.com/DeedleFake/crypto/groestl512"
+)
+
+// ErrNonceSpace is returned by Search when no nonce produces a hash
+// that meets the target.
+var ErrNonceSpace = errors.New("groestlcoin: nonce space exhausted")
+
+// Midstate is the state of the block hash of a header after everything
+// but its nonce. It is safe for concurrent use.
+type Midstate struct {
+	m *groestl512.Midstate
+}
+
+// NewMidstate returns the midstate of header. The nonce already in the
+// header is ignored.
+func NewMidstate(header [HeaderSize]byte) *Midstate {
+	ctx := groestl512.New().(*groestl512.Digest)
+	ctx.Write(header[:HeaderSize-groestl512.NonceSize])
+	return &Midstate{m: ctx.Midstate()}
+}
+
+// HashWithNonce returns the block hash of the header with the given
+// nonce.
+func (m *Midstate) HashWithNonce(nonce uint32) Hash {
+	first := m.m.FinishWithNonce(nonce)
+	second := groestl512.Sum(first[:])
+	return Hash(second[:HashSize])
+}
+
+// searchCheckInterval is the number of nonces that a worker tries
+// between checks for cancellation.
+const searchCheckInterval = 1 << 10
+
+// Search looks for a nonce for which the block hash of the header that
+// m was created from is no greater than target, and returns the nonce
+// and the resulting hash. The nonce space is split between the given
+// number of goroutines, or runtime.GOMAXPROCS(0) of them if workers is
+// not positive. If several nonces are found at about the same time, it
+// is unspecified which one is returned.
+//
+// Search returns ctx.Err() if ctx is canceled first, and ErrNonceSpace
+// if every nonce has been tried.
+func Search(ctx context.Context, m *Midstate, target *big.Int, workers int) (nonce uint32, hash Hash, err error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	if target.Sign() < 0 {
+		return 0, Hash{}, fmt.Errorf("%w: %v is negative", ErrTarget, target)
+	}
+	limit := hashFromBig(target)
+
+	sctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		nonce uint32
+		hash  Hash
+	}
+	found := make(chan result, workers)
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for n := uint64(w); n <= math.MaxUint32; n += uint64(workers) {
+				if ((n/uint64(workers))%searchCheckInterval == 0) && (sctx.Err() != nil) {
+					return
+				}
+
+				hash := m.HashWithNonce(uint32(n))
+				if hash.cmp(limit) <= 0 {
+					found <- result{nonce: uint32(n), hash: hash}
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case r := <-found:
+		return r.nonce, r.hash, nil
+	default:
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, Hash{}, err
+	}
+	return 0, Hash{}, ErrNonceSpace
+}
+
+// hashFromBig returns n as a little-endian hash, saturating at the
+// largest one if n does not fit.
+func hashFromBig(n *big.Int) (h Hash) {
+	if n.BitLen() > 8*HashSize {
+		for i := range h {
+			h[i] = 0xFF
+		}
+		return h
+	}
+
+	n.FillBytes(h[:])
+	for i, j := 0, len(h)-1; i < j; i, j = i+1, j-1 {
+		h[i], h[j] = h[j], h[i]
+	}
+	return h
+}
+
+// cmp compares h and o as little-endian numbers.
+func (h Hash) cmp(o Hash) int {
+	for i := len(h) - 1; i >= 0; i-- {
+		switch {
+		case h[i] < o[i]:
+			return -1
+		case h[i] > o[i]:
+			return 1
+		}
+	}
+	return 0
+}
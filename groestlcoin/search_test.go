@@ -0,0 +1,91 @@
+package groestlcoin
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestMidstate(t *testing.T) {
+	header := genesisHeader(t)
+	m := NewMidstate(header)
+
+	h, err := ParseHeader(header[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := m.HashWithNonce(h.Nonce).String(); s != genesisHash {
+		t.Errorf("Expected %v, got %v", genesisHash, s)
+	}
+
+	for _, nonce := range []uint32{0, 1, 0xFFFFFFFF} {
+		h.Nonce = nonce
+		if got, want := m.HashWithNonce(nonce), h.Hash(); got != want {
+			t.Errorf("Nonce %#x: expected %v, got %v", nonce, want, got)
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	header := genesisHeader(t)
+	h, err := ParseHeader(header[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	target, err := Target(h.Bits)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The genesis nonce is low enough for the search to find it, or
+	// another one, quickly.
+	m := NewMidstate(header)
+
+	for _, workers := range []int{0, 1, 3} {
+		nonce, hash, err := Search(context.Background(), m, target, workers)
+		if err != nil {
+			t.Fatalf("%v workers: %v", workers, err)
+		}
+
+		h.Nonce = nonce
+		if h.Hash() != hash {
+			t.Errorf("%v workers: returned hash %v does not match nonce %v", workers, hash, nonce)
+		}
+		if err := h.CheckProofOfWork(MainPowLimit); err != nil {
+			t.Errorf("%v workers: %v", workers, err)
+		}
+	}
+}
+
+func TestSearchCancel(t *testing.T) {
+	m := NewMidstate(genesisHeader(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := Search(ctx, m, big.NewInt(0), 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	if _, _, err := Search(context.Background(), m, big.NewInt(-1), 2); !errors.Is(err, ErrTarget) {
+		t.Errorf("Expected ErrTarget, got %v", err)
+	}
+}
+
+func TestHashCmp(t *testing.T) {
+	for _, n := range []int64{0, 1, 255, 256, 1 << 40} {
+		a := hashFromBig(big.NewInt(n))
+		b := hashFromBig(big.NewInt(n + 1))
+		if (a.cmp(b) != -1) || (b.cmp(a) != 1) || (a.cmp(a) != 0) {
+			t.Errorf("Comparison of %v and %v is wrong", n, n+1)
+		}
+		if a.Big().Int64() != n {
+			t.Errorf("hashFromBig(%v).Big() = %v", n, a.Big())
+		}
+	}
+
+	huge := new(big.Int).Lsh(big.NewInt(1), 300)
+	if h := hashFromBig(huge); h.cmp(MainPowLimit) != 1 {
+		t.Errorf("Oversized target did not saturate: %v", h)
+	}
+}
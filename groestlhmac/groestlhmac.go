@@ -0,0 +1,108 @@
+// Package groestlhmac implements HMAC (RFC 2104) and HKDF (RFC 5869)
+// over the Groestl hash functions.
+package groestlhmac
+
+import (
+	"crypto/hkdf"
+	"crypto/hmac"
+	"hash"
+	"strconv"
+
+	"github.com/DeedleFake/crypto/groestl256"
+	"github.com/DeedleFake/crypto/groestl512"
+)
+
+// Hash identifies one of the Groestl hash functions.
+type Hash uint
+
+const (
+	Groestl224 Hash = 1 + iota
+	Groestl256
+	Groestl384
+	Groestl512
+)
+
+type hashInfo struct {
+	name string
+	size int
+	new  func() hash.Hash
+}
+
+// hashes uses the constant-time variants throughout, as everything in
+// this package hashes secret keys.
+var hashes = [...]hashInfo{
+	Groestl224: {name: "Groestl-224", size: groestl256.Size224, new: groestl256.NewConstantTime224},
+	Groestl256: {name: "Groestl-256", size: groestl256.Size, new: groestl256.NewConstantTime},
+	Groestl384: {name: "Groestl-384", size: groestl512.Size384, new: groestl512.NewConstantTime384},
+	Groestl512: {name: "Groestl-512", size: groestl512.Size, new: groestl512.NewConstantTime},
+}
+
+// Available reports whether h is one of the Groestl hash functions.
+func (h Hash) Available() bool {
+	return (h > 0) && (int(h) < len(hashes))
+}
+
+// New returns a new hash.Hash computing h with the constant-time
+// permutations. It panics if h is not available.
+func (h Hash) New() hash.Hash {
+	return h.hash().new()
+}
+
+// Size returns the length in bytes of a digest produced by h. It panics
+// if h is not available.
+func (h Hash) Size() int {
+	return h.hash().size
+}
+
+func (h Hash) String() string {
+	if !h.Available() {
+		return "Hash(" + strconv.FormatUint(uint64(h), 10) + ")"
+	}
+	return hashes[h].name
+}
+
+func (h Hash) hash() *hashInfo {
+	if !h.Available() {
+		panic("groestlhmac: unknown hash function " + h.String())
+	}
+	return &hashes[h]
+}
+
+// New returns a new hash.Hash computing the HMAC of h with the given
+// key. It panics if h is not available.
+func New(h Hash, key []byte) hash.Hash {
+	return hmac.New(h.hash().new, key)
+}
+
+// MAC returns the HMAC of message with the given key, using h. It
+// panics if h is not available.
+func MAC(h Hash, key, message []byte) []byte {
+	mac := New(h, key)
+	mac.Write(message)
+	return mac.Sum(nil)
+}
+
+// Equal compares two MACs for equality in constant time.
+func Equal(mac1, mac2 []byte) bool {
+	return hmac.Equal(mac1, mac2)
+}
+
+// Extract derives a pseudorandom key from the input keying material
+// secret and an optional salt, using h. An empty salt is equivalent to
+// a salt of h.Size() zero bytes.
+func Extract(h Hash, secret, salt []byte) ([]byte, error) {
+	return hkdf.Extract(h.hash().new, secret, salt)
+}
+
+// Expand derives a key of length bytes from the pseudorandom key prk,
+// which should usually come from Extract, and optional context info,
+// using h. The length may not exceed 255*h.Size().
+func Expand(h Hash, prk []byte, info string, length int) ([]byte, error) {
+	return hkdf.Expand(h.hash().new, prk, info, length)
+}
+
+// Key derives a key of length bytes from secret, salt and info by
+// running Extract followed by Expand.
+func Key(h Hash, secret, salt []byte, info string, length int) ([]byte, error) {
+	return hkdf.Key(h.hash().new, secret, salt, info, length)
+}
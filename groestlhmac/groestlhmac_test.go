@@ -0,0 +1,311 @@
+package groestlhmac
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"testing"
+)
+
+func repeat(b byte, n int) []byte {
+	return bytes.Repeat([]byte{b}, n)
+}
+
+func seq(from, to int) []byte {
+	b := make([]byte, 0, to-from)
+	for i := from; i < to; i++ {
+		b = append(b, byte(i))
+	}
+	return b
+}
+
+func unhex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// The inputs of RFC 4231's HMAC-SHA-2 test cases. Test 5 truncates
+// the output to 128 bits.
+var macInputs = [...]struct {
+	key, data []byte
+}{
+	1: {key: repeat(0x0b, 20), data: []byte("Hi There")},
+	2: {key: []byte("Jefe"), data: []byte("what do ya want for nothing?")},
+	3: {key: repeat(0xaa, 20), data: repeat(0xdd, 50)},
+	4: {key: seq(1, 26), data: repeat(0xcd, 50)},
+	5: {key: repeat(0x0c, 20), data: []byte("Test With Truncation")},
+	6: {key: repeat(0xaa, 131), data: []byte("Test Using Larger Than Block-Size Key - Hash Key First")},
+	7: {key: repeat(0xaa, 131), data: []byte("This is a test using a larger than block-size key and a larger than block-size data. The key needs to be hashed before being used by the HMAC algorithm.")},
+}
+
+// The inputs of RFC 5869's HKDF-SHA-256 test cases.
+var hkdfInputs = [...]struct {
+	ikm, salt, info []byte
+	length          int
+}{
+	1: {ikm: repeat(0x0b, 22), salt: seq(0x00, 0x0d), info: seq(0xf0, 0xfa), length: 42},
+	2: {ikm: seq(0x00, 0x50), salt: seq(0x60, 0xb0), info: seq(0xb0, 0x100), length: 82},
+	3: {ikm: repeat(0x0b, 22), salt: nil, info: nil, length: 42},
+}
+
+// The expected outputs were computed by internal/refgen, with its
+// reference Groestl and the HMAC and HKDF of the standard library:
+//
+//	go run ./internal/refgen groestlhmac
+
+func TestMAC(t *testing.T) {
+	tests := []struct {
+		hash Hash
+		test int
+		mac  string
+	}{
+		{hash: Groestl224, test: 1, mac: "9350362d1da206e9d66a2a926deb3791472f4d452f3d34afd2ec2a50"},
+		{hash: Groestl224, test: 2, mac: "4570b25e9699b885c087c7d335ed6060b42c1fa0289559d202347bc2"},
+		{hash: Groestl224, test: 3, mac: "b480ee265cff3a6a92eb7718e6cc1567fa8b24e3f01cc80ccca47d76"},
+		{hash: Groestl224, test: 4, mac: "6ec424aad7758f720ac8ec0e7dbc73d66df8a7c385ff65b868b33918"},
+		{hash: Groestl224, test: 5, mac: "c02996bec423c374a0b0a6858ef4f3a5"},
+		{hash: Groestl224, test: 6, mac: "8515053aa99b8f5b72fd7fa96609b8525712bbf3a5e0957a5a1ff183"},
+		{hash: Groestl224, test: 7, mac: "77cecf53ab1f4270dbe1468a7fa5ca23343098c4ba3f593208fafa79"},
+		{hash: Groestl256, test: 1, mac: "8aaf19dca57e0abbade66a29dc0bd4d9b88c2085355fd68db7901d94ede6fe8a"},
+		{hash: Groestl256, test: 2, mac: "c73d0d315b1630e5714f1555fdf64f15556ca8ee5bca2a693d3da5ff04f9cf13"},
+		{hash: Groestl256, test: 3, mac: "c7a054d7a98b8c864c523050977db0145c3c709d373437eedc52dafbfaa5c7b4"},
+		{hash: Groestl256, test: 4, mac: "617c75b7dc4f2debdcd8dac223126ce5adc554073197ed901bca9ca7f3e7fa94"},
+		{hash: Groestl256, test: 5, mac: "e19e3129652ea72d8af02559e9b93c0f"},
+		{hash: Groestl256, test: 6, mac: "4408b2551f79112d8ffcf3697497ade84f1439d3fc24ee614a993646f19a421d"},
+		{hash: Groestl256, test: 7, mac: "8fe2b33741fb9b60e2e84b30084bafc9edf8d532969954f99fd9ef71264092fe"},
+		{hash: Groestl384, test: 1, mac: "8c1fac1684559575cabe7e3575767b83f8d55ffb4d68280019d0abe133a7adbddbc8819770ef229ac93b1f412d80f2a6"},
+		{hash: Groestl384, test: 2, mac: "c9d83c3164baeb8dc3f266346058e5a9a5e1468da5e4b0023096e2e4eada2564f739c5a567d3a2ffa61525b6fc31b6f4"},
+		{hash: Groestl384, test: 3, mac: "32f524606aca10f7708ad4e9f4d055fda6d2ece4157832117588a902c723b69ff5f15e82efe437cc645bd180299c930b"},
+		{hash: Groestl384, test: 4, mac: "52677819e05aecc04d9fc334c22f01a15d4a89c6a307cf2e0ea2446141560527f155775e679e58dcce6e16fa0a42a116"},
+		{hash: Groestl384, test: 5, mac: "c8f5062cbcf1fe0e1dbac98d4e211545"},
+		{hash: Groestl384, test: 6, mac: "e4100d1cf3485c42158de381f178867fffcbe321acb233d8e40022cde763e78acf73c1d631f65f35ef6a190c47305d0a"},
+		{hash: Groestl384, test: 7, mac: "71654dfa780eecfe41c566efff3289c472f5a20294609fc9c5b0bd004338c19783272df213a1008fb7c85bd4119a93bc"},
+		{hash: Groestl512, test: 1, mac: "70efea4d746f5a94aaf0b726a9c177d66a2a049c8e57ae7e86adfece3701f0e78bc47a61ac6c42194a54bcdfad1525a51913d161601ca5fce87491a0c92fd2ed"},
+		{hash: Groestl512, test: 2, mac: "8b8f53f2cbb6d068ee99b848b7d9b19c6d86daf46d42081ac303595f28cc7fefc52b92537bfbc3172cf2822201e518ea3cfe0a67f09a14932a8bdf9c65147434"},
+		{hash: Groestl512, test: 3, mac: "ca7d48ee4423252c2309aaae4ebd77392746e874b5b11c3d49cb71055a711797ccf0692838a4128ae5cfaca84c66142bb138fd36c398947dd3ac3599c0c72ce9"},
+		{hash: Groestl512, test: 4, mac: "dae0c5815eee49ce18a64e721b94bbab80973dd1d775ae24eb0d1e619d637e152f68f7b94a00ee736990522807f1bd22dfa6f1c294ff300f315cc8c94253528c"},
+		{hash: Groestl512, test: 5, mac: "61e6750a35fe0aaa711d4cffc1c4eaa2"},
+		{hash: Groestl512, test: 6, mac: "29af1423f80f2b1e2bd6b0cca21bcecffb6939f505bcb9287fcfaf39c8e17959ffa0c11fbea7e129829234962e9186162275cc9a715eee5a0482fec4c272fca5"},
+		{hash: Groestl512, test: 7, mac: "c8f3e575a8537585b3a56c63a92cc577a44c63929d0553a425ebf27680c69a6de5ba8f75f38162f01053bc5c8c3cc356cd890753f7a2f1aa76b27943021610c1"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%v/%v", test.hash, test.test), func(t *testing.T) {
+			in := macInputs[test.test]
+			want := unhex(t, test.mac)
+
+			mac := MAC(test.hash, in.key, in.data)
+			if len(mac) != test.hash.Size() {
+				t.Errorf("Expected %v bytes, got %v", test.hash.Size(), len(mac))
+			}
+			if !Equal(mac[:len(want)], want) {
+				t.Errorf("Expected %x", want)
+				t.Errorf("Got %x", mac[:len(want)])
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	in := macInputs[7]
+	want := MAC(Groestl256, in.key, in.data)
+
+	h := New(Groestl256, in.key)
+	h.Write(in.data[:50])
+	h.Sum(nil)
+	h.Write(in.data[50:])
+	if got := h.Sum(nil); !bytes.Equal(got, want) {
+		t.Errorf("Expected %x", want)
+		t.Errorf("Got %x", got)
+	}
+
+	h.Reset()
+	h.Write(in.data)
+	if got := h.Sum(nil); !bytes.Equal(got, want) {
+		t.Errorf("After Reset: expected %x", want)
+		t.Errorf("After Reset: got %x", got)
+	}
+}
+
+func TestConstantTime(t *testing.T) {
+	for h := Groestl224; h <= Groestl512; h++ {
+		// Record the digests that New creates underneath the HMAC.
+		var digests []hash.Hash
+		orig := hashes[h].new
+		hashes[h].new = func() hash.Hash {
+			d := orig()
+			digests = append(digests, d)
+			return d
+		}
+		New(h, []byte("key"))
+		hashes[h].new = orig
+
+		if len(digests) == 0 {
+			t.Fatalf("%v: New created no digests", h)
+		}
+		for _, d := range digests {
+			ct, ok := d.(interface{ ConstantTime() bool })
+			if !ok || !ct.ConstantTime() {
+				t.Errorf("%v: %T is not constant-time", h, d)
+			}
+		}
+	}
+}
+
+func TestEqual(t *testing.T) {
+	a := MAC(Groestl512, []byte("key"), []byte("message"))
+	b := MAC(Groestl512, []byte("key"), []byte("message!"))
+	if !Equal(a, a) || Equal(a, b) || Equal(a, a[:32]) {
+		t.Errorf("Equal gave wrong result")
+	}
+}
+
+func TestHKDF(t *testing.T) {
+	tests := []struct {
+		hash Hash
+		test int
+		prk  string
+		okm  string
+	}{
+		{
+			hash: Groestl224,
+			test: 1,
+			prk:  "1422a22750a9d5c12e39f0c031d71b3d3b6b07b6aab420a96703a14e",
+			okm:  "159e2883668915e0315a6e783d40a34d420492d8e347a69fd67d3f10beb25f7e8e8d1449406400aa165d",
+		},
+		{
+			hash: Groestl224,
+			test: 2,
+			prk:  "40e98123082b44abaf984c6cd0534be551833291d6664d65ec0f2454",
+			okm:  "0a3c78197f46608b4f5c40ed3bf7e75cfabf0a8dc222e9d121e197e4d23684d90b7b1a0851781872b7a2042ea382d912b742d343816b123b3f8c47bd3d72b53cf8756154295cd68ba1aaba0535a99cfbe2b2",
+		},
+		{
+			hash: Groestl224,
+			test: 3,
+			prk:  "bd6da5e47336fa9c98be03dffd02be70ba419e62b1f4b7a4d50515ec",
+			okm:  "040b6fedc4f52ffa714f6c3b2411c4c6120e1f2a2024379418484f6c05afd62e8c0d9635d701632c2448",
+		},
+		{
+			hash: Groestl256,
+			test: 1,
+			prk:  "821f410049ce5e2580ae56c9f7c1f06372318453a71f3661ca3ce49c1074de0e",
+			okm:  "fd8322c3090f05a9f909afff05f2caed582073c626b30b2fec82f35f2dfc91670b5555ac318e93c397a1",
+		},
+		{
+			hash: Groestl256,
+			test: 2,
+			prk:  "5abf4996054247373ffa991f68cae729b60674658ec42e60bf58aec0c08e3384",
+			okm:  "4ddeffad01558c6285df3a7e0c02171aa28e902d87c0b64ee8cfd9d6fe0f052fb0627d5271504cb65a24792b67e2fe307790b51b714c8009b9d12ac0e456ac950815c9e6713669b19aed5a2957976d2039c6",
+		},
+		{
+			hash: Groestl256,
+			test: 3,
+			prk:  "41bf9851416188d4a1d2d900b5cf453dd8d3cd460725efc52b4d87a121dcd55c",
+			okm:  "3f29de714b53350bea85e6a20673f7548ab7f37bad47cbb8b77b7ae08814470d78dd9a0058eca19c13d8",
+		},
+		{
+			hash: Groestl384,
+			test: 1,
+			prk:  "ed1b9f63bd7cb79d9f36855c90a0fe74164e567d658224247a9924661d7df3a9998affea69ddf816f554c312549ab5f5",
+			okm:  "f65796d4bb44ea45f9e267cebccfb18200a2aa92deb91c5e64d60a258f253327c96539d4018a62178b9b",
+		},
+		{
+			hash: Groestl384,
+			test: 2,
+			prk:  "ae03e99e6855b01576da52d14fae18befe03bfbfd21dde3950a66bbb06adcbfdcc7fa8620cfdf841b3b32a86a8df7972",
+			okm:  "f0166e4f325a8a28475af6477a36d429212b51967acb22126d4150aafd77265eb63d2547f38c022960ccc44c05c5b98124f253435835e49d77c85c1ce356f5fbb567ab953b70d81f0ff92564e86153571250",
+		},
+		{
+			hash: Groestl384,
+			test: 3,
+			prk:  "16366aa32a3d2fc340546a9a3c294a17a1aa57717bc6e006b3c8448edf16610a1c9216ca932d2666ce661b83acdf0a75",
+			okm:  "8c3de0c1e3069865a44ef9e7b221e9fb92f9878e2373726644c0c43cf71caebdc4da1115e36c11a84225",
+		},
+		{
+			hash: Groestl512,
+			test: 1,
+			prk:  "d1583830743a11728c6854db2e6e6236112c933df6a35acb472df7bfcd914d23bab558e1492b4c772903682d87cf14bcee305375e1ddbef48378b50cf1bd704b",
+			okm:  "bbbfded01177ac3ce37118e69e9eaf03c52a58ea2d2f6758c394f4512310a7da433dfcebcca2df404400",
+		},
+		{
+			hash: Groestl512,
+			test: 2,
+			prk:  "1c4994a20bd1cdcdfe60f70cf12a1a82c637289a857a1aa404a15c1922d10c724d572bd7f9022ea40db3746915079ee3214c88dbbd4968656414b6a95fd8643c",
+			okm:  "1a3e4ab2cc2aa71f96be77213ec25e7ef3bb7b6c66f938ecd91abf66da2f5c837944cf60fb143514bcbce2fa6a062d006a07362f7235dad3bfdcecea01aaabd7ba3555d587f61ca10bf5cd3f003a075b175b",
+		},
+		{
+			hash: Groestl512,
+			test: 3,
+			prk:  "366045dd778bac95f7ee3326b702a481b53cea1e2ac016231a23e08593019aff8ae6e02d4caf695566a5a5bcef50e7156a3b3180b406ca1c0ec5375d8745513e",
+			okm:  "697063ccc2e3087f185cd9ed5868ad7c3aefc294228b58bdc67eff0d7ecf3eab6be3c739dd664b743095",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%v/%v", test.hash, test.test), func(t *testing.T) {
+			in := hkdfInputs[test.test]
+			wantPRK, wantOKM := unhex(t, test.prk), unhex(t, test.okm)
+
+			prk, err := Extract(test.hash, in.ikm, in.salt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(prk, wantPRK) {
+				t.Errorf("Expected PRK %x", wantPRK)
+				t.Errorf("Got %x", prk)
+			}
+
+			okm, err := Expand(test.hash, prk, string(in.info), in.length)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(okm, wantOKM) {
+				t.Errorf("Expected OKM %x", wantOKM)
+				t.Errorf("Got %x", okm)
+			}
+
+			key, err := Key(test.hash, in.ikm, in.salt, string(in.info), in.length)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(key, wantOKM) {
+				t.Errorf("Key: expected %x", wantOKM)
+				t.Errorf("Key: got %x", key)
+			}
+		})
+	}
+}
+
+func TestExpandTooLong(t *testing.T) {
+	prk := make([]byte, Groestl224.Size())
+	if _, err := Expand(Groestl224, prk, "", 255*Groestl224.Size()+1); err == nil {
+		t.Errorf("Expected error")
+	}
+}
+
+func TestHash(t *testing.T) {
+	if Hash(0).Available() || Hash(5).Available() {
+		t.Errorf("Invalid hashes reported as available")
+	}
+	if s := Hash(5).String(); s != "Hash(5)" {
+		t.Errorf("Expected Hash(5), got %q", s)
+	}
+	if s := Groestl384.String(); s != "Groestl-384" {
+		t.Errorf("Expected Groestl-384, got %q", s)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected panic")
+		}
+	}()
+	Hash(0).New()
+}
@@ -0,0 +1,31 @@
+// Package groestlpbkdf2 implements the PBKDF2 key derivation function
+// (RFC 8018) with HMAC-Groestl, along with an encoding of password
+// hashes in the PHC string format:
+//
+//	$pbkdf2-groestl256$i=<iterations>$<salt>$<hash>
+//
+// where the salt and hash are base64 without padding.
+package groestlpbkdf2
+
+import (
+	"crypto/pbkdf2"
+	"errors"
+
+	"github.com/DeedleFake/crypto/groestlhmac"
+)
+
+var errIterations = errors.New("groestlpbkdf2: iteration count must be positive")
+
+// Key derives a key of keyLen bytes from password and salt with PBKDF2
+// and HMAC-Groestl-256, doing iter iterations.
+func Key(password, salt []byte, iter, keyLen int) ([]byte, error) {
+	return KeyHash(groestlhmac.Groestl256, password, salt, iter, keyLen)
+}
+
+// KeyHash is like Key, but uses the Groestl hash function h.
+func KeyHash(h groestlhmac.Hash, password, salt []byte, iter, keyLen int) ([]byte, error) {
+	if iter < 1 {
+		return nil, errIterations
+	}
+	return pbkdf2.Key(h.New, string(password), salt, iter, keyLen)
+}
@@ -0,0 +1,76 @@
+package groestlpbkdf2
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/DeedleFake/crypto/groestlhmac"
+)
+
+// The inputs are those of RFC 6070. The expected outputs were computed
+// by internal/refgen, with its reference Groestl and the PBKDF2 of the
+// standard library:
+//
+//	go run ./internal/refgen groestlpbkdf2
+func TestKey(t *testing.T) {
+	tests := []struct {
+		hash     groestlhmac.Hash
+		password string
+		salt     string
+		iter     int
+		key      string
+	}{
+		{groestlhmac.Groestl224, "password", "salt", 2, "1adda8b140439a9df9c819eb4cc92193ec8707a073cc9ac941b4a613"},
+		{groestlhmac.Groestl256, "password", "salt", 1, "a634b70db0ca0a831b95797d5236561ac747ffb2f422f3a9af488dcd38d52e21"},
+		{groestlhmac.Groestl256, "password", "salt", 2, "b98ba872dfd6ab04519c5c0253897b373e4fe1d780f73d219487fb7e94acba8e"},
+		{groestlhmac.Groestl256, "password", "salt", 4096, "d12d20dbeae12594a74680f3461216c338ca785930f5d4976b29b83bbf9d5ed7"},
+		{groestlhmac.Groestl256, "passwordPASSWORDpassword", "saltSALTsaltSALTsaltSALTsaltSALTsalt", 4096, "de1bc912f9f19cf97619592430cdf97d07ae3a8f85d252a8d77f61f2caf5c90b46d386acf4dd4402"},
+		{groestlhmac.Groestl256, "pass\x00word", "sa\x00lt", 4096, "a308b48dd715767213acd7d76ea5ddc1"},
+		{groestlhmac.Groestl384, "password", "salt", 2, "4ccb66c399d113a3a632aee286f075e527f7b9872eb8c4d8fa269c1195404372e9c642bc93915adb56eea3641a28af49"},
+		{groestlhmac.Groestl512, "password", "salt", 1, "9722a7d84d77aa5d375158a6d8550dffe5f8f6ff89960bab25a4ea1ce520b29a4d271eb33023b7aa5f124ce46bd4f8ac995d412e1bf5ed376a9ee9cfa06e219d"},
+		{groestlhmac.Groestl512, "password", "salt", 2, "32d3bfc39e956c687ae37f5f1ac383e0329723e1e24d0d12356cb04c492c6876cb3dd005159f59f8a68b0425168729865d9d2862dfd8a5f43122fb5d3f77ad75"},
+		{groestlhmac.Groestl512, "password", "salt", 2, "32d3bfc39e956c687ae37f5f1ac383e0329723e1e24d0d12356cb04c492c6876cb3dd005159f59f8a68b0425168729865d9d2862dfd8a5f43122fb5d3f77ad75058fa2e639052ec952de54bfb781e1e4"},
+		{groestlhmac.Groestl512, "password", "salt", 4096, "6b8012c7b52f671a7bc4e082bf526d1cc91e481435a63f1a82fecb9127108e06d26ea554d1343f61b516e62030997dbb64dc7522f9712d4606da7e44f01f19ef"},
+	}
+
+	for i, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%v/%v", test.hash, i), func(t *testing.T) {
+			want, _ := hex.DecodeString(test.key)
+			key, err := KeyHash(test.hash, []byte(test.password), []byte(test.salt), test.iter, len(want))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(key, want) {
+				t.Errorf("Expected %x", want)
+				t.Errorf("Got %x", key)
+			}
+		})
+	}
+}
+
+func TestKeyDefault(t *testing.T) {
+	want, err := KeyHash(groestlhmac.Groestl256, []byte("password"), []byte("salt"), 2, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := Key([]byte("password"), []byte("salt"), 2, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(key, want) {
+		t.Errorf("Expected %x", want)
+		t.Errorf("Got %x", key)
+	}
+}
+
+func TestKeyErrors(t *testing.T) {
+	if _, err := Key([]byte("password"), []byte("salt"), 0, 32); err == nil {
+		t.Errorf("Expected error for zero iterations")
+	}
+	if _, err := Key([]byte("password"), []byte("salt"), 1, 0); err == nil {
+		t.Errorf("Expected error for zero key length")
+	}
+}
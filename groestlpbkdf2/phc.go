@@ -0,0 +1,202 @@
+package groestlpbkdf2
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/DeedleFake/crypto/groestlhmac"
+)
+
+// Limits on the parameters of encoded password hashes. Hashes with
+// parameters below the minimums are rejected as too weak to be safe,
+// and ones above the maximums are rejected so that a crafted hash
+// can't make Verify do an unreasonable amount of work.
+const (
+	MinIterations = 10000
+	MaxIterations = 1 << 24
+
+	MinSaltLen = 16
+	MaxSaltLen = 64
+
+	MinKeyLen = 16
+	MaxKeyLen = 128
+)
+
+// DefaultSaltLen is the length of the salts generated by Hash.
+const DefaultSaltLen = 16
+
+var (
+	// ErrMismatch is returned by Verify when the password does not
+	// match the hash.
+	ErrMismatch = errors.New("groestlpbkdf2: password does not match")
+
+	// ErrFormat is returned when an encoded hash is malformed.
+	ErrFormat = errors.New("groestlpbkdf2: malformed password hash")
+
+	// ErrWeakParameters is returned when the parameters of a hash are
+	// below the minimums.
+	ErrWeakParameters = errors.New("groestlpbkdf2: parameters are too weak")
+
+	// ErrParameters is returned when the parameters of a hash are above
+	// the maximums or use an unknown hash function.
+	ErrParameters = errors.New("groestlpbkdf2: unsupported parameters")
+)
+
+var b64 = base64.RawStdEncoding.Strict()
+
+var ids = map[groestlhmac.Hash]string{
+	groestlhmac.Groestl224: "pbkdf2-groestl224",
+	groestlhmac.Groestl256: "pbkdf2-groestl256",
+	groestlhmac.Groestl384: "pbkdf2-groestl384",
+	groestlhmac.Groestl512: "pbkdf2-groestl512",
+}
+
+// Hash derives a key from password with a random salt of
+// DefaultSaltLen bytes, using h and doing iter iterations, and returns
+// it as an encoded hash. The key is h.Size() bytes long.
+func Hash(h groestlhmac.Hash, password []byte, iter int) (string, error) {
+	if !h.Available() {
+		return "", fmt.Errorf("%w: %v", ErrParameters, h)
+	}
+
+	// Check the parameters before deriving the key so that a call that
+	// Encode would reject doesn't do all of the iterations first.
+	if err := check(h, iter, DefaultSaltLen, h.Size()); err != nil {
+		return "", err
+	}
+
+	salt := make([]byte, DefaultSaltLen)
+	rand.Read(salt)
+
+	key, err := KeyHash(h, password, salt, iter, h.Size())
+	if err != nil {
+		return "", err
+	}
+
+	return Encode(h, iter, salt, key)
+}
+
+// Encode returns the encoded hash of a key that was derived using h
+// with the given iteration count and salt.
+func Encode(h groestlhmac.Hash, iter int, salt, key []byte) (string, error) {
+	if err := check(h, iter, len(salt), len(key)); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("$")
+	sb.WriteString(ids[h])
+	sb.WriteString("$i=")
+	sb.WriteString(strconv.Itoa(iter))
+	sb.WriteString("$")
+	sb.WriteString(b64.EncodeToString(salt))
+	sb.WriteString("$")
+	sb.WriteString(b64.EncodeToString(key))
+	return sb.String(), nil
+}
+
+// Decode parses an encoded hash and returns its parameters.
+func Decode(encoded string) (h groestlhmac.Hash, iter int, salt, key []byte, err error) {
+	parts := strings.Split(encoded, "$")
+	if (len(parts) != 5) || (parts[0] != "") {
+		return 0, 0, nil, nil, ErrFormat
+	}
+
+	for hash, id := range ids {
+		if parts[1] == id {
+			h = hash
+		}
+	}
+	if h == 0 {
+		return 0, 0, nil, nil, fmt.Errorf("%w: unknown algorithm %q", ErrParameters, parts[1])
+	}
+
+	iter, err = parseIterations(parts[2])
+	if err != nil {
+		return 0, 0, nil, nil, err
+	}
+
+	salt, err = b64.DecodeString(parts[3])
+	if err != nil {
+		return 0, 0, nil, nil, ErrFormat
+	}
+	key, err = b64.DecodeString(parts[4])
+	if err != nil {
+		return 0, 0, nil, nil, ErrFormat
+	}
+
+	if err := check(h, iter, len(salt), len(key)); err != nil {
+		return 0, 0, nil, nil, err
+	}
+
+	return h, iter, salt, key, nil
+}
+
+// parseIterations parses the i parameter. As required by the PHC
+// format, it must be a decimal number without a sign or leading zeros.
+func parseIterations(param string) (int, error) {
+	v, ok := strings.CutPrefix(param, "i=")
+	if !ok || (v == "") || (len(v) > 10) || ((v[0] == '0') && (len(v) > 1)) {
+		return 0, ErrFormat
+	}
+	for _, c := range v {
+		if (c < '0') || (c > '9') {
+			return 0, ErrFormat
+		}
+	}
+
+	iter, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, ErrFormat
+	}
+	return iter, nil
+}
+
+// Verify checks whether password matches the encoded hash. It returns
+// ErrMismatch if it does not, or another error if the hash is malformed
+// or its parameters are outside of the limits. The comparison is done
+// in constant time.
+func Verify(encoded string, password []byte) error {
+	h, iter, salt, key, err := Decode(encoded)
+	if err != nil {
+		return err
+	}
+
+	got, err := KeyHash(h, password, salt, iter, len(key))
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare(got, key) != 1 {
+		return ErrMismatch
+	}
+	return nil
+}
+
+// check checks the parameters of a hash against the limits.
+func check(h groestlhmac.Hash, iter, saltLen, keyLen int) error {
+	if !h.Available() {
+		return fmt.Errorf("%w: %v", ErrParameters, h)
+	}
+
+	switch {
+	case iter < MinIterations:
+		return fmt.Errorf("%w: %v iterations", ErrWeakParameters, iter)
+	case saltLen < MinSaltLen:
+		return fmt.Errorf("%w: %v byte salt", ErrWeakParameters, saltLen)
+	case keyLen < MinKeyLen:
+		return fmt.Errorf("%w: %v byte key", ErrWeakParameters, keyLen)
+	case iter > MaxIterations:
+		return fmt.Errorf("%w: %v iterations", ErrParameters, iter)
+	case saltLen > MaxSaltLen:
+		return fmt.Errorf("%w: %v byte salt", ErrParameters, saltLen)
+	case keyLen > MaxKeyLen:
+		return fmt.Errorf("%w: %v byte key", ErrParameters, keyLen)
+	}
+	return nil
+}
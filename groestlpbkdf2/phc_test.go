@@ -0,0 +1,128 @@
+package groestlpbkdf2
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/DeedleFake/crypto/groestlhmac"
+)
+
+// knownHash was computed by go run ./internal/refgen groestlpbkdf2.
+const (
+	knownPassword = "correct horse battery staple"
+	knownHash     = "$pbkdf2-groestl256$i=10000$MDEyMzQ1Njc4OWFiY2RlZg$hF6ZgB30u9PC8mSSeCsLa4Zza5t+Z0utFrENLJApMRs"
+)
+
+func TestVerifyKnown(t *testing.T) {
+	if err := Verify(knownHash, []byte(knownPassword)); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+	if err := Verify(knownHash, []byte("Correct horse battery staple")); !errors.Is(err, ErrMismatch) {
+		t.Errorf("Expected ErrMismatch, got %v", err)
+	}
+
+	h, iter, salt, key, err := Decode(knownHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (h != groestlhmac.Groestl256) || (iter != 10000) || (string(salt) != "0123456789abcdef") || (len(key) != 32) {
+		t.Errorf("Decode = %v, %v, %q, %x", h, iter, salt, key)
+	}
+
+	encoded, err := Encode(h, iter, salt, key)
+	if (encoded != knownHash) || (err != nil) {
+		t.Errorf("Encode = %q, %v", encoded, err)
+	}
+}
+
+func TestHash(t *testing.T) {
+	for _, h := range []groestlhmac.Hash{groestlhmac.Groestl224, groestlhmac.Groestl256, groestlhmac.Groestl384, groestlhmac.Groestl512} {
+		h := h
+		t.Run(h.String(), func(t *testing.T) {
+			encoded, err := Hash(h, []byte("password"), MinIterations)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(encoded, "$"+ids[h]+"$i=10000$") {
+				t.Errorf("Unexpected encoding %q", encoded)
+			}
+
+			if err := Verify(encoded, []byte("password")); err != nil {
+				t.Errorf("Verify: %v", err)
+			}
+			if err := Verify(encoded, []byte("passwore")); !errors.Is(err, ErrMismatch) {
+				t.Errorf("Expected ErrMismatch, got %v", err)
+			}
+
+			again, err := Hash(h, []byte("password"), MinIterations)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if again == encoded {
+				t.Errorf("Salt was reused")
+			}
+		})
+	}
+}
+
+func TestHashWeak(t *testing.T) {
+	if _, err := Hash(groestlhmac.Groestl256, []byte("password"), MinIterations-1); !errors.Is(err, ErrWeakParameters) {
+		t.Errorf("Expected ErrWeakParameters, got %v", err)
+	}
+	if _, err := Hash(groestlhmac.Hash(0), []byte("password"), MinIterations); !errors.Is(err, ErrParameters) {
+		t.Errorf("Expected ErrParameters, got %v", err)
+	}
+}
+
+func TestHashTooManyIterations(t *testing.T) {
+	// If Hash derived the key before checking the limits, this would
+	// take minutes instead of returning straight away.
+	if _, err := Hash(groestlhmac.Groestl512, []byte("password"), math.MaxInt); !errors.Is(err, ErrParameters) {
+		t.Errorf("Expected ErrParameters, got %v", err)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	const (
+		salt = "MDEyMzQ1Njc4OWFiY2RlZg"
+		key  = "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"
+	)
+
+	tests := []struct {
+		name    string
+		encoded string
+		err     error
+	}{
+		{name: "Empty", encoded: "", err: ErrFormat},
+		{name: "NoLeadingDollar", encoded: "pbkdf2-groestl256$i=10000$" + salt + "$" + key, err: ErrFormat},
+		{name: "TooFewFields", encoded: "$pbkdf2-groestl256$i=10000$" + salt, err: ErrFormat},
+		{name: "TooManyFields", encoded: "$pbkdf2-groestl256$i=10000$" + salt + "$" + key + "$", err: ErrFormat},
+		{name: "UnknownAlgorithm", encoded: "$pbkdf2-sha256$i=10000$" + salt + "$" + key, err: ErrParameters},
+		{name: "MissingIterations", encoded: "$pbkdf2-groestl256$10000$" + salt + "$" + key, err: ErrFormat},
+		{name: "LeadingZero", encoded: "$pbkdf2-groestl256$i=010000$" + salt + "$" + key, err: ErrFormat},
+		{name: "Sign", encoded: "$pbkdf2-groestl256$i=+10000$" + salt + "$" + key, err: ErrFormat},
+		{name: "Overflow", encoded: "$pbkdf2-groestl256$i=99999999999999999999$" + salt + "$" + key, err: ErrFormat},
+		{name: "BadSalt", encoded: "$pbkdf2-groestl256$i=10000$" + salt + "=$" + key, err: ErrFormat},
+		{name: "BadKey", encoded: "$pbkdf2-groestl256$i=10000$" + salt + "$" + key + "!", err: ErrFormat},
+		{name: "FewIterations", encoded: "$pbkdf2-groestl256$i=9999$" + salt + "$" + key, err: ErrWeakParameters},
+		{name: "ShortSalt", encoded: "$pbkdf2-groestl256$i=10000$c2FsdA$" + key, err: ErrWeakParameters},
+		{name: "ShortKey", encoded: "$pbkdf2-groestl256$i=10000$" + salt + "$AAAA", err: ErrWeakParameters},
+		{name: "ManyIterations", encoded: "$pbkdf2-groestl256$i=999999999$" + salt + "$" + key, err: ErrParameters},
+		{name: "LongSalt", encoded: "$pbkdf2-groestl256$i=10000$" + strings.Repeat("A", 100) + "$" + key, err: ErrParameters},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, _, _, _, err := Decode(test.encoded)
+			if !errors.Is(err, test.err) {
+				t.Errorf("Expected %v, got %v", test.err, err)
+			}
+			if err := Verify(test.encoded, []byte("password")); !errors.Is(err, test.err) {
+				t.Errorf("Verify: expected %v, got %v", test.err, err)
+			}
+		})
+	}
+}
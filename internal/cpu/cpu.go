@@ -0,0 +1,13 @@
+// Package cpu reports the processor features that the assembly
+// implementations in this module depend on.
+package cpu
+
+// X86 holds the features of the processor when running on amd64. All
+// of the fields are false on other architectures.
+var X86 struct {
+	HasAES   bool
+	HasSSSE3 bool
+	HasAVX   bool
+	HasAVX2  bool
+	HasVAES  bool
+}
@@ -0,0 +1,47 @@
+//go:build amd64 && !purego
+
+package cpu
+
+const (
+	// CPUID.1:ECX
+	bitSSSE3   = 1 << 9
+	bitAES     = 1 << 25
+	bitOSXSAVE = 1 << 27
+	bitAVX     = 1 << 28
+
+	// CPUID.7.0:EBX
+	bitAVX2 = 1 << 5
+
+	// CPUID.7.0:ECX
+	bitVAES = 1 << 9
+)
+
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+func xgetbv() (eax, edx uint32)
+
+func init() {
+	maxID, _, _, _ := cpuid(0, 0)
+	if maxID < 1 {
+		return
+	}
+
+	_, _, ecx1, _ := cpuid(1, 0)
+	X86.HasSSSE3 = ecx1&bitSSSE3 != 0
+	X86.HasAES = ecx1&bitAES != 0
+
+	// AVX needs the operating system to save the YMM registers.
+	osAVX := false
+	if ecx1&bitOSXSAVE != 0 {
+		xcr0, _ := xgetbv()
+		osAVX = xcr0&6 == 6
+	}
+	X86.HasAVX = osAVX && (ecx1&bitAVX != 0)
+
+	if maxID < 7 {
+		return
+	}
+	_, ebx7, ecx7, _ := cpuid(7, 0)
+	X86.HasAVX2 = X86.HasAVX && (ebx7&bitAVX2 != 0)
+	X86.HasVAES = X86.HasAVX && (ecx7&bitVAES != 0)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSbox(t *testing.T) {
+	s := sbox()
+	for x, want := range map[byte]byte{0x00: 0x63, 0x01: 0x7C, 0x53: 0xED, 0xFF: 0x16} {
+		if s[x] != want {
+			t.Errorf("S(%#02x): expected %#02x, got %#02x", x, want, s[x])
+		}
+	}
+}
+
+func TestGenerated(t *testing.T) {
+	tests := []struct {
+		pkg     string
+		file    string
+		big     bool
+		compact bool
+	}{
+		{pkg: "groestl256", file: "internal.go"},
+		{pkg: "groestl256", file: "internal_compact.go", compact: true},
+		{pkg: "groestl512", file: "internal.go", big: true},
+		{pkg: "groestl512", file: "internal_compact.go", big: true, compact: true},
+	}
+
+	for _, test := range tests {
+		test := test
+		path := filepath.Join(test.pkg, test.file)
+		t.Run(path, func(t *testing.T) {
+			want, err := generate(test.pkg, test.big, test.compact)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := os.ReadFile(filepath.Join("..", "..", path))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(got, want) {
+				t.Errorf("%v is stale; run go generate ./%v", path, test.pkg)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+// Command gen generates the table-driven Groestl permutations used by
+// the groestl256 and groestl512 packages.
+//
+// The lookup tables are derived from the AES S-box, which is computed
+// from inversion in GF(2^8) followed by the AES affine transformation,
+// and from the circulant Groestl MixBytes matrix. The permutations take
+// the state as a pointer to an array, so that it lives in local
+// variables and no bounds are checked, and they are emitted unrolled
+// over the columns of the state. The 8-column permutations of
+// Groestl-224 and Groestl-256 are also unrolled over their rounds, with
+// the round constants as literals. The 16-column ones keep their rounds
+// in a loop: unrolled, the compiler spills their state to the stack,
+// and they run about 60% slower.
+//
+// With -compact, only the first of the eight tables is emitted, and
+// the others are derived from it with rotations. This takes an eighth of
+// the memory at some cost in speed, and is used when building with the
+// groestl_small tag. The compact permutations keep their rounds in a
+// loop. Unrolled, they would take about ten times the code, more than
+// the 14 KiB of tables that the tag saves, and would run slower too.
+//
+// Usage:
+//
+//	gen [-big] [-compact] [-pkg name] [-o file]
+//
+// It is run by go generate in each package.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+)
+
+// mixBytes is the first row of the circulant MixBytes matrix. Row i is
+// this row rotated right by i.
+var mixBytes = [8]byte{2, 2, 3, 4, 5, 3, 5, 7}
+
+// Per-row ShiftBytes amounts for the small and big permutations.
+var (
+	shiftSmallP = [8]int{0, 1, 2, 3, 4, 5, 6, 7}
+	shiftSmallQ = [8]int{1, 3, 5, 7, 0, 2, 4, 6}
+	shiftBigP   = [8]int{0, 1, 2, 3, 4, 5, 6, 11}
+	shiftBigQ   = [8]int{1, 3, 5, 11, 0, 2, 4, 6}
+)
+
+// mul multiplies a and b in GF(2^8) with the AES polynomial.
+func mul(a, b byte) (p byte) {
+	for b != 0 {
+		if b&1 != 0 {
+			p ^= a
+		}
+		hi := a & 0x80
+		a <<= 1
+		if hi != 0 {
+			a ^= 0x1B
+		}
+		b >>= 1
+	}
+	return p
+}
+
+// sbox computes the AES S-box.
+func sbox() (s [256]byte) {
+	for x := 0; x < 256; x++ {
+		// x^254 is the multiplicative inverse of x, with 0 mapping to
+		// 0.
+		inv := byte(1)
+		for i := 0; i < 254; i++ {
+			inv = mul(inv, byte(x))
+		}
+		if x == 0 {
+			inv = 0
+		}
+
+		y := inv
+		for i := 1; i < 5; i++ {
+			y ^= inv<<i | inv>>(8-i)
+		}
+		s[x] = y ^ 0x63
+	}
+	return s
+}
+
+// tables computes the combined SubBytes and MixBytes lookup tables.
+// Entry x of table k is the column that MixBytes produces from S(x) in
+// row k and zeros everywhere else, packed with row 0 in the most
+// significant byte.
+func tables() (t [8][256]uint64) {
+	s := sbox()
+	for k := range t {
+		for x := range t[k] {
+			var col uint64
+			for i := 0; i < 8; i++ {
+				col = col<<8 | uint64(mul(mixBytes[(k-i+8)%8], s[x]))
+			}
+			t[k][x] = col
+		}
+	}
+	return t
+}
+
+// generate returns the source of the permutations for package pkg.
+func generate(pkg string, big, compact bool) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by internal/gen. DO NOT EDIT.\n\n")
+	if compact {
+		fmt.Fprintf(&buf, "//go:build groestl_small\n\n")
+		fmt.Fprintf(&buf, "package %v\n\n", pkg)
+		fmt.Fprintf(&buf, "import \"math/bits\"\n")
+	} else {
+		fmt.Fprintf(&buf, "//go:build !groestl_small\n\n")
+		fmt.Fprintf(&buf, "package %v\n", pkg)
+	}
+
+	if big {
+		writePerm(&buf, "permBigP", 16, 14, &shiftBigP, false, compact, false)
+		writePerm(&buf, "permBigQ", 16, 14, &shiftBigQ, true, compact, false)
+	} else {
+		writePerm(&buf, "permSmallP", 8, 10, &shiftSmallP, false, compact, !compact)
+		writePerm(&buf, "permSmallQ", 8, 10, &shiftSmallQ, true, compact, !compact)
+	}
+	writeTables(&buf, compact)
+
+	return format.Source(buf.Bytes())
+}
+
+// writePerm writes a permutation of a state with the given number of
+// columns and rounds. q selects the round constants of Q instead of P,
+// compact selects lookups into t0 alone, and unroll selects straight-
+// line rounds instead of a loop.
+func writePerm(buf *bytes.Buffer, name string, cols, rounds int, shift *[8]int, q, compact, unroll bool) {
+	perm := "P"
+	if q {
+		perm = "Q"
+	}
+	fmt.Fprintf(buf, "\n// %v applies the Groestl permutation %v to the state x.\n", name, perm)
+	fmt.Fprintf(buf, "func %v(x *[%v]uint64) {\n", name, cols)
+	for j := 0; j < cols; j++ {
+		fmt.Fprintf(buf, "a%v := x[%v]\n", j, j)
+	}
+
+	if !unroll {
+		fmt.Fprintf(buf, "\nfor r := uint64(0); r < %v; r++ {\n", rounds)
+		for j := 0; j < cols; j++ {
+			switch {
+			case q:
+				fmt.Fprintf(buf, "a%v ^= 0x%016x ^ r\n", j, ^uint64(j<<4))
+			case j == 0:
+				fmt.Fprintf(buf, "a%v ^= r << 56\n", j)
+			default:
+				fmt.Fprintf(buf, "a%v ^= 0x%016x ^ r<<56\n", j, uint64(j<<4)<<56)
+			}
+		}
+		fmt.Fprintln(buf)
+
+		writeRound(buf, "a", "b", ":=", cols, shift, compact)
+		fmt.Fprintln(buf)
+
+		for j := 0; j < cols; j++ {
+			fmt.Fprintf(buf, "a%v = b%v\n", j, j)
+		}
+		fmt.Fprintf(buf, "}\n\n")
+
+		for j := 0; j < cols; j++ {
+			fmt.Fprintf(buf, "x[%v] = a%v\n", j, j)
+		}
+		fmt.Fprintf(buf, "}\n")
+		return
+	}
+
+	// The rounds alternate between the a and b variables, so that
+	// nothing has to be copied between them.
+	src, dst := "a", "b"
+	for r := 0; r < rounds; r++ {
+		fmt.Fprintf(buf, "\n// Round %v.\n", r)
+		for j := 0; j < cols; j++ {
+			c := uint64(j<<4^r) << 56
+			if q {
+				c = ^uint64(j<<4) ^ uint64(r)
+			}
+			if c == 0 {
+				continue
+			}
+			fmt.Fprintf(buf, "%v%v ^= 0x%016x\n", src, j, c)
+		}
+		fmt.Fprintln(buf)
+
+		assign := "="
+		if r == 0 {
+			assign = ":="
+		}
+		writeRound(buf, src, dst, assign, cols, shift, compact)
+		src, dst = dst, src
+	}
+	fmt.Fprintln(buf)
+
+	for j := 0; j < cols; j++ {
+		fmt.Fprintf(buf, "x[%v] = %v%v\n", j, src, j)
+	}
+	fmt.Fprintf(buf, "}\n")
+}
+
+// writeRound writes the SubBytes, ShiftBytes and MixBytes steps of a
+// round, from the state in the variables named src to the ones named
+// dst, which are assigned with assign.
+func writeRound(buf *bytes.Buffer, src, dst, assign string, cols int, shift *[8]int, compact bool) {
+	for j := 0; j < cols; j++ {
+		fmt.Fprintf(buf, "%v%v %v", dst, j, assign)
+		for i := 0; i < 8; i++ {
+			if i > 0 {
+				fmt.Fprintf(buf, " ^")
+			}
+			a := fmt.Sprintf("%v%v", src, (j+shift[i])%cols)
+			switch {
+			case compact && (i == 0):
+				fmt.Fprintf(buf, " t0[%v>>56]", a)
+			case compact && (i == 7):
+				fmt.Fprintf(buf, " bits.RotateLeft64(t0[byte(%v)], -56)", a)
+			case compact:
+				fmt.Fprintf(buf, " bits.RotateLeft64(t0[byte(%v>>%v)], -%v)", a, 56-8*i, 8*i)
+			case i == 0:
+				fmt.Fprintf(buf, " t0[%v>>56]", a)
+			case i == 7:
+				fmt.Fprintf(buf, " t7[byte(%v)]", a)
+			default:
+				fmt.Fprintf(buf, " t%v[byte(%v>>%v)]", i, a, 56-8*i)
+			}
+		}
+		fmt.Fprintln(buf)
+	}
+}
+
+func writeTables(buf *bytes.Buffer, compact bool) {
+	t := tables()
+	if compact {
+		fmt.Fprintf(buf, "\n// t0 combines SubBytes and MixBytes. Entry x is the column that\n")
+		fmt.Fprintf(buf, "// MixBytes produces from S(x) in row 0. Rotating it right by 8k bits\n")
+		fmt.Fprintf(buf, "// gives the column for row k.\n")
+		fmt.Fprintf(buf, "var t0 = [256]uint64{\n")
+		for x := 0; x < len(t[0]); x += 2 {
+			fmt.Fprintf(buf, "0x%016x, 0x%016x,\n", t[0][x], t[0][x+1])
+		}
+		fmt.Fprintf(buf, "}\n")
+		return
+	}
+
+	fmt.Fprintf(buf, "\n// t0 through t7 combine SubBytes and MixBytes. Entry x of tk is the\n")
+	fmt.Fprintf(buf, "// column that MixBytes produces from S(x) in row k.\n")
+	fmt.Fprintf(buf, "var (\n")
+	for k, t := range t {
+		fmt.Fprintf(buf, "t%v = [256]uint64{\n", k)
+		for x := 0; x < len(t); x += 2 {
+			fmt.Fprintf(buf, "0x%016x, 0x%016x,\n", t[x], t[x+1])
+		}
+		fmt.Fprintf(buf, "}\n")
+	}
+	fmt.Fprintf(buf, ")\n")
+}
+
+func main() {
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package name of the generated file")
+	big := flag.Bool("big", false, "generate the permutations for Groestl-384 and Groestl-512")
+	compact := flag.Bool("compact", false, "generate the permutations with a single table")
+	out := flag.String("o", "internal.go", "output file")
+	flag.Parse()
+
+	src, err := generate(*pkg, *big, *compact)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gen: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "gen: %v\n", err)
+		os.Exit(1)
+	}
+}
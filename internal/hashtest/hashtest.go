@@ -0,0 +1,243 @@
+// Package hashtest holds the tests that every hash implementation in
+// this module shares. They check the Digest methods against each other
+// instead of against known answers, which are left to each package.
+package hashtest
+
+import (
+	"bytes"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+	"testing"
+)
+
+// Hash is the set of methods that the Digest of each hash package
+// provides.
+type Hash interface {
+	hash.Hash
+	io.StringWriter
+	io.ByteWriter
+
+	// WriteBits adds the first bits bits of data to the running hash.
+	WriteBits(data []byte, bits uint64)
+
+	Clone() (hash.Cloner, error)
+}
+
+// Seq returns n bytes counting up from zero.
+func Seq(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(i)
+	}
+	return b
+}
+
+// sum returns the digest of in from a new hash.
+func sum(newHash func() Hash, in []byte) []byte {
+	h := newHash()
+	h.Write(in)
+	return h.Sum(nil)
+}
+
+var tests = []struct {
+	name string
+	run  func(t *testing.T, newHash func() Hash)
+}{
+	{name: "SumInterleaved", run: testSumInterleaved},
+	{name: "WriteLength", run: testWriteLength},
+	{name: "WriteBitsUnaligned", run: testWriteBitsUnaligned},
+	{name: "Clone", run: testClone},
+	{name: "WriteStringByte", run: testWriteStringByte},
+	{name: "WriteChunks", run: testWriteChunks},
+}
+
+// Run runs each of the shared tests against hashes returned by
+// newHash, each in its own subtest.
+func Run(t *testing.T, newHash func() Hash) {
+	t.Helper()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t, newHash)
+		})
+	}
+}
+
+func testSumInterleaved(t *testing.T, newHash func() Hash) {
+	in := bytes.Repeat([]byte("The quick brown fox jumps over the lazy dog"), 5)
+	whole := sum(newHash, in)
+
+	for split := 0; split <= len(in); split++ {
+		h := newHash()
+		h.Write(in[:split])
+		first := h.Sum(nil)
+		if want := sum(newHash, in[:split]); !bytes.Equal(first, want) {
+			t.Errorf("Split %v: expected first sum %x", split, want)
+			t.Errorf("Split %v: got %x", split, first)
+		}
+
+		h.Write(in[split:])
+		second := h.Sum(nil)
+		if !bytes.Equal(second, whole) {
+			t.Errorf("Split %v: expected second sum %x", split, whole)
+			t.Errorf("Split %v: got %x", split, second)
+		}
+
+		if again := h.Sum(nil); !bytes.Equal(again, second) {
+			t.Errorf("Split %v: repeated Sum changed from %x to %x", split, second, again)
+		}
+	}
+}
+
+func testWriteLength(t *testing.T, newHash func() Hash) {
+	h := newHash()
+	bs := h.BlockSize()
+	for _, n := range []int{0, 1, bs - 1, bs, 3*bs + 7} {
+		written, err := h.Write(make([]byte, n))
+		if (written != n) || (err != nil) {
+			t.Errorf("Write(%v bytes) = %v, %v", n, written, err)
+		}
+	}
+}
+
+func testWriteBitsUnaligned(t *testing.T, newHash func() Hash) {
+	in := bytes.Repeat([]byte("The quick brown fox jumps over the lazy dog"), 7)
+
+	bitwise := func(h Hash, data []byte, bits uint64) {
+		for i := uint64(0); i < bits; i++ {
+			h.WriteBits([]byte{data[i/8] << (i % 8)}, 1)
+		}
+	}
+
+	for lead := uint64(1); lead < 8; lead++ {
+		h := newHash()
+		h.WriteBits(in, lead)
+		h.Write(in[1:])
+		h.WriteBits([]byte{in[0] << lead}, 8-lead)
+		h.Write(in[1:])
+
+		want := newHash()
+		bitwise(want, in, lead)
+		bitwise(want, in[1:], uint64(len(in)-1)*8)
+		bitwise(want, []byte{in[0] << lead}, 8-lead)
+		bitwise(want, in[1:], uint64(len(in)-1)*8)
+
+		if got, want := h.Sum(nil), want.Sum(nil); !bytes.Equal(got, want) {
+			t.Errorf("Lead %v: expected %x", lead, want)
+			t.Errorf("Lead %v: got %x", lead, got)
+		}
+	}
+
+	// Writing a message in pieces of odd bit lengths must give the same
+	// hash as writing it all at once.
+	want := newHash()
+	want.WriteBits(in, 1003)
+
+	h := newHash()
+	var off uint64
+	for _, n := range []uint64{3, 17, 500, 1, 482} {
+		var buf [80]byte
+		for i := uint64(0); i < n; i++ {
+			bit := (in[(off+i)/8] >> (7 - (off+i)%8)) & 1
+			buf[i/8] |= bit << (7 - i%8)
+		}
+		h.WriteBits(buf[:], n)
+		off += n
+	}
+
+	if got, want := h.Sum(nil), want.Sum(nil); !bytes.Equal(got, want) {
+		t.Errorf("Pieces: expected %x", want)
+		t.Errorf("Pieces: got %x", got)
+	}
+}
+
+func testClone(t *testing.T, newHash func() Hash) {
+	header := bytes.Repeat([]byte("header"), 50)
+	bodies := []string{"", "a", "The quick brown fox jumps over the lazy dog"}
+
+	h := newHash()
+	h.Write(header)
+
+	for _, body := range bodies {
+		c, err := h.Clone()
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.Write([]byte(body))
+
+		want := sum(newHash, append(append([]byte{}, header...), body...))
+		if got := c.Sum(nil); !bytes.Equal(got, want) {
+			t.Errorf("Body %q: expected %x", body, want)
+			t.Errorf("Body %q: got %x", body, got)
+		}
+	}
+
+	if got, want := h.Sum(nil), sum(newHash, header); !bytes.Equal(got, want) {
+		t.Errorf("Clones modified the original: expected %x, got %x", want, got)
+	}
+}
+
+func testWriteStringByte(t *testing.T, newHash func() Hash) {
+	in := strings.Repeat("The quick brown fox jumps over the lazy dog", 7)
+	want := sum(newHash, []byte(in))
+
+	h := newHash()
+	h.WriteString(in[:100])
+	for i := 100; i < 200; i++ {
+		h.WriteByte(in[i])
+	}
+	n, err := h.WriteString(in[200:])
+	if (n != len(in)-200) || (err != nil) {
+		t.Errorf("WriteString = %v, %v", n, err)
+	}
+
+	if got := h.Sum(nil); !bytes.Equal(got, want) {
+		t.Errorf("Expected %x", want)
+		t.Errorf("Got %x", got)
+	}
+
+	allocs := testing.AllocsPerRun(10, func() {
+		h.WriteString(in)
+		h.WriteByte('x')
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, got %v", allocs)
+	}
+}
+
+func testWriteChunks(t *testing.T, newHash func() Hash) {
+	in := Seq(2000)
+	want := sum(newHash, in)
+
+	bs := newHash().BlockSize()
+	for _, chunk := range []int{1, 7, bs - 1, bs, bs + 1, 3 * bs, 1000} {
+		h := newHash()
+		for data := in; len(data) > 0; {
+			n := min(chunk, len(data))
+			h.Write(data[:n])
+			data = data[n:]
+		}
+		if got := h.Sum(nil); !bytes.Equal(got, want) {
+			t.Errorf("Chunk %v: expected %x", chunk, want)
+			t.Errorf("Chunk %v: got %x", chunk, got)
+		}
+	}
+}
+
+// Benchmark measures the speed of writing messages of a few sizes to
+// a hash returned by newHash.
+func Benchmark(b *testing.B, newHash func() hash.Hash) {
+	for _, size := range []int{64, 1 << 10, 64 << 10, 16 << 20} {
+		in := make([]byte, size)
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			h := newHash()
+			b.SetBytes(int64(size))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				h.Write(in)
+			}
+		})
+	}
+}
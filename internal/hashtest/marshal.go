@@ -0,0 +1,166 @@
+package hashtest
+
+import (
+	"bytes"
+	"encoding"
+	"testing"
+)
+
+// State is a Hash whose state can be saved and restored.
+type State interface {
+	Hash
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+
+	AppendBinary(b []byte) ([]byte, error)
+}
+
+// MarshalTest describes the state format of a hash for RunMarshal.
+// Every state ends with the block offset as a big-endian uint64, then
+// the bits of a partially written byte and how many of them there are.
+type MarshalTest struct {
+	// New returns the hash under test, and Other a hash of another
+	// variant of the same function, whose states New must reject.
+	New, Other func() State
+
+	// Zero returns the zero value of the hash, which must accept the
+	// states of every variant.
+	Zero func() State
+
+	// Magic is the identifier at the start of each state. The version
+	// byte follows it.
+	Magic string
+
+	// The errors that UnmarshalBinary returns for each kind of bad
+	// state.
+	ErrIdentifier error
+	ErrVersion    error
+	ErrSize       error
+	ErrVariant    error
+	ErrCorrupt    error
+}
+
+var marshalTests = []struct {
+	name string
+	run  func(t *testing.T, m *MarshalTest)
+}{
+	{name: "MarshalBinary", run: testMarshalBinary},
+	{name: "MarshalBinaryPartial", run: testMarshalBinaryPartial},
+	{name: "AppendBinary", run: testAppendBinary},
+	{name: "UnmarshalBinaryErrors", run: testUnmarshalBinaryErrors},
+}
+
+// RunMarshal runs the shared tests of MarshalBinary, AppendBinary and
+// UnmarshalBinary, each in its own subtest.
+func RunMarshal(t *testing.T, m MarshalTest) {
+	t.Helper()
+
+	for _, test := range marshalTests {
+		t.Run(test.name, func(t *testing.T) {
+			test.run(t, &m)
+		})
+	}
+}
+
+func testMarshalBinary(t *testing.T, m *MarshalTest) {
+	in := bytes.Repeat([]byte("The quick brown fox jumps over the lazy dog"), 7)
+
+	want := m.New()
+	want.Write(in)
+
+	for split := 0; split <= len(in); split += 13 {
+		h := m.New()
+		h.Write(in[:split])
+		state, err := h.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range []State{m.New(), m.Zero()} {
+			err = r.UnmarshalBinary(state)
+			if err != nil {
+				t.Fatalf("Split %v: %v", split, err)
+			}
+			r.Write(in[split:])
+
+			if got, want := r.Sum(nil), want.Sum(nil); !bytes.Equal(got, want) {
+				t.Errorf("Split %v: expected %x", split, want)
+				t.Errorf("Split %v: got %x", split, got)
+			}
+		}
+	}
+}
+
+func testMarshalBinaryPartial(t *testing.T, m *MarshalTest) {
+	h := m.New()
+	h.WriteBits([]byte{0xA5, 0xFF}, 13)
+	state, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := m.Zero()
+	if err := r.UnmarshalBinary(state); err != nil {
+		t.Fatal(err)
+	}
+	r.WriteBits([]byte{0xFF}, 3)
+	h.WriteBits([]byte{0xFF}, 3)
+
+	if got, want := r.Sum(nil), h.Sum(nil); !bytes.Equal(got, want) {
+		t.Errorf("Expected %x", want)
+		t.Errorf("Got %x", got)
+	}
+}
+
+func testAppendBinary(t *testing.T, m *MarshalTest) {
+	h := m.New()
+	h.Write([]byte("abc"))
+
+	state, _ := h.MarshalBinary()
+	appended, err := h.AppendBinary([]byte("prefix"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(appended, append([]byte("prefix"), state...)) {
+		t.Errorf("AppendBinary did not append the marshaled state")
+	}
+}
+
+func testUnmarshalBinaryErrors(t *testing.T, m *MarshalTest) {
+	h := m.New()
+	h.Write([]byte("abc"))
+	state, _ := h.MarshalBinary()
+	otherState, _ := m.Other().MarshalBinary()
+
+	modify := func(i int, v byte) []byte {
+		b := append([]byte{}, state...)
+		b[i] = v
+		return b
+	}
+
+	tests := []struct {
+		name  string
+		state []byte
+		err   error
+	}{
+		{name: "Empty", state: nil, err: m.ErrIdentifier},
+		{name: "Magic", state: modify(0, 'x'), err: m.ErrIdentifier},
+		{name: "Version", state: modify(len(m.Magic), 99), err: m.ErrVersion},
+		{name: "Short", state: state[:len(state)-1], err: m.ErrSize},
+		{name: "Long", state: append(state, 0), err: m.ErrSize},
+		{name: "Variant", state: otherState, err: m.ErrVariant},
+		{name: "Offset", state: modify(len(state)-3, byte(h.BlockSize())), err: m.ErrCorrupt},
+		{name: "Bits", state: modify(len(state)-1, 8), err: m.ErrCorrupt},
+		{name: "Partial", state: modify(len(state)-2, 0x01), err: m.ErrCorrupt},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := m.New()
+			if err := r.UnmarshalBinary(test.state); err != test.err {
+				t.Errorf("Expected %v", test.err)
+				t.Errorf("Got %v", err)
+			}
+		})
+	}
+}
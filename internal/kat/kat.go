@@ -0,0 +1,172 @@
+// Package kat reads the known-answer test files from the NIST SHA-3
+// competition submission packages and runs them against hash
+// implementations in this module.
+//
+// The three file formats are handled: ShortMsgKAT and LongMsgKAT,
+// which contain Len, Msg and MD entries, and ExtremelyLongMsgKAT,
+// which contains Repeat, Text and MD entries describing a message
+// built by repeating Text.
+package kat
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var extreme = flag.Bool("kat.extreme", false, "run ExtremelyLongMsgKAT vectors, which hash 1 GiB of data each")
+
+// Vector is a single known-answer test.
+type Vector struct {
+	// Line is the line of the file that the vector started on.
+	Line int
+
+	// Len is the length of the message in bits.
+	Len uint64
+
+	// Msg is the message, or the text that is repeated to build it.
+	// Only the first Len bits are used when Repeat is zero.
+	Msg []byte
+
+	// Repeat is the number of times Msg is repeated to build the
+	// message. It is zero for vectors that are not from an
+	// ExtremelyLongMsgKAT file.
+	Repeat uint64
+
+	// MD is the expected digest.
+	MD []byte
+}
+
+// Parse reads the vectors from a KAT file.
+func Parse(r io.Reader) ([]Vector, error) {
+	var vectors []Vector
+	var cur Vector
+	var have int
+
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		if (text == "") || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		key, val, ok := strings.Cut(text, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %v: expected key = value", line)
+		}
+		key, val = strings.TrimSpace(key), strings.TrimSpace(val)
+
+		if have == 0 {
+			cur.Line = line
+		}
+
+		var err error
+		switch key {
+		case "Len":
+			cur.Len, err = strconv.ParseUint(val, 10, 64)
+		case "Msg":
+			cur.Msg, err = hex.DecodeString(val)
+		case "Repeat":
+			cur.Repeat, err = strconv.ParseUint(val, 10, 64)
+		case "Text":
+			cur.Msg = []byte(val)
+		case "MD":
+			cur.MD, err = hex.DecodeString(val)
+		default:
+			err = fmt.Errorf("unknown key %q", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %v: %w", line, err)
+		}
+		have++
+
+		if key != "MD" {
+			continue
+		}
+
+		if cur.Repeat != 0 {
+			cur.Len = uint64(len(cur.Msg)) * 8 * cur.Repeat
+		}
+		if (cur.Repeat == 0) && (uint64(len(cur.Msg))*8 < cur.Len) {
+			return nil, fmt.Errorf("line %v: message is shorter than %v bits", cur.Line, cur.Len)
+		}
+
+		vectors = append(vectors, cur)
+		cur, have = Vector{}, 0
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if have != 0 {
+		return nil, fmt.Errorf("line %v: incomplete vector", cur.Line)
+	}
+
+	return vectors, nil
+}
+
+// ParseFile reads the vectors from the KAT file at path.
+func ParseFile(path string) ([]Vector, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return Parse(file)
+}
+
+// BitHash is a hash that can accept messages which are not a whole
+// number of bytes long.
+type BitHash interface {
+	hash.Hash
+
+	// WriteBits adds the first bits bits of data to the running hash.
+	WriteBits(data []byte, bits uint64)
+}
+
+// Run runs every vector in the KAT file at path against hashes
+// returned by newHash, each in its own subtest. Vectors with repeated
+// messages are skipped unless the -kat.extreme flag is given.
+func Run(t *testing.T, path string, newHash func() BitHash) {
+	t.Helper()
+
+	vectors, err := ParseFile(path)
+	if err != nil {
+		t.Fatalf("%v: %v", path, err)
+	}
+	if len(vectors) == 0 {
+		t.Fatalf("%v: no vectors", path)
+	}
+
+	for _, v := range vectors {
+		v := v
+		t.Run(fmt.Sprintf("Len=%v", v.Len), func(t *testing.T) {
+			h := newHash()
+			if v.Repeat != 0 {
+				if !*extreme {
+					t.Skip("skipping without -kat.extreme")
+				}
+				for i := uint64(0); i < v.Repeat; i++ {
+					h.Write(v.Msg)
+				}
+			} else {
+				h.WriteBits(v.Msg, v.Len)
+			}
+
+			got := h.Sum(nil)
+			if !bytes.Equal(got, v.MD) {
+				t.Errorf("%v:%v: Expected %X", path, v.Line, v.MD)
+				t.Errorf("%v:%v: Got %X", path, v.Line, got)
+			}
+		})
+	}
+}
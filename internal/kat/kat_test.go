@@ -0,0 +1,72 @@
+package kat
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	const in = `# ShortMsgKAT_256.txt
+# Algorithm Name: Groestl
+
+Len = 0
+Msg = 00
+MD = 1A52D11D550039BE16107F9C58DB9EBCC417F16F736ADB2502567119F0083467
+
+Len = 3
+Msg = C0
+MD = 0102
+
+Repeat = 2
+Text = abc
+MD = 0304
+`
+
+	vectors, err := Parse(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Vector{
+		{Line: 4, Len: 0, Msg: []byte{0x00}},
+		{Line: 8, Len: 3, Msg: []byte{0xC0}, MD: []byte{0x01, 0x02}},
+		{Line: 12, Len: 48, Msg: []byte("abc"), Repeat: 2, MD: []byte{0x03, 0x04}},
+	}
+	if len(vectors) != len(want) {
+		t.Fatalf("Expected %v vectors, got %v", len(want), len(vectors))
+	}
+	for i, v := range vectors {
+		w := want[i]
+		if (v.Line != w.Line) || (v.Len != w.Len) || (v.Repeat != w.Repeat) || !bytes.Equal(v.Msg, w.Msg) {
+			t.Errorf("Vector %v: expected %+v", i, w)
+			t.Errorf("Vector %v: got %+v", i, v)
+		}
+		if (i > 0) && !bytes.Equal(v.MD, w.MD) {
+			t.Errorf("Vector %v: expected MD %X, got %X", i, w.MD, v.MD)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{name: "NoEquals", in: "Len 8\n"},
+		{name: "UnknownKey", in: "Foo = 1\n"},
+		{name: "BadLen", in: "Len = x\nMsg = 00\nMD = 00\n"},
+		{name: "BadHex", in: "Len = 8\nMsg = zz\nMD = 00\n"},
+		{name: "ShortMsg", in: "Len = 16\nMsg = 00\nMD = 00\n"},
+		{name: "Incomplete", in: "Len = 8\nMsg = 00\n"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(test.in)); err == nil {
+				t.Errorf("Expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Package partial handles messages that are not a whole number of
+// bytes long for the hash implementations in this module.
+//
+// Each Digest has a WriteBits method that can leave part of a byte
+// behind. Every byte written after that straddles two bytes of the
+// message, so it has to be shifted before the hash function sees it.
+// A Byte keeps track of the partial byte and does that shifting, while
+// the Digest keeps its own buffering and compression.
+package partial
+
+// A Byte is the leading bits of a message byte that has only been
+// partially written. The zero value has no bits in it.
+type Byte struct {
+	// Bits holds the N bits that have been written at its most
+	// significant end. The rest of it is zero.
+	Bits byte
+	N    uint
+}
+
+// Next splits off the next chunk of data to be hashed and returns it
+// along with the rest of data. Without a partial byte, the chunk is
+// all of data. Otherwise, as much of data as fits is shifted through
+// the partial byte into buf, and the chunk is that part of buf.
+func (p *Byte) Next(buf, data []byte) (chunk, rest []byte) {
+	if p.N == 0 {
+		return data, nil
+	}
+
+	c := copy(buf, data)
+	p.shift(buf[:c])
+	return buf[:c], data[c:]
+}
+
+// NextString is like Next for a string, except that the chunk always
+// comes from buf.
+func (p *Byte) NextString(buf []byte, s string) (chunk []byte, rest string) {
+	c := copy(buf, s)
+	p.shift(buf[:c])
+	return buf[:c], s[c:]
+}
+
+func (p *Byte) shift(buf []byte) {
+	if p.N == 0 {
+		return
+	}
+
+	for i, b := range buf {
+		buf[i] = p.Bits | (b >> p.N)
+		p.Bits = b << (8 - p.N)
+	}
+}
+
+// Add appends the leading n bits of c, where n is less than 8, to the
+// partial byte. If that fills it, Add returns the full byte, to be
+// hashed, and true, and the partial byte keeps the bits of c that were
+// left over.
+func (p *Byte) Add(c byte, n uint) (full byte, ok bool) {
+	c &^= 0xFF >> n
+	p.Bits |= c >> p.N
+	p.N += n
+	if p.N < 8 {
+		return 0, false
+	}
+
+	full = p.Bits
+	p.N -= 8
+	p.Bits = c << (n - p.N)
+	return full, true
+}
+
+// Padded returns the partial byte followed by a single 1 bit, which is
+// how the padding of most of the hash functions starts.
+func (p *Byte) Padded() byte {
+	return p.Bits | (0x80 >> p.N)
+}
+
+// Valid reports whether p is a partial byte that could have been
+// produced by Add. It is used to check unmarshaled state.
+func (p *Byte) Valid() bool {
+	return (p.N < 8) && (p.Bits&(0xFF>>p.N) == 0)
+}
@@ -0,0 +1,105 @@
+package partial
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+// bitString returns the first bits bits of data as a string of 0s and
+// 1s.
+func bitString(data []byte, bits int) string {
+	s := make([]byte, bits)
+	for i := range s {
+		s[i] = '0' + (data[i/8]>>(7-i%8))&1
+	}
+	return string(s)
+}
+
+// TestSplit writes random pieces of a message, some of them not a
+// whole number of bytes long, and checks that the bytes that come out
+// are the message.
+func TestSplit(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 100 {
+		var p Byte
+		var want, got []byte
+		var buf [7]byte
+		for range 20 {
+			data := make([]byte, r.IntN(20))
+			for i := range data {
+				data[i] = byte(r.Uint32())
+			}
+			bits := 8 * len(data)
+			if (len(data) > 0) && (r.IntN(2) == 0) {
+				bits -= 1 + r.IntN(7)
+			}
+			want = append(want, bitString(data, bits)...)
+
+			rest := data[:bits/8]
+			if r.IntN(2) == 0 {
+				s := string(rest)
+				for len(s) > 0 {
+					var chunk []byte
+					chunk, s = p.NextString(buf[:], s)
+					got = append(got, chunk...)
+				}
+			} else {
+				for len(rest) > 0 {
+					var chunk []byte
+					chunk, rest = p.Next(buf[:], rest)
+					got = append(got, chunk...)
+				}
+			}
+
+			if rem := uint(bits % 8); rem != 0 {
+				if full, ok := p.Add(data[bits/8], rem); ok {
+					got = append(got, full)
+				}
+				if !p.Valid() {
+					t.Fatalf("Invalid partial byte %08b with %v bits", p.Bits, p.N)
+				}
+			}
+		}
+
+		if g := bitString(got, 8*len(got)) + bitString([]byte{p.Bits}, int(p.N)); g != string(want) {
+			t.Fatalf("Expected %v\nGot %v", string(want), g)
+		}
+	}
+}
+
+func TestNextAligned(t *testing.T) {
+	var p Byte
+	data := []byte("abc")
+	chunk, rest := p.Next(nil, data)
+	if (&chunk[0] != &data[0]) || (len(chunk) != len(data)) || (len(rest) != 0) {
+		t.Errorf("Aligned data was not passed through")
+	}
+}
+
+func TestPadded(t *testing.T) {
+	tests := []struct {
+		p    Byte
+		want byte
+	}{
+		{p: Byte{}, want: 0x80},
+		{p: Byte{Bits: 0x80, N: 1}, want: 0xC0},
+		{p: Byte{Bits: 0xFE, N: 7}, want: 0xFF},
+		{p: Byte{Bits: 0xA0, N: 4}, want: 0xA8},
+	}
+	for _, test := range tests {
+		if got := test.p.Padded(); got != test.want {
+			t.Errorf("%08b with %v bits: expected %08b, got %08b", test.p.Bits, test.p.N, test.want, got)
+		}
+	}
+}
+
+func TestValid(t *testing.T) {
+	for _, p := range []Byte{{Bits: 0, N: 8}, {Bits: 0x40, N: 1}, {Bits: 0x01, N: 7}} {
+		if p.Valid() {
+			t.Errorf("%08b with %v bits is valid", p.Bits, p.N)
+		}
+	}
+	if p := (Byte{Bits: 0xC0, N: 2}); !p.Valid() {
+		t.Errorf("Valid partial byte is invalid")
+	}
+}
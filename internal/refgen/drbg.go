@@ -0,0 +1,216 @@
+package main
+
+import (
+	"crypto/hmac"
+	"encoding/binary"
+	"fmt"
+	"hash"
+)
+
+// drbg is a DRBG mechanism from NIST SP 800-90A Rev. 1.
+type drbg interface {
+	reseed(entropy, additional []byte)
+	generate(n int, additional []byte) []byte
+}
+
+// hashDRBG is Hash_DRBG, section 10.1.1.
+type hashDRBG struct {
+	h       func() hash.Hash
+	seedlen int // in bytes
+	v, c    []byte
+	counter uint64
+}
+
+func newHashDRBG(h func() hash.Hash, entropy, nonce, personalization []byte) *hashDRBG {
+	// Table 2 gives a seedlen of 440 bits for outputs of up to 256 bits
+	// and 888 bits beyond that.
+	d := &hashDRBG{h: h, seedlen: 55}
+	if h().Size() > 32 {
+		d.seedlen = 111
+	}
+	d.v = d.df(entropy, nonce, personalization)
+	d.c = d.df([]byte{0}, d.v)
+	d.counter = 1
+	return d
+}
+
+func (d *hashDRBG) hash(in ...[]byte) []byte {
+	h := d.h()
+	for _, b := range in {
+		h.Write(b)
+	}
+	return h.Sum(nil)
+}
+
+// df is Hash_df of the concatenation of in, section 10.3.1.
+func (d *hashDRBG) df(in ...[]byte) []byte {
+	var out []byte
+	for counter := byte(1); len(out) < d.seedlen; counter++ {
+		prefix := binary.BigEndian.AppendUint32([]byte{counter}, uint32(8*d.seedlen))
+		out = append(out, d.hash(append([][]byte{prefix}, in...)...)...)
+	}
+	return out[:d.seedlen]
+}
+
+// add sets v to v + x modulo 2^(8*len(v)), with x right-aligned.
+func add(v, x []byte) {
+	var carry uint
+	for i, j := len(v)-1, len(x)-1; i >= 0; i, j = i-1, j-1 {
+		sum := uint(v[i]) + carry
+		if j >= 0 {
+			sum += uint(x[j])
+		}
+		v[i], carry = byte(sum), sum>>8
+	}
+}
+
+func (d *hashDRBG) reseed(entropy, additional []byte) {
+	d.v = d.df([]byte{1}, d.v, entropy, additional)
+	d.c = d.df([]byte{0}, d.v)
+	d.counter = 1
+}
+
+func (d *hashDRBG) generate(n int, additional []byte) []byte {
+	if len(additional) != 0 {
+		add(d.v, d.hash([]byte{2}, d.v, additional))
+	}
+
+	// Hashgen.
+	var out []byte
+	data := append([]byte(nil), d.v...)
+	for len(out) < n {
+		out = append(out, d.hash(data)...)
+		add(data, []byte{1})
+	}
+
+	add(d.v, d.hash([]byte{3}, d.v))
+	add(d.v, d.c)
+	add(d.v, binary.BigEndian.AppendUint64(nil, d.counter))
+	d.counter++
+	return out[:n]
+}
+
+// hmacDRBG is HMAC_DRBG, section 10.1.2.
+type hmacDRBG struct {
+	h       func() hash.Hash
+	k, v    []byte
+	counter uint64
+}
+
+func newHMACDRBG(h func() hash.Hash, entropy, nonce, personalization []byte) *hmacDRBG {
+	size := h().Size()
+	d := &hmacDRBG{h: h, k: make([]byte, size), v: make([]byte, size)}
+	for i := range d.v {
+		d.v[i] = 1
+	}
+	d.update(entropy, nonce, personalization)
+	d.counter = 1
+	return d
+}
+
+func (d *hmacDRBG) mac(in ...[]byte) []byte {
+	m := hmac.New(d.h, d.k)
+	for _, b := range in {
+		m.Write(b)
+	}
+	return m.Sum(nil)
+}
+
+// update is HMAC_DRBG_Update with the concatenation of provided.
+func (d *hmacDRBG) update(provided ...[]byte) {
+	d.k = d.mac(append([][]byte{d.v, {0}}, provided...)...)
+	d.v = d.mac(d.v)
+
+	var n int
+	for _, b := range provided {
+		n += len(b)
+	}
+	if n == 0 {
+		return
+	}
+
+	d.k = d.mac(append([][]byte{d.v, {1}}, provided...)...)
+	d.v = d.mac(d.v)
+}
+
+func (d *hmacDRBG) reseed(entropy, additional []byte) {
+	d.update(entropy, additional)
+	d.counter = 1
+}
+
+func (d *hmacDRBG) generate(n int, additional []byte) []byte {
+	if len(additional) != 0 {
+		d.update(additional)
+	}
+
+	var out []byte
+	for len(out) < n {
+		d.v = d.mac(d.v)
+		out = append(out, d.v...)
+	}
+
+	d.update(additional)
+	d.counter++
+	return out[:n]
+}
+
+// pattern returns n bytes of the test pattern of the drbg package.
+func pattern(seed byte, n int) []byte {
+	p := make([]byte, n)
+	for i := range p {
+		p[i] = seed*7 + byte(i)*13
+	}
+	return p
+}
+
+// drbgCommand prints the expected outputs of the drbg tests. Each
+// makes two requests of four digests' worth of output and prints the
+// second. With prediction resistance, each request first reseeds and
+// then generates without additional input, as in section 9.3.1.
+func drbgCommand(args []string) error {
+	mechs := []struct {
+		name string
+		new  func(h func() hash.Hash, entropy, nonce, personalization []byte) drbg
+	}{
+		{"Hash", func(h func() hash.Hash, entropy, nonce, personalization []byte) drbg {
+			return newHashDRBG(h, entropy, nonce, personalization)
+		}},
+		{"HMAC", func(h func() hash.Hash, entropy, nonce, personalization []byte) drbg {
+			return newHMACDRBG(h, entropy, nonce, personalization)
+		}},
+	}
+
+	for _, size := range groestlSizes {
+		// The security strength is 192 bits for Groestl-224 and 256
+		// bits for the others.
+		strength := 32
+		if size == 224 {
+			strength = 24
+		}
+
+		for _, mech := range mechs {
+			for _, variant := range []string{"NoReseed", "Reseed", "PR"} {
+				var personalization []byte
+				if variant != "NoReseed" {
+					personalization = pattern(3, strength)
+				}
+
+				d := mech.new(newGroestlHash(size), pattern(1, strength), pattern(2, strength/2), personalization)
+				if variant == "Reseed" {
+					d.reseed(pattern(4, strength), pattern(5, strength))
+				}
+
+				var out []byte
+				for i, add := range [][]byte{pattern(6, strength), pattern(7, strength)} {
+					if variant == "PR" {
+						d.reseed(pattern(byte(8+i), strength), add)
+						add = nil
+					}
+					out = d.generate(size/2, add)
+				}
+				fmt.Printf("Groestl%v %v %v: %x\n", size, mech.name, variant, out)
+			}
+		}
+	}
+	return nil
+}
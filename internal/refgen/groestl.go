@@ -0,0 +1,198 @@
+package main
+
+import "encoding/binary"
+
+// aesSbox is the AES S-box as printed in FIPS 197, figure 7, which
+// Groestl uses for SubBytes.
+var aesSbox = [256]byte{
+	0x63, 0x7c, 0x77, 0x7b, 0xf2, 0x6b, 0x6f, 0xc5, 0x30, 0x01, 0x67, 0x2b, 0xfe, 0xd7, 0xab, 0x76,
+	0xca, 0x82, 0xc9, 0x7d, 0xfa, 0x59, 0x47, 0xf0, 0xad, 0xd4, 0xa2, 0xaf, 0x9c, 0xa4, 0x72, 0xc0,
+	0xb7, 0xfd, 0x93, 0x26, 0x36, 0x3f, 0xf7, 0xcc, 0x34, 0xa5, 0xe5, 0xf1, 0x71, 0xd8, 0x31, 0x15,
+	0x04, 0xc7, 0x23, 0xc3, 0x18, 0x96, 0x05, 0x9a, 0x07, 0x12, 0x80, 0xe2, 0xeb, 0x27, 0xb2, 0x75,
+	0x09, 0x83, 0x2c, 0x1a, 0x1b, 0x6e, 0x5a, 0xa0, 0x52, 0x3b, 0xd6, 0xb3, 0x29, 0xe3, 0x2f, 0x84,
+	0x53, 0xd1, 0x00, 0xed, 0x20, 0xfc, 0xb1, 0x5b, 0x6a, 0xcb, 0xbe, 0x39, 0x4a, 0x4c, 0x58, 0xcf,
+	0xd0, 0xef, 0xaa, 0xfb, 0x43, 0x4d, 0x33, 0x85, 0x45, 0xf9, 0x02, 0x7f, 0x50, 0x3c, 0x9f, 0xa8,
+	0x51, 0xa3, 0x40, 0x8f, 0x92, 0x9d, 0x38, 0xf5, 0xbc, 0xb6, 0xda, 0x21, 0x10, 0xff, 0xf3, 0xd2,
+	0xcd, 0x0c, 0x13, 0xec, 0x5f, 0x97, 0x44, 0x17, 0xc4, 0xa7, 0x7e, 0x3d, 0x64, 0x5d, 0x19, 0x73,
+	0x60, 0x81, 0x4f, 0xdc, 0x22, 0x2a, 0x90, 0x88, 0x46, 0xee, 0xb8, 0x14, 0xde, 0x5e, 0x0b, 0xdb,
+	0xe0, 0x32, 0x3a, 0x0a, 0x49, 0x06, 0x24, 0x5c, 0xc2, 0xd3, 0xac, 0x62, 0x91, 0x95, 0xe4, 0x79,
+	0xe7, 0xc8, 0x37, 0x6d, 0x8d, 0xd5, 0x4e, 0xa9, 0x6c, 0x56, 0xf4, 0xea, 0x65, 0x7a, 0xae, 0x08,
+	0xba, 0x78, 0x25, 0x2e, 0x1c, 0xa6, 0xb4, 0xc6, 0xe8, 0xdd, 0x74, 0x1f, 0x4b, 0xbd, 0x8b, 0x8a,
+	0x70, 0x3e, 0xb5, 0x66, 0x48, 0x03, 0xf6, 0x0e, 0x61, 0x35, 0x57, 0xb9, 0x86, 0xc1, 0x1d, 0x9e,
+	0xe1, 0xf8, 0x98, 0x11, 0x69, 0xd9, 0x8e, 0x94, 0x9b, 0x1e, 0x87, 0xe9, 0xce, 0x55, 0x28, 0xdf,
+	0x8c, 0xa1, 0x89, 0x0d, 0xbf, 0xe6, 0x42, 0x68, 0x41, 0x99, 0x2d, 0x0f, 0xb0, 0x54, 0xbb, 0x16,
+}
+
+// gfMul multiplies a and b in GF(2^8) modulo x^8 + x^4 + x^3 + x + 1.
+func gfMul(a, b byte) (p byte) {
+	for ; b != 0; b >>= 1 {
+		if b&1 != 0 {
+			p ^= a
+		}
+		a = a<<1 ^ 0x1b*(a>>7)
+	}
+	return p
+}
+
+// gfTable holds the products of every byte with the coefficients of
+// the MixBytes matrix, so that hashing a gigabyte does not take all
+// day.
+var gfTable = func() (t [8][256]byte) {
+	for c := range t {
+		for x := range t[c] {
+			t[c][x] = gfMul(byte(c), byte(x))
+		}
+	}
+	return t
+}()
+
+// groestlB is the first row of the MixBytes matrix B. Row i is the
+// first row rotated right by i.
+var groestlB = [8]byte{2, 2, 3, 4, 5, 3, 5, 7}
+
+// groestlState is the Groestl state as a matrix of 8 rows and 8 or 16
+// columns.
+type groestlState [8][16]byte
+
+// groestl is Groestl as specified in "Grøstl – a SHA-3 candidate",
+// version 2.0.2, which is the round-3 specification.
+type groestl struct {
+	size   int // digest size in bits
+	cols   int
+	rounds int
+	h      groestlState
+	buf    []byte
+	blocks uint64
+}
+
+func newGroestl(size int) *groestl {
+	g := &groestl{size: size, cols: 8, rounds: 10}
+	if size > 256 {
+		g.cols, g.rounds = 16, 14
+	}
+
+	var iv [8]byte
+	binary.BigEndian.PutUint64(iv[:], uint64(size))
+	g.h.load(iv[:], g.cols-1)
+	return g
+}
+
+// load maps the bytes of b into the state column by column, starting
+// at column col.
+func (x *groestlState) load(b []byte, col int) {
+	for i, c := range b {
+		x[i%8][col+i/8] ^= c
+	}
+}
+
+// shifts are the ShiftBytes amounts of each row for P and Q in
+// Groestl-224/256 and Groestl-384/512.
+var (
+	shiftP512  = [8]int{0, 1, 2, 3, 4, 5, 6, 7}
+	shiftQ512  = [8]int{1, 3, 5, 7, 0, 2, 4, 6}
+	shiftP1024 = [8]int{0, 1, 2, 3, 4, 5, 6, 11}
+	shiftQ1024 = [8]int{1, 3, 5, 11, 0, 2, 4, 6}
+)
+
+// perm applies P, or Q if q is set, to x.
+func (g *groestl) perm(x *groestlState, q bool) {
+	shift := &shiftP512
+	switch {
+	case (g.cols == 8) && q:
+		shift = &shiftQ512
+	case (g.cols == 16) && !q:
+		shift = &shiftP1024
+	case g.cols == 16:
+		shift = &shiftQ1024
+	}
+
+	for r := 0; r < g.rounds; r++ {
+		// AddRoundConstant.
+		for j := 0; j < g.cols; j++ {
+			c := byte(j<<4 ^ r)
+			if !q {
+				x[0][j] ^= c
+				continue
+			}
+			for i := 0; i < 7; i++ {
+				x[i][j] ^= 0xff
+			}
+			x[7][j] ^= 0xff ^ c
+		}
+
+		// SubBytes and ShiftBytes.
+		var y groestlState
+		for i := 0; i < 8; i++ {
+			for j := 0; j < g.cols; j++ {
+				y[i][j] = aesSbox[x[i][(j+shift[i])%g.cols]]
+			}
+		}
+
+		// MixBytes.
+		for j := 0; j < g.cols; j++ {
+			for i := 0; i < 8; i++ {
+				var c byte
+				for k := 0; k < 8; k++ {
+					c ^= gfTable[groestlB[(k-i+8)%8]][y[k][j]]
+				}
+				x[i][j] = c
+			}
+		}
+	}
+}
+
+// compress computes f(h, m) = P(h ^ m) ^ Q(m) ^ h.
+func (g *groestl) compress(m []byte) {
+	var p, q groestlState
+	q.load(m, 0)
+	p = g.h
+	p.load(m, 0)
+	g.perm(&p, false)
+	g.perm(&q, true)
+	for i := range g.h {
+		for j := 0; j < g.cols; j++ {
+			g.h[i][j] ^= p[i][j] ^ q[i][j]
+		}
+	}
+	g.blocks++
+}
+
+func (g *groestl) Write(p []byte) {
+	n := 8 * g.cols
+	g.buf = append(g.buf, p...)
+	for len(g.buf) >= n {
+		g.compress(g.buf[:n])
+		g.buf = g.buf[n:]
+	}
+	g.buf = append([]byte(nil), g.buf...)
+}
+
+// SumBits returns the digest of the message written so far followed by
+// the n most significant bits of last.
+func (g *groestl) SumBits(last byte, n int) []byte {
+	l := 8 * g.cols
+
+	// A one bit, then zeros up to 64 bits short of a block, then the
+	// number of blocks.
+	tail := append([]byte(nil), g.buf...)
+	tail = append(tail, last&^(0xff>>n)|0x80>>n)
+	for len(tail)%l != l-8 {
+		tail = append(tail, 0)
+	}
+	tail = binary.BigEndian.AppendUint64(tail, g.blocks+uint64(len(tail)+8)/uint64(l))
+	for len(tail) > 0 {
+		g.compress(tail[:l])
+		tail = tail[l:]
+	}
+
+	// The output transformation, truncated to the last size bits.
+	x := g.h
+	g.perm(&x, false)
+	out := make([]byte, 0, l)
+	for j := 0; j < g.cols; j++ {
+		for i := 0; i < 8; i++ {
+			out = append(out, x[i][j]^g.h[i][j])
+		}
+	}
+	return out[l-(g.size+7)/8:]
+}
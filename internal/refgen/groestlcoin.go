@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58Check encodes version and payload followed by the first four
+// bytes of their double Groestl-512 hash, which Groestlcoin uses in
+// place of Bitcoin's double SHA-256, in Base58.
+func base58Check(version byte, payload []byte) string {
+	data := append([]byte{version}, payload...)
+	g := newGroestl(512)
+	g.Write(data)
+	first := g.SumBits(0, 0)
+	g = newGroestl(512)
+	g.Write(first)
+	data = append(data, g.SumBits(0, 0)[:4]...)
+
+	// Every leading zero byte is written as the first digit, and the
+	// rest of the data as a big-endian number.
+	var s []byte
+	n := new(big.Int).SetBytes(data)
+	mod := new(big.Int)
+	for n.Sign() > 0 {
+		n.DivMod(n, big.NewInt(58), mod)
+		s = append(s, base58Alphabet[mod.Int64()])
+	}
+	for _, c := range data {
+		if c != 0 {
+			break
+		}
+		s = append(s, base58Alphabet[0])
+	}
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+	return string(s)
+}
+
+// groestlcoinCommand prints the expected addresses and WIF private keys
+// of the groestlcoin tests. The private key is the one in the Bitcoin
+// wiki's example of the WIF encoding.
+func groestlcoinCommand(args []string) error {
+	unhex := func(s string) []byte {
+		b, _ := hex.DecodeString(s)
+		return b
+	}
+	pkHash := unhex("751e76e8199196d454941c45d1b3a323f1433bd6")
+	scriptHash := unhex("bfb2a3c2c7a4ed1e9b1b66e7a8d2f6c3e5d4a1b0")
+	privateKey := unhex("0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d")
+
+	nets := []struct {
+		name                   string
+		pubKey, script, secret byte
+	}{
+		{"MainNet", 36, 5, 128},
+		{"TestNet", 111, 196, 239},
+	}
+
+	for _, net := range nets {
+		fmt.Printf("%v PubKeyHash: %v\n", net.name, base58Check(net.pubKey, pkHash))
+		fmt.Printf("%v ScriptHash: %v\n", net.name, base58Check(net.script, scriptHash))
+		fmt.Printf("%v WIF: %v\n", net.name, base58Check(net.secret, privateKey))
+		fmt.Printf("%v compressed WIF: %v\n", net.name, base58Check(net.secret, append(privateKey, 1)))
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package main
+
+import "encoding/binary"
+
+// The two JH S-boxes. The round constant bit of each element picks one
+// of them.
+var (
+	jhS0 = [16]byte{9, 0, 4, 11, 13, 12, 3, 15, 1, 10, 2, 6, 7, 5, 8, 14}
+	jhS1 = [16]byte{3, 12, 6, 13, 5, 7, 1, 9, 15, 2, 0, 4, 11, 10, 14, 8}
+)
+
+// jhC0 is the first round constant of E8, the integer part of
+// (sqrt(2)-1) * 2^256.
+var jhC0 = [32]byte{
+	0x6a, 0x09, 0xe6, 0x67, 0xf3, 0xbc, 0xc9, 0x08, 0xb2, 0xfb, 0x13, 0x66, 0xea, 0x95, 0x7d, 0x3e,
+	0x3a, 0xde, 0xc1, 0x75, 0x12, 0x77, 0x50, 0x99, 0xda, 0x2f, 0x59, 0x0b, 0x06, 0x67, 0x32, 0x2a,
+}
+
+// bit returns bit i of b, counting from the most significant bit of
+// b[0].
+func bit(b []byte, i int) byte {
+	return b[i/8] >> (7 - i%8) & 1
+}
+
+// setBit sets bit i of b, counting as bit does, to v.
+func setBit(b []byte, i int, v byte) {
+	b[i/8] |= v << (7 - i%8)
+}
+
+// jhLinear is the linear transformation L of two 4-bit elements, whose
+// bit 0 is the most significant.
+func jhLinear(a, b byte) (c, d byte) {
+	a0, a1, a2, a3 := a>>3&1, a>>2&1, a>>1&1, a&1
+	b0, b1, b2, b3 := b>>3&1, b>>2&1, b>>1&1, b&1
+
+	d0 := b0 ^ a1
+	d1 := b1 ^ a2
+	d2 := b2 ^ a3 ^ a0
+	d3 := b3 ^ a0
+	c0 := a0 ^ d1
+	c1 := a1 ^ d2
+	c2 := a2 ^ d3 ^ d0
+	c3 := a3 ^ d0
+
+	return c0<<3 | c1<<2 | c2<<1 | c3, d0<<3 | d1<<2 | d2<<1 | d3
+}
+
+// jhRound is the round function R_d on the 2^d 4-bit elements of a,
+// with c holding the 2^d round constant bits.
+func jhRound(a []byte, c []byte) {
+	n := len(a)
+
+	// S-boxes and the linear transformation.
+	v := make([]byte, n)
+	for i := range v {
+		if bit(c, i) == 0 {
+			v[i] = jhS0[a[i]]
+		} else {
+			v[i] = jhS1[a[i]]
+		}
+	}
+	for i := 0; i < n; i += 2 {
+		v[i], v[i+1] = jhLinear(v[i], v[i+1])
+	}
+
+	// The permutation P_d is pi_d, then P'_d, then phi_d.
+	for i := 0; i < n; i += 4 {
+		v[i+2], v[i+3] = v[i+3], v[i+2]
+	}
+	for i := 0; i < n/2; i++ {
+		a[i], a[i+n/2] = v[2*i], v[2*i+1]
+	}
+	for i := n / 2; i < n; i += 2 {
+		a[i], a[i+1] = a[i+1], a[i]
+	}
+}
+
+// jhE8 is the bijective function E8 on the 1024-bit h.
+func jhE8(h *[128]byte) {
+	// Grouping: element 2i holds bits i, i+256, i+512 and i+768 and
+	// element 2i+1 the bits 128 further on.
+	var q [256]byte
+	for i := 0; i < 128; i++ {
+		for j := 0; j < 4; j++ {
+			q[2*i] = q[2*i]<<1 | bit(h[:], i+256*j)
+			q[2*i+1] = q[2*i+1]<<1 | bit(h[:], i+128+256*j)
+		}
+	}
+
+	// Each round constant is the last one after R6 with zero
+	// constants, on its bits taken 4 at a time.
+	c := jhC0
+	for range 42 {
+		jhRound(q[:], c[:])
+
+		var e [64]byte
+		for i := range e {
+			e[i] = c[i/2] >> (4 - 4*(i%2)) & 0xf
+		}
+		jhRound(e[:], make([]byte, 8))
+		for i := range c {
+			c[i] = e[2*i]<<4 | e[2*i+1]
+		}
+	}
+
+	// Degrouping.
+	*h = [128]byte{}
+	for i := 0; i < 128; i++ {
+		for j := 0; j < 4; j++ {
+			setBit(h[:], i+256*j, q[2*i]>>(3-j)&1)
+			setBit(h[:], i+128+256*j, q[2*i+1]>>(3-j)&1)
+		}
+	}
+}
+
+// jh is JH as specified in "The Hash Function JH", the round-3
+// specification of January 2011.
+type jh struct {
+	size int // digest size in bits
+	h    [128]byte
+	buf  []byte
+	bits uint64
+}
+
+func newJH(size int) *jh {
+	j := &jh{size: size}
+	binary.BigEndian.PutUint16(j.h[:], uint16(size))
+	j.compress(make([]byte, 64))
+	return j
+}
+
+// compress computes F8(h, m), which mixes m into the first half of h
+// before E8 and into the second half after it.
+func (j *jh) compress(m []byte) {
+	for i, c := range m {
+		j.h[i] ^= c
+	}
+	jhE8(&j.h)
+	for i, c := range m {
+		j.h[64+i] ^= c
+	}
+}
+
+func (j *jh) Write(p []byte) {
+	j.bits += 8 * uint64(len(p))
+	j.buf = append(j.buf, p...)
+	for len(j.buf) >= 64 {
+		j.compress(j.buf[:64])
+		j.buf = j.buf[64:]
+	}
+	j.buf = append([]byte(nil), j.buf...)
+}
+
+// SumBits returns the digest of the message written so far followed by
+// the n most significant bits of last.
+func (j *jh) SumBits(last byte, n int) []byte {
+	// A one bit, then 383 zeros and as many more as it takes to end
+	// on a block boundary, then the length of the message in bits.
+	l := 64
+	if (len(j.buf) != 0) || (n != 0) {
+		l = 128
+	}
+	tail := append([]byte(nil), j.buf...)
+	tail = append(tail, last&^(0xff>>n)|0x80>>n)
+	for len(tail) < l-8 {
+		tail = append(tail, 0)
+	}
+	tail = binary.BigEndian.AppendUint64(tail, j.bits+uint64(n))
+	for len(tail) > 0 {
+		j.compress(tail[:64])
+		tail = tail[64:]
+	}
+
+	return j.h[128-j.size/8:]
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+func katCommand(args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("usage: refgen kat hash file...")
+	}
+
+	newHash, ok := hashes[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown hash %q", args[0])
+	}
+
+	for _, path := range args[1:] {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		data, err = rewriteKAT(data, newHash)
+		if err != nil {
+			return fmt.Errorf("%v: %w", path, err)
+		}
+
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewriteKAT returns the KAT file data with every MD entry replaced by
+// the digest that newHash computes for the message before it.
+func rewriteKAT(data []byte, newHash func() bitHash) ([]byte, error) {
+	var out bytes.Buffer
+	var bits, repeat uint64
+	var msg []byte
+
+	lines := strings.SplitAfter(string(data), "\n")
+	for i, line := range lines {
+		key, val, ok := strings.Cut(strings.TrimSpace(line), " = ")
+		if !ok || strings.HasPrefix(key, "#") {
+			out.WriteString(line)
+			continue
+		}
+
+		var err error
+		switch key {
+		case "Len":
+			bits, err = strconv.ParseUint(val, 10, 64)
+		case "Msg":
+			msg, err = hex.DecodeString(val)
+		case "Repeat":
+			repeat, err = strconv.ParseUint(val, 10, 64)
+		case "Text":
+			msg = []byte(val)
+		case "MD":
+			h := newHash()
+			var md []byte
+			if repeat != 0 {
+				for range repeat {
+					h.Write(msg)
+				}
+				md = h.SumBits(0, 0)
+			} else {
+				if uint64(len(msg))*8 < bits {
+					return nil, fmt.Errorf("line %v: message is shorter than %v bits", i+1, bits)
+				}
+				h.Write(msg[:bits/8])
+				var last byte
+				if bits%8 != 0 {
+					last = msg[bits/8]
+				}
+				md = h.SumBits(last, int(bits%8))
+			}
+			line = strings.Replace(line, val, strings.ToUpper(hex.EncodeToString(md)), 1)
+			bits, repeat, msg = 0, 0, nil
+		default:
+			err = fmt.Errorf("unknown key %q", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %v: %w", i+1, err)
+		}
+		out.WriteString(line)
+	}
+
+	return out.Bytes(), nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+)
+
+// myriadGroestl returns SHA-256 of the Groestl-512 hash of data.
+func myriadGroestl(data []byte) []byte {
+	g := newGroestl(512)
+	g.Write(data)
+	sum := sha256.Sum256(g.SumBits(0, 0))
+	return sum[:]
+}
+
+// reversed returns the hex encoding of b with its bytes reversed, as
+// block explorers show hashes.
+func reversed(b []byte) string {
+	b = slices.Clone(b)
+	slices.Reverse(b)
+	return hex.EncodeToString(b)
+}
+
+// myrgroestlCommand prints the expected hashes of the myrgroestl sum
+// tests, and the block hash and Myriad-Groestl hash of each header in
+// the header files given as arguments. For headers mined with
+// Myriad-Groestl, it also prints whether the hash meets the target.
+func myrgroestlCommand(args []string) error {
+	for _, msg := range [][]byte{nil, []byte("abc"), seq(0, 200)} {
+		fmt.Printf("Sum(%q): %x\n", msg, myriadGroestl(msg))
+	}
+
+	for _, path := range args {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		var height string
+		s := bufio.NewScanner(file)
+		for s.Scan() {
+			key, val, ok := strings.Cut(s.Text(), " = ")
+			if key == "Height" {
+				height = val
+			}
+			if !ok || (key != "Header") {
+				continue
+			}
+
+			header, err := hex.DecodeString(val)
+			if err != nil {
+				return err
+			}
+			first := sha256.Sum256(header)
+			hash := sha256.Sum256(first[:])
+			pow := myriadGroestl(header)
+			fmt.Printf("Block %v: Hash %v PoW %v", height, reversed(hash[:]), reversed(pow))
+
+			// Myriad-Groestl is algorithm 2 in bits 9 to 11 of the
+			// version. The target is encoded in the bits field as a
+			// mantissa of three bytes and an exponent of one.
+			if (binary.LittleEndian.Uint32(header)>>9)&7 == 2 {
+				bits := binary.LittleEndian.Uint32(header[72:])
+				target := big.NewInt(int64(bits & 0x7fffff))
+				if exp := int(bits >> 24); exp > 3 {
+					target.Lsh(target, uint(8*(exp-3)))
+				} else {
+					target.Rsh(target, uint(8*(3-exp)))
+				}
+
+				// The hash is a little-endian number.
+				be := slices.Clone(pow)
+				slices.Reverse(be)
+				value := new(big.Int).SetBytes(be)
+				fmt.Printf(" meets target: %v", value.Cmp(target) <= 0)
+			}
+			fmt.Println()
+		}
+		if err := s.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
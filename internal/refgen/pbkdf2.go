@@ -0,0 +1,49 @@
+package main
+
+import (
+	"crypto/pbkdf2"
+	"encoding/base64"
+	"fmt"
+)
+
+// groestlpbkdf2Command prints the expected outputs of the groestlpbkdf2
+// tests, which use the inputs of the PBKDF2-HMAC-SHA1 tests of RFC
+// 6070, and the PHC string of its known password hash.
+func groestlpbkdf2Command(args []string) error {
+	tests := []struct {
+		size     int
+		password string
+		salt     string
+		iter     int
+		length   int
+	}{
+		{224, "password", "salt", 2, 28},
+		{256, "password", "salt", 1, 32},
+		{256, "password", "salt", 2, 32},
+		{256, "password", "salt", 4096, 32},
+		{256, "passwordPASSWORDpassword", "saltSALTsaltSALTsaltSALTsaltSALTsalt", 4096, 40},
+		{256, "pass\x00word", "sa\x00lt", 4096, 16},
+		{384, "password", "salt", 2, 48},
+		{512, "password", "salt", 1, 64},
+		{512, "password", "salt", 2, 64},
+		{512, "password", "salt", 2, 80},
+		{512, "password", "salt", 4096, 64},
+	}
+
+	for _, test := range tests {
+		key, err := pbkdf2.Key(newGroestlHash(test.size), test.password, []byte(test.salt), test.iter, test.length)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Groestl%v %q %q %v: %x\n", test.size, test.password, test.salt, test.iter, key)
+	}
+
+	const password, salt, iter = "correct horse battery staple", "0123456789abcdef", 10000
+	key, err := pbkdf2.Key(newGroestlHash(256), password, []byte(salt), iter, 32)
+	if err != nil {
+		return err
+	}
+	b64 := base64.RawStdEncoding
+	fmt.Printf("PHC: $pbkdf2-groestl256$i=%v$%v$%v\n", iter, b64.EncodeToString([]byte(salt)), b64.EncodeToString(key))
+	return nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestKAT checks that the KAT files in the module are what the kat
+// command produces. The Groestl-512, BLAKE-512 and JH-512 short message
+// files hold the published round-3 digests, so they check the reference
+// implementations themselves.
+func TestKAT(t *testing.T) {
+	tests := []struct {
+		hash string
+		file string
+	}{
+		{hash: "groestl224", file: "groestl256/testdata/ShortMsgKAT_224.txt"},
+		{hash: "groestl224", file: "groestl256/testdata/LongMsgKAT_224.txt"},
+		{hash: "groestl256", file: "groestl256/testdata/ShortMsgKAT_256.txt"},
+		{hash: "groestl256", file: "groestl256/testdata/LongMsgKAT_256.txt"},
+		{hash: "groestl384", file: "groestl512/testdata/ShortMsgKAT_384.txt"},
+		{hash: "groestl384", file: "groestl512/testdata/LongMsgKAT_384.txt"},
+		{hash: "groestl512", file: "groestl512/testdata/ShortMsgKAT_512.txt"},
+		{hash: "groestl512", file: "groestl512/testdata/LongMsgKAT_512.txt"},
+		{hash: "blake224", file: "blake256/testdata/ShortMsgKAT_224.txt"},
+		{hash: "blake224", file: "blake256/testdata/LongMsgKAT_224.txt"},
+		{hash: "blake256", file: "blake256/testdata/ShortMsgKAT_256.txt"},
+		{hash: "blake256", file: "blake256/testdata/LongMsgKAT_256.txt"},
+		{hash: "blake384", file: "blake512/testdata/ShortMsgKAT_384.txt"},
+		{hash: "blake384", file: "blake512/testdata/LongMsgKAT_384.txt"},
+		{hash: "blake512", file: "blake512/testdata/ShortMsgKAT_512.txt"},
+		{hash: "blake512", file: "blake512/testdata/LongMsgKAT_512.txt"},
+		{hash: "jh224", file: "jh/testdata/ShortMsgKAT_224.txt"},
+		{hash: "jh224", file: "jh/testdata/LongMsgKAT_224.txt"},
+		{hash: "jh256", file: "jh/testdata/ShortMsgKAT_256.txt"},
+		{hash: "jh256", file: "jh/testdata/LongMsgKAT_256.txt"},
+		{hash: "jh384", file: "jh/testdata/ShortMsgKAT_384.txt"},
+		{hash: "jh384", file: "jh/testdata/LongMsgKAT_384.txt"},
+		{hash: "jh512", file: "jh/testdata/ShortMsgKAT_512.txt"},
+		{hash: "jh512", file: "jh/testdata/LongMsgKAT_512.txt"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.file, func(t *testing.T) {
+			want, err := os.ReadFile(filepath.Join("..", "..", test.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := rewriteKAT(want, hashes[test.hash])
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(got, want) {
+				t.Errorf("%v does not match; run go run ./internal/refgen kat %v %v", test.file, test.hash, test.file)
+			}
+		})
+	}
+}
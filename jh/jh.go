@@ -0,0 +1,394 @@
+// Package jh implements the JH-224, JH-256, JH-384 and JH-512 hash
+// functions, as submitted to the final round of the SHA-3 competition
+// with 42 rounds of E8.
+package jh
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+	"io"
+
+	"github.com/DeedleFake/crypto/internal/partial"
+)
+
+const (
+	// Size is the size of a JH-512 hash in bytes.
+	Size = 64
+
+	// Size224 is the size of a JH-224 hash in bytes.
+	Size224 = 28
+
+	// Size256 is the size of a JH-256 hash in bytes.
+	Size256 = 32
+
+	// Size384 is the size of a JH-384 hash in bytes.
+	Size384 = 48
+
+	// BlockSize is the blocksize of a hash.
+	BlockSize = 64
+)
+
+var (
+	iv224 = initialState(Size224)
+	iv256 = initialState(Size256)
+	iv384 = initialState(Size384)
+	iv512 = initialState(Size)
+)
+
+// initialState returns H(0) for a digest length of size bytes. H(-1)
+// holds the digest length in bits in its first two bytes, big-endian,
+// and is compressed with a block of zeros.
+func initialState(size int) (x [8][2]uint64) {
+	var b [8]byte
+	binary.BigEndian.PutUint16(b[:], uint16(size*8))
+	x[0][0] = binary.LittleEndian.Uint64(b[:])
+
+	var zero [BlockSize]byte
+	f8(&x, &zero)
+	return x
+}
+
+// Digest is the state of a JH hash. It implements hash.Hash. The zero
+// value is ready to use and computes JH-512 hashes. A Digest for any
+// other digest length must come from its constructor.
+type Digest struct {
+	buf    [64]byte
+	offset int
+	state  [8][2]uint64
+
+	// size is the length of the digest in bytes. It is zero until the
+	// zero Digest is first used.
+	size int
+
+	// count is the number of message bits in the blocks that have been
+	// compressed.
+	count uint64
+
+	// partial is what WriteBits has left of a partially written byte.
+	partial partial.Byte
+}
+
+var (
+	_ hash.Cloner     = (*Digest)(nil)
+	_ io.StringWriter = (*Digest)(nil)
+	_ io.ByteWriter   = (*Digest)(nil)
+)
+
+// New224 returns a new hash.Hash that computes JH-224 hashes.
+func New224() hash.Hash {
+	return newDigest(Size224)
+}
+
+// New256 returns a new hash.Hash that computes JH-256 hashes.
+func New256() hash.Hash {
+	return newDigest(Size256)
+}
+
+// New384 returns a new hash.Hash that computes JH-384 hashes.
+func New384() hash.Hash {
+	return newDigest(Size384)
+}
+
+// New512 returns a new hash.Hash that computes JH-512 hashes.
+func New512() hash.Hash {
+	return newDigest(Size)
+}
+
+func newDigest(size int) *Digest {
+	ctx := &Digest{size: size}
+	ctx.Reset()
+	return ctx
+}
+
+// init sets up the zero Digest to compute JH-512 hashes. It does nothing
+// to a Digest that is already set up.
+func (ctx *Digest) init() {
+	if ctx.size == 0 {
+		*ctx = *newDigest(Size)
+	}
+}
+
+func (ctx *Digest) Write(data []byte) (n int, err error) {
+	ctx.init()
+	n = len(data)
+
+	var buf [BlockSize]byte
+	for len(data) > 0 {
+		var chunk []byte
+		chunk, data = ctx.partial.Next(buf[:], data)
+		ctx.write(chunk)
+	}
+
+	return n, nil
+}
+
+// WriteString adds the bytes of s to the running hash without
+// converting it to a byte slice first. It implements io.StringWriter.
+func (ctx *Digest) WriteString(s string) (n int, err error) {
+	ctx.init()
+	n = len(s)
+
+	var buf [BlockSize]byte
+	for len(s) > 0 {
+		var chunk []byte
+		chunk, s = ctx.partial.NextString(buf[:], s)
+		ctx.write(chunk)
+	}
+
+	return n, nil
+}
+
+// WriteByte adds c to the running hash. It implements io.ByteWriter.
+func (ctx *Digest) WriteByte(c byte) error {
+	buf := [1]byte{c}
+	ctx.Write(buf[:])
+	return nil
+}
+
+// WriteBits adds the first bits bits of data to the running hash.
+// Bits are taken from the most significant end of each byte. Unlike
+// Write, the message does not need to be a whole number of bytes long,
+// and calls to WriteBits and Write can be freely interleaved. It
+// panics if bits is greater than 8*len(data).
+func (ctx *Digest) WriteBits(data []byte, bits uint64) {
+	full := bits / 8
+	ctx.Write(data[:full])
+
+	if rem := uint(bits % 8); rem != 0 {
+		if b, ok := ctx.partial.Add(data[full], rem); ok {
+			ctx.write([]byte{b})
+		}
+	}
+}
+
+func (ctx *Digest) write(data []byte) (n int, err error) {
+	n = len(data)
+
+	if ctx.offset > 0 {
+		c := copy(ctx.buf[ctx.offset:], data)
+		ctx.offset += c
+		data = data[c:]
+		if ctx.offset < len(ctx.buf) {
+			return n, nil
+		}
+
+		ctx.blocks(ctx.buf[:])
+		ctx.offset = 0
+	}
+
+	// Compress whole blocks straight out of data and only buffer
+	// what's left over.
+	if len(data) >= len(ctx.buf) {
+		c := len(data) - len(data)%len(ctx.buf)
+		ctx.blocks(data[:c])
+		data = data[c:]
+	}
+	ctx.offset = copy(ctx.buf[:], data)
+
+	return n, nil
+}
+
+// blocks compresses data, the length of which must be a multiple of
+// BlockSize, into the state.
+func (ctx *Digest) blocks(data []byte) {
+	for len(data) >= len(ctx.buf) {
+		f8(&ctx.state, (*[BlockSize]byte)(data))
+		ctx.count += BlockSize * 8
+		data = data[len(ctx.buf):]
+	}
+}
+
+func (ctx *Digest) close(dst []byte) {
+	// n is the number of message bits that have not been compressed.
+	n := uint64(ctx.offset)*8 + uint64(ctx.partial.N)
+	total := ctx.count + n
+
+	block := ctx.buf
+	block[ctx.offset] = ctx.partial.Padded()
+	clear(block[ctx.offset+1:])
+
+	// The padding is at least a whole block long, so a message that
+	// doesn't end on a block boundary is followed by two blocks of it.
+	if n > 0 {
+		f8(&ctx.state, &block)
+		clear(block[:])
+	}
+	binary.BigEndian.PutUint64(block[BlockSize-8:], total)
+	f8(&ctx.state, &block)
+
+	// The digest is the trailing bytes of the state.
+	var out [16 * 8]byte
+	for i := range 16 {
+		binary.LittleEndian.PutUint64(out[i<<3:], ctx.state[i>>1][i&1])
+	}
+	copy(dst, out[len(out)-len(dst):])
+}
+
+func (ctx *Digest) Sum(prev []byte) []byte {
+	// Finalize a copy so that the running hash can keep being written
+	// to after a call to Sum.
+	c := *ctx
+	c.init()
+	out := append(prev, make([]byte, c.size)...)
+	c.close(out[len(prev):])
+	return out
+}
+
+// Clone returns a copy of the running hash that can be written to and
+// summed independently of ctx. It implements hash.Cloner and never
+// returns an error.
+func (ctx *Digest) Clone() (hash.Cloner, error) {
+	c := *ctx
+	return &c, nil
+}
+
+func (ctx *Digest) Reset() {
+	if ctx.size == 0 {
+		return
+	}
+
+	*ctx = Digest{size: ctx.size}
+	switch ctx.size {
+	case Size224:
+		ctx.state = iv224
+	case Size256:
+		ctx.state = iv256
+	case Size384:
+		ctx.state = iv384
+	default:
+		ctx.state = iv512
+	}
+}
+
+func (ctx *Digest) Size() int {
+	if ctx.size == 0 {
+		return Size
+	}
+	return ctx.size
+}
+
+func (ctx *Digest) BlockSize() int {
+	return BlockSize
+}
+
+const (
+	magic          = "jh"
+	marshalVersion = 1
+
+	// magic, version, size, state, buf, count, offset, partial, nbits
+	marshaledSize = len(magic) + 1 + 1 + 16*8 + BlockSize + 8 + 8 + 1 + 1
+)
+
+var (
+	errStateIdentifier = errors.New("jh: invalid hash state identifier")
+	errStateVersion    = errors.New("jh: unsupported hash state version")
+	errStateSize       = errors.New("jh: invalid hash state size")
+	errStateVariant    = errors.New("jh: hash state is for a different digest length")
+	errStateCorrupt    = errors.New("jh: corrupt hash state")
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler. The returned
+// state can be restored with UnmarshalBinary to continue hashing,
+// possibly in a different process.
+func (ctx *Digest) MarshalBinary() ([]byte, error) {
+	return ctx.AppendBinary(make([]byte, 0, marshaledSize))
+}
+
+// AppendBinary implements encoding.BinaryAppender.
+func (ctx *Digest) AppendBinary(b []byte) ([]byte, error) {
+	if ctx.size == 0 {
+		ctx = newDigest(Size)
+	}
+
+	b = append(b, magic...)
+	b = append(b, marshalVersion, byte(ctx.size))
+	for _, v := range ctx.state {
+		b = binary.BigEndian.AppendUint64(b, v[0])
+		b = binary.BigEndian.AppendUint64(b, v[1])
+	}
+	b = append(b, ctx.buf[:]...)
+	b = binary.BigEndian.AppendUint64(b, ctx.count)
+	b = binary.BigEndian.AppendUint64(b, uint64(ctx.offset))
+	b = append(b, ctx.partial.Bits, byte(ctx.partial.N))
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The state
+// must have been produced by a Digest with the same digest length as
+// ctx, unless ctx is the zero Digest, in which case it takes on the
+// digest length stored in the state.
+func (ctx *Digest) UnmarshalBinary(b []byte) error {
+	if (len(b) < len(magic)) || (string(b[:len(magic)]) != magic) {
+		return errStateIdentifier
+	}
+	b = b[len(magic):]
+	if (len(b) < 1) || (b[0] != marshalVersion) {
+		return errStateVersion
+	}
+	if len(b) != marshaledSize-len(magic) {
+		return errStateSize
+	}
+	size := int(b[1])
+	if (ctx.size != 0) && (size != ctx.size) {
+		return errStateVariant
+	}
+	b = b[2:]
+
+	var d Digest
+	d.size = size
+	for u := range d.state {
+		d.state[u][0] = binary.BigEndian.Uint64(b[u<<4:])
+		d.state[u][1] = binary.BigEndian.Uint64(b[u<<4+8:])
+	}
+	b = b[len(d.state)*16:]
+	b = b[copy(d.buf[:], b):]
+	d.count = binary.BigEndian.Uint64(b)
+	offset := binary.BigEndian.Uint64(b[8:])
+	d.partial = partial.Byte{Bits: b[16], N: uint(b[17])}
+
+	switch size {
+	case Size224, Size256, Size384, Size:
+	default:
+		return errStateCorrupt
+	}
+	if (offset >= BlockSize) || (d.count%(BlockSize*8) != 0) || !d.partial.Valid() {
+		return errStateCorrupt
+	}
+	d.offset = int(offset)
+
+	*ctx = d
+	return nil
+}
+
+// Sum224 returns the JH-224 hash of data.
+func Sum224(data []byte) (out [Size224]byte) {
+	ctx := newDigest(Size224)
+	ctx.Write(data)
+	ctx.close(out[:])
+	return out
+}
+
+// Sum256 returns the JH-256 hash of data.
+func Sum256(data []byte) (out [Size256]byte) {
+	ctx := newDigest(Size256)
+	ctx.Write(data)
+	ctx.close(out[:])
+	return out
+}
+
+// Sum384 returns the JH-384 hash of data.
+func Sum384(data []byte) (out [Size384]byte) {
+	ctx := newDigest(Size384)
+	ctx.Write(data)
+	ctx.close(out[:])
+	return out
+}
+
+// Sum512 returns the JH-512 hash of data.
+func Sum512(data []byte) (out [Size]byte) {
+	ctx := newDigest(Size)
+	ctx.Write(data)
+	ctx.close(out[:])
+	return out
+}
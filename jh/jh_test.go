@@ -0,0 +1,159 @@
+package jh
+
+import (
+	"bytes"
+	"fmt"
+	"hash"
+	"path/filepath"
+	"testing"
+
+	"github.com/DeedleFake/crypto/internal/hashtest"
+	"github.com/DeedleFake/crypto/internal/kat"
+)
+
+var variants = []struct {
+	name    string
+	newHash func() hash.Hash
+	sum     func([]byte) []byte
+	empty   string
+}{
+	{
+		name:    "224",
+		newHash: New224,
+		sum:     func(in []byte) []byte { s := Sum224(in); return s[:] },
+		empty:   "2c99df889b019309051c60fecc2bd285a774940e43175b76b2626630",
+	},
+	{
+		name:    "256",
+		newHash: New256,
+		sum:     func(in []byte) []byte { s := Sum256(in); return s[:] },
+		empty:   "46e64619c18bb0a92a5e87185a47eef83ca747b8fcc8e1412921357e326df434",
+	},
+	{
+		name:    "384",
+		newHash: New384,
+		sum:     func(in []byte) []byte { s := Sum384(in); return s[:] },
+		empty:   "2fe5f71b1b3290d3c017fb3c1a4d02a5cbeb03a0476481e25082434a881994b0ff99e078d2c16b105ad069b569315328",
+	},
+	{
+		name:    "512",
+		newHash: New512,
+		sum:     func(in []byte) []byte { s := Sum512(in); return s[:] },
+		empty:   "90ecf2f76f9d2c8017d979ad5ab96b87d58fc8fc4b83060f3f900774faa2c8fabe69c5f4ff1ec2b61d6b316941cedee117fb04b1f4c5bc1b919ae841c50eec4f",
+	},
+}
+
+func TestSum(t *testing.T) {
+	in := hashtest.Seq(300)
+
+	for _, v := range variants {
+		v := v
+		t.Run(v.name, func(t *testing.T) {
+			h := v.newHash()
+			if got := fmt.Sprintf("%x", h.Sum(nil)); got != v.empty {
+				t.Errorf("Expected %v", v.empty)
+				t.Errorf("Got %v", got)
+			}
+			if got := fmt.Sprintf("%x", v.sum(nil)); got != v.empty {
+				t.Errorf("Sum of empty message = %v", got)
+			}
+
+			h.Write(in)
+			if got, want := v.sum(in), h.Sum(nil); !bytes.Equal(got, want) {
+				t.Errorf("Sum = %x, hash.Hash = %x", got, want)
+			}
+			if h.Size() != len(v.sum(nil)) {
+				t.Errorf("Size = %v", h.Size())
+			}
+		})
+	}
+}
+
+func TestHash(t *testing.T) {
+	hashtest.Run(t, func() hashtest.Hash { return New512().(*Digest) })
+}
+
+func TestZeroDigest(t *testing.T) {
+	hashtest.Run(t, func() hashtest.Hash { return new(Digest) })
+
+	var d Digest
+	if d.Size() != Size {
+		t.Errorf("Expected size %v", Size)
+		t.Errorf("Got %v", d.Size())
+	}
+
+	// The zero Digest marshals as a fresh JH-512 state.
+	got, _ := d.MarshalBinary()
+	want, _ := New512().(*Digest).MarshalBinary()
+	if !bytes.Equal(got, want) {
+		t.Errorf("Expected state %x", want)
+		t.Errorf("Got %x", got)
+	}
+
+	d.Reset()
+	d.Write([]byte("abc"))
+	if got, want := d.Sum(nil), Sum512([]byte("abc")); !bytes.Equal(got, want[:]) {
+		t.Errorf("Expected %x", want)
+		t.Errorf("Got %x", got)
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	for i, v := range variants {
+		other := variants[(i+1)%len(variants)]
+		t.Run(v.name, func(t *testing.T) {
+			hashtest.RunMarshal(t, hashtest.MarshalTest{
+				New:           func() hashtest.State { return v.newHash().(*Digest) },
+				Other:         func() hashtest.State { return other.newHash().(*Digest) },
+				Zero:          func() hashtest.State { return new(Digest) },
+				Magic:         magic,
+				ErrIdentifier: errStateIdentifier,
+				ErrVersion:    errStateVersion,
+				ErrSize:       errStateSize,
+				ErrVariant:    errStateVariant,
+				ErrCorrupt:    errStateCorrupt,
+			})
+		})
+	}
+}
+
+// The block count must agree with the offset, and a zero Digest only
+// accepts the digest lengths of JH.
+func TestUnmarshalBinaryCorrupt(t *testing.T) {
+	h := New256().(*Digest)
+	h.Write([]byte("abc"))
+	state, _ := h.MarshalBinary()
+
+	count := append([]byte{}, state...)
+	count[len(count)-11] = 1
+	if err := New256().(*Digest).UnmarshalBinary(count); err != errStateCorrupt {
+		t.Errorf("Count: got %v", err)
+	}
+
+	size := append([]byte{}, state...)
+	size[len(magic)+1] = 20
+	if err := new(Digest).UnmarshalBinary(size); err != errStateCorrupt {
+		t.Errorf("Size: got %v", err)
+	}
+}
+
+// The KAT files contain the messages of the SHA-3 competition. The
+// JH-512 short message digests are the published results, and the
+// others were computed by internal/refgen, which reproduces them.
+func TestKAT(t *testing.T) {
+	for _, v := range variants {
+		v := v
+		for _, file := range []string{"ShortMsgKAT", "LongMsgKAT"} {
+			name := file + "_" + v.name
+			t.Run(name, func(t *testing.T) {
+				kat.Run(t, filepath.Join("testdata", name+".txt"), func() kat.BitHash {
+					return v.newHash().(*Digest)
+				})
+			})
+		}
+	}
+}
+
+func BenchmarkWrite(b *testing.B) {
+	hashtest.Benchmark(b, New512)
+}
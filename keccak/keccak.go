@@ -0,0 +1,395 @@
+// Package keccak implements the Keccak-224, Keccak-256, Keccak-384 and
+// Keccak-512 hash functions, as submitted to the final round of the
+// SHA-3 competition, and the Keccak sponge that they are built on.
+//
+// These functions pad messages with the original Keccak padding rule
+// rather than the one of FIPS 202, so they give different digests than
+// the SHA-3 functions of the same lengths. They are the ones used by
+// X11, Quark and Ethereum, among others.
+package keccak
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math"
+
+	"github.com/DeedleFake/crypto/internal/partial"
+)
+
+const (
+	// Size is the size of a Keccak-512 hash in bytes.
+	Size = 64
+
+	// Size224 is the size of a Keccak-224 hash in bytes.
+	Size224 = 28
+
+	// Size256 is the size of a Keccak-256 hash in bytes.
+	Size256 = 32
+
+	// Size384 is the size of a Keccak-384 hash in bytes.
+	Size384 = 48
+)
+
+// stateSize is the size of the Keccak-f[1600] state in bytes, and
+// maxRate the largest rate that leaves a capacity of at least one lane.
+const (
+	stateSize = 25 * 8
+	maxRate   = stateSize - 8
+)
+
+// Digest is the state of a Keccak hash. It implements hash.Hash. The
+// zero value is ready to use and computes Keccak-512 hashes. A Digest
+// for any other digest length or rate must come from its constructor.
+type Digest struct {
+	a      [25]uint64
+	buf    [maxRate]byte
+	offset int
+
+	// rate is the number of bytes absorbed or squeezed per permutation
+	// and size the number of bytes squeezed out in total. Both are zero
+	// until the zero Digest is first used.
+	rate int
+	size int
+
+	// partial is what WriteBits has left of a partially written byte.
+	partial partial.Byte
+}
+
+var (
+	_ hash.Cloner     = (*Digest)(nil)
+	_ io.StringWriter = (*Digest)(nil)
+	_ io.ByteWriter   = (*Digest)(nil)
+)
+
+// New224 returns a new hash.Hash that computes Keccak-224 hashes.
+func New224() hash.Hash {
+	return newDigest(Size224)
+}
+
+// New256 returns a new hash.Hash that computes Keccak-256 hashes.
+func New256() hash.Hash {
+	return newDigest(Size256)
+}
+
+// New384 returns a new hash.Hash that computes Keccak-384 hashes.
+func New384() hash.Hash {
+	return newDigest(Size384)
+}
+
+// New512 returns a new hash.Hash that computes Keccak-512 hashes.
+func New512() hash.Hash {
+	return newDigest(Size)
+}
+
+// NewSponge returns a new hash.Hash that computes hashes with the
+// Keccak sponge of the given rate, which leaves a capacity of 1600 bits
+// minus the rate, and a digest length of the given number of bits. The
+// rate must be a multiple of 64 less than 1600 and the length a
+// positive multiple of 8. Keccak-256 is NewSponge(1088, 256), for
+// example. Digests longer than the rate are squeezed out over several
+// permutations.
+func NewSponge(rate, bits int) (hash.Hash, error) {
+	if (rate <= 0) || (rate > maxRate*8) || (rate%64 != 0) {
+		return nil, fmt.Errorf("keccak: invalid rate: %v bits", rate)
+	}
+	if (bits <= 0) || (bits%8 != 0) {
+		return nil, fmt.Errorf("keccak: invalid digest length: %v bits", bits)
+	}
+
+	return &Digest{rate: rate / 8, size: bits / 8}, nil
+}
+
+// newDigest returns a Digest for the fixed-length function with a
+// digest length of size bytes, which has a capacity of twice that.
+func newDigest(size int) *Digest {
+	return &Digest{rate: stateSize - 2*size, size: size}
+}
+
+// init sets up the zero Digest to compute Keccak-512 hashes. It does
+// nothing to a Digest that is already set up.
+func (ctx *Digest) init() {
+	if ctx.rate == 0 {
+		*ctx = *newDigest(Size)
+	}
+}
+
+func (ctx *Digest) Write(data []byte) (n int, err error) {
+	ctx.init()
+	n = len(data)
+
+	var buf [maxRate]byte
+	for len(data) > 0 {
+		var chunk []byte
+		chunk, data = ctx.partial.Next(buf[:], data)
+		ctx.write(chunk)
+	}
+
+	return n, nil
+}
+
+// WriteString adds the bytes of s to the running hash without
+// converting it to a byte slice first. It implements io.StringWriter.
+func (ctx *Digest) WriteString(s string) (n int, err error) {
+	ctx.init()
+	n = len(s)
+
+	var buf [maxRate]byte
+	for len(s) > 0 {
+		var chunk []byte
+		chunk, s = ctx.partial.NextString(buf[:], s)
+		ctx.write(chunk)
+	}
+
+	return n, nil
+}
+
+// WriteByte adds c to the running hash. It implements io.ByteWriter.
+func (ctx *Digest) WriteByte(c byte) error {
+	buf := [1]byte{c}
+	ctx.Write(buf[:])
+	return nil
+}
+
+// WriteBits adds the first bits bits of data to the running hash.
+// Bits are taken from the most significant end of each byte, as in the
+// SHA-3 competition test vectors. Unlike Write, the message does not
+// need to be a whole number of bytes long, and calls to WriteBits and
+// Write can be freely interleaved. It panics if bits is greater than
+// 8*len(data).
+func (ctx *Digest) WriteBits(data []byte, bits uint64) {
+	full := bits / 8
+	ctx.Write(data[:full])
+
+	if rem := uint(bits % 8); rem != 0 {
+		if b, ok := ctx.partial.Add(data[full], rem); ok {
+			ctx.write([]byte{b})
+		}
+	}
+}
+
+func (ctx *Digest) write(data []byte) (n int, err error) {
+	n = len(data)
+	rate := ctx.rate
+
+	if ctx.offset > 0 {
+		c := copy(ctx.buf[ctx.offset:rate], data)
+		ctx.offset += c
+		data = data[c:]
+		if ctx.offset < rate {
+			return n, nil
+		}
+
+		ctx.blocks(ctx.buf[:rate])
+		ctx.offset = 0
+	}
+
+	// Absorb whole blocks straight out of data and only buffer what's
+	// left over.
+	if len(data) >= rate {
+		c := len(data) - len(data)%rate
+		ctx.blocks(data[:c])
+		data = data[c:]
+	}
+	ctx.offset = copy(ctx.buf[:rate], data)
+
+	return n, nil
+}
+
+// blocks absorbs data, the length of which must be a multiple of the
+// rate, into the state.
+func (ctx *Digest) blocks(data []byte) {
+	lanes := ctx.rate / 8
+	for len(data) >= ctx.rate {
+		for i := range lanes {
+			ctx.a[i] ^= binary.LittleEndian.Uint64(data[i<<3:])
+		}
+		keccakF1600(&ctx.a)
+		data = data[ctx.rate:]
+	}
+}
+
+func (ctx *Digest) close(dst []byte) {
+	rate := ctx.rate
+	block := ctx.buf
+
+	// Keccak fills bytes from the least significant bit up, so the bits
+	// of a partial byte move to the bottom, and the padding starts with
+	// a one right above them and ends with a one in the top bit of the
+	// block. Both can be in the same byte, but if the first one is the
+	// last bit of the block, the second one needs a block of its own.
+	block[ctx.offset] = byte((0x100 | uint(ctx.partial.Bits)) >> (8 - ctx.partial.N))
+	clear(block[ctx.offset+1 : rate])
+	if (ctx.offset == rate-1) && (ctx.partial.N == 7) {
+		ctx.blocks(block[:rate])
+		clear(block[:rate])
+	}
+	block[rate-1] |= 0x80
+	ctx.blocks(block[:rate])
+
+	for {
+		for i := range rate / 8 {
+			binary.LittleEndian.PutUint64(block[i<<3:], ctx.a[i])
+		}
+		dst = dst[copy(dst, block[:rate]):]
+		if len(dst) == 0 {
+			return
+		}
+		keccakF1600(&ctx.a)
+	}
+}
+
+func (ctx *Digest) Sum(prev []byte) []byte {
+	// Finalize a copy so that the running hash can keep being written
+	// to after a call to Sum.
+	c := *ctx
+	c.init()
+	out := append(prev, make([]byte, c.size)...)
+	c.close(out[len(prev):])
+	return out
+}
+
+// Clone returns a copy of the running hash that can be written to and
+// summed independently of ctx. It implements hash.Cloner and never
+// returns an error.
+func (ctx *Digest) Clone() (hash.Cloner, error) {
+	c := *ctx
+	return &c, nil
+}
+
+func (ctx *Digest) Reset() {
+	*ctx = Digest{rate: ctx.rate, size: ctx.size}
+}
+
+func (ctx *Digest) Size() int {
+	if ctx.rate == 0 {
+		return Size
+	}
+	return ctx.size
+}
+
+// BlockSize returns the rate of the sponge in bytes.
+func (ctx *Digest) BlockSize() int {
+	if ctx.rate == 0 {
+		return stateSize - 2*Size
+	}
+	return ctx.rate
+}
+
+const (
+	magic          = "keccak"
+	marshalVersion = 1
+
+	// magic, version, rate, size, state, buf, offset, partial, nbits
+	marshaledSize = len(magic) + 1 + 1 + 8 + stateSize + maxRate + 8 + 1 + 1
+)
+
+var (
+	errStateIdentifier = errors.New("keccak: invalid hash state identifier")
+	errStateVersion    = errors.New("keccak: unsupported hash state version")
+	errStateSize       = errors.New("keccak: invalid hash state size")
+	errStateVariant    = errors.New("keccak: hash state is for a different rate or digest length")
+	errStateCorrupt    = errors.New("keccak: corrupt hash state")
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler. The returned
+// state can be restored with UnmarshalBinary to continue hashing,
+// possibly in a different process.
+func (ctx *Digest) MarshalBinary() ([]byte, error) {
+	return ctx.AppendBinary(make([]byte, 0, marshaledSize))
+}
+
+// AppendBinary implements encoding.BinaryAppender.
+func (ctx *Digest) AppendBinary(b []byte) ([]byte, error) {
+	if ctx.rate == 0 {
+		ctx = newDigest(Size)
+	}
+
+	b = append(b, magic...)
+	b = append(b, marshalVersion, byte(ctx.rate))
+	b = binary.BigEndian.AppendUint64(b, uint64(ctx.size))
+	for _, v := range ctx.a {
+		b = binary.BigEndian.AppendUint64(b, v)
+	}
+	b = append(b, ctx.buf[:]...)
+	b = binary.BigEndian.AppendUint64(b, uint64(ctx.offset))
+	b = append(b, ctx.partial.Bits, byte(ctx.partial.N))
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The state
+// must have been produced by a Digest with the same rate and digest
+// length as ctx, unless ctx is the zero Digest, in which case it takes
+// on the ones stored in the state.
+func (ctx *Digest) UnmarshalBinary(b []byte) error {
+	if (len(b) < len(magic)) || (string(b[:len(magic)]) != magic) {
+		return errStateIdentifier
+	}
+	b = b[len(magic):]
+	if (len(b) < 1) || (b[0] != marshalVersion) {
+		return errStateVersion
+	}
+	if len(b) != marshaledSize-len(magic) {
+		return errStateSize
+	}
+	rate := int(b[1])
+	size := binary.BigEndian.Uint64(b[2:])
+	if (ctx.rate != 0) && ((rate != ctx.rate) || (size != uint64(ctx.size))) {
+		return errStateVariant
+	}
+	b = b[10:]
+
+	var d Digest
+	for i := range d.a {
+		d.a[i] = binary.BigEndian.Uint64(b[i<<3:])
+	}
+	b = b[stateSize:]
+	b = b[copy(d.buf[:], b):]
+	offset := binary.BigEndian.Uint64(b)
+	d.partial = partial.Byte{Bits: b[8], N: uint(b[9])}
+
+	if (rate == 0) || (rate > maxRate) || (rate%8 != 0) || (size == 0) || (size > math.MaxInt) {
+		return errStateCorrupt
+	}
+	if (offset >= uint64(rate)) || !d.partial.Valid() {
+		return errStateCorrupt
+	}
+	d.rate, d.size, d.offset = rate, int(size), int(offset)
+
+	*ctx = d
+	return nil
+}
+
+// Sum224 returns the Keccak-224 hash of data.
+func Sum224(data []byte) (out [Size224]byte) {
+	ctx := newDigest(Size224)
+	ctx.Write(data)
+	ctx.close(out[:])
+	return out
+}
+
+// Sum256 returns the Keccak-256 hash of data.
+func Sum256(data []byte) (out [Size256]byte) {
+	ctx := newDigest(Size256)
+	ctx.Write(data)
+	ctx.close(out[:])
+	return out
+}
+
+// Sum384 returns the Keccak-384 hash of data.
+func Sum384(data []byte) (out [Size384]byte) {
+	ctx := newDigest(Size384)
+	ctx.Write(data)
+	ctx.close(out[:])
+	return out
+}
+
+// Sum512 returns the Keccak-512 hash of data.
+func Sum512(data []byte) (out [Size]byte) {
+	ctx := newDigest(Size)
+	ctx.Write(data)
+	ctx.close(out[:])
+	return out
+}
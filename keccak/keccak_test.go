@@ -0,0 +1,337 @@
+package keccak
+
+import (
+	"bytes"
+	"encoding"
+	"fmt"
+	"hash"
+	"path/filepath"
+	"testing"
+
+	"github.com/DeedleFake/crypto/internal/hashtest"
+	"github.com/DeedleFake/crypto/internal/kat"
+)
+
+var variants = []struct {
+	name    string
+	newHash func() hash.Hash
+	sum     func([]byte) []byte
+	empty   string
+}{
+	{
+		name:    "224",
+		newHash: New224,
+		sum:     func(in []byte) []byte { s := Sum224(in); return s[:] },
+		empty:   "f71837502ba8e10837bdd8d365adb85591895602fc552b48b7390abd",
+	},
+	{
+		name:    "256",
+		newHash: New256,
+		sum:     func(in []byte) []byte { s := Sum256(in); return s[:] },
+		empty:   "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",
+	},
+	{
+		name:    "384",
+		newHash: New384,
+		sum:     func(in []byte) []byte { s := Sum384(in); return s[:] },
+		empty:   "2c23146a63a29acf99e73b88f8c24eaa7dc60aa771780ccc006afbfa8fe2479b2dd2b21362337441ac12b515911957ff",
+	},
+	{
+		name:    "512",
+		newHash: New512,
+		sum:     func(in []byte) []byte { s := Sum512(in); return s[:] },
+		empty:   "0eab42de4c3ceb9235fc91acffe746b29c29a8c366b7c60e4e67c466f36a4304c00fa9caf9d87976ba469bcbe06713b435f091ef2769fb160cdab33d3670680e",
+	},
+}
+
+func TestSum(t *testing.T) {
+	in := hashtest.Seq(300)
+
+	for _, v := range variants {
+		v := v
+		t.Run(v.name, func(t *testing.T) {
+			h := v.newHash()
+			if got := fmt.Sprintf("%x", h.Sum(nil)); got != v.empty {
+				t.Errorf("Expected %v", v.empty)
+				t.Errorf("Got %v", got)
+			}
+			if got := fmt.Sprintf("%x", v.sum(nil)); got != v.empty {
+				t.Errorf("Sum of empty message = %v", got)
+			}
+
+			h.Write(in)
+			if got, want := v.sum(in), h.Sum(nil); !bytes.Equal(got, want) {
+				t.Errorf("Sum = %x, hash.Hash = %x", got, want)
+			}
+			if h.Size() != len(v.sum(nil)) {
+				t.Errorf("Size = %v", h.Size())
+			}
+		})
+	}
+}
+
+func TestSumString(t *testing.T) {
+	tests := []struct {
+		in   string
+		sum  func([]byte) []byte
+		want string
+	}{
+		{
+			in:   "Keccak-224 Test Hash",
+			sum:  variants[0].sum,
+			want: "30045b34946e1b2e091613362fd22aa08e2beafec5e8daee42c2e665",
+		},
+		{
+			in:   "Keccak-256 Test Hash",
+			sum:  variants[1].sum,
+			want: "a8d71b07f4af26a4ff21027f62ff60267ff955c963f042c46da52ee3cfaf3d3c",
+		},
+		{
+			in:   "Keccak-384 Test Hash",
+			sum:  variants[2].sum,
+			want: "e213fd74af0c5ff91b423c8bceecd701f8dd64ec18fd6f9260fc9ec1edbd2230a6908665bcd9fbf41a99a18a7d9e446e",
+		},
+	}
+
+	for _, test := range tests {
+		if got := fmt.Sprintf("%x", test.sum([]byte(test.in))); got != test.want {
+			t.Errorf("%q: expected %v", test.in, test.want)
+			t.Errorf("%q: got %v", test.in, got)
+		}
+	}
+}
+
+func TestSponge(t *testing.T) {
+	in := hashtest.Seq(300)
+
+	for _, v := range variants {
+		size := len(v.sum(nil))
+		h, err := NewSponge(1600-2*size*8, size*8)
+		if err != nil {
+			t.Fatal(err)
+		}
+		h.Write(in)
+		if got, want := h.Sum(nil), v.sum(in); !bytes.Equal(got, want) {
+			t.Errorf("Keccak-%v: expected %x", v.name, want)
+			t.Errorf("Keccak-%v: got %x", v.name, got)
+		}
+	}
+
+	// A digest longer than the rate continues the one that fits in it.
+	short, _ := NewSponge(576, 576)
+	long, _ := NewSponge(576, 4096)
+	short.Write(in)
+	long.Write(in)
+	got, want := long.Sum(nil), short.Sum(nil)
+	if (len(got) != 512) || !bytes.Equal(got[:len(want)], want) {
+		t.Errorf("Expected %x...", want)
+		t.Errorf("Got %x", got)
+	}
+	if bytes.Equal(got[len(want):2*len(want)], want) {
+		t.Errorf("Squeezing did not permute the state")
+	}
+}
+
+func TestSpongeErrors(t *testing.T) {
+	for _, test := range []struct {
+		rate, bits int
+	}{
+		{0, 256},
+		{-64, 256},
+		{1000, 256},
+		{1600, 256},
+		{1664, 256},
+		{1088, 0},
+		{1088, -8},
+		{1088, 255},
+	} {
+		if _, err := NewSponge(test.rate, test.bits); err == nil {
+			t.Errorf("Expected error for rate %v and %v bits", test.rate, test.bits)
+		}
+	}
+
+	h, err := NewSponge(1536, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (h.BlockSize() != 192) || (h.Size() != 1) {
+		t.Errorf("BlockSize = %v, Size = %v", h.BlockSize(), h.Size())
+	}
+}
+
+func TestHash(t *testing.T) {
+	for _, v := range variants {
+		t.Run(v.name, func(t *testing.T) {
+			hashtest.Run(t, func() hashtest.Hash { return v.newHash().(*Digest) })
+		})
+	}
+}
+
+func TestZeroDigest(t *testing.T) {
+	hashtest.Run(t, func() hashtest.Hash { return new(Digest) })
+
+	var d Digest
+	if (d.Size() != Size) || (d.BlockSize() != New512().BlockSize()) {
+		t.Errorf("BlockSize = %v, Size = %v", d.BlockSize(), d.Size())
+	}
+
+	zero, _ := d.MarshalBinary()
+	fresh, _ := New512().(*Digest).MarshalBinary()
+	if !bytes.Equal(zero, fresh) {
+		t.Errorf("Expected state %x", fresh)
+		t.Errorf("Got %x", zero)
+	}
+
+	d.Reset()
+	d.Write([]byte("abc"))
+	if got, want := d.Sum(nil), Sum512([]byte("abc")); !bytes.Equal(got, want[:]) {
+		t.Errorf("Expected %x", want)
+		t.Errorf("Got %x", got)
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	in := bytes.Repeat([]byte("The quick brown fox jumps over the lazy dog"), 7)
+
+	for _, v := range variants {
+		want := v.newHash()
+		want.Write(in)
+
+		for split := 0; split <= len(in); split += 13 {
+			h := v.newHash()
+			h.Write(in[:split])
+			state, err := h.(encoding.BinaryMarshaler).MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, r := range []hash.Hash{v.newHash(), new(Digest)} {
+				err = r.(encoding.BinaryUnmarshaler).UnmarshalBinary(state)
+				if err != nil {
+					t.Fatalf("Keccak-%v: split %v: %v", v.name, split, err)
+				}
+				r.Write(in[split:])
+
+				if got, want := r.Sum(nil), want.Sum(nil); !bytes.Equal(got, want) {
+					t.Errorf("Keccak-%v: split %v: expected %x", v.name, split, want)
+					t.Errorf("Keccak-%v: split %v: got %x", v.name, split, got)
+				}
+			}
+		}
+	}
+}
+
+func TestMarshalBinaryPartial(t *testing.T) {
+	h := New256().(*Digest)
+	h.WriteBits([]byte{0xA5, 0xFF}, 13)
+	state, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var r Digest
+	if err := r.UnmarshalBinary(state); err != nil {
+		t.Fatal(err)
+	}
+	r.WriteBits([]byte{0xFF}, 3)
+	h.WriteBits([]byte{0xFF}, 3)
+
+	if got, want := r.Sum(nil), h.Sum(nil); !bytes.Equal(got, want) {
+		t.Errorf("Expected %x", want)
+		t.Errorf("Got %x", got)
+	}
+}
+
+func TestAppendBinary(t *testing.T) {
+	h := New256().(*Digest)
+	h.Write([]byte("abc"))
+
+	state, _ := h.MarshalBinary()
+	appended, err := h.AppendBinary([]byte("prefix"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(appended, append([]byte("prefix"), state...)) {
+		t.Errorf("AppendBinary did not append the marshaled state")
+	}
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	h := New256().(*Digest)
+	h.Write([]byte("abc"))
+	state, _ := h.MarshalBinary()
+
+	other := New224().(*Digest)
+	otherState, _ := other.MarshalBinary()
+
+	modify := func(i int, v byte) []byte {
+		b := append([]byte{}, state...)
+		b[i] = v
+		return b
+	}
+
+	tests := []struct {
+		name  string
+		state []byte
+		err   error
+	}{
+		{name: "Empty", state: nil, err: errStateIdentifier},
+		{name: "Magic", state: modify(0, 'x'), err: errStateIdentifier},
+		{name: "Version", state: modify(len(magic), 99), err: errStateVersion},
+		{name: "Short", state: state[:len(state)-1], err: errStateSize},
+		{name: "Long", state: append(state, 0), err: errStateSize},
+		{name: "Variant", state: otherState, err: errStateVariant},
+		{name: "Offset", state: modify(len(state)-3, byte(h.BlockSize())), err: errStateCorrupt},
+		{name: "Bits", state: modify(len(state)-1, 8), err: errStateCorrupt},
+		{name: "Partial", state: modify(len(state)-2, 0x01), err: errStateCorrupt},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			r := New256().(*Digest)
+			if err := r.UnmarshalBinary(test.state); err != test.err {
+				t.Errorf("Expected %v", test.err)
+				t.Errorf("Got %v", err)
+			}
+		})
+	}
+
+	// A zero Digest only accepts rates of whole lanes and digests that
+	// are at least a byte long.
+	for _, test := range []struct {
+		name string
+		i    int
+		v    byte
+	}{
+		{name: "Rate", i: len(magic) + 1, v: 20},
+		{name: "MaxRate", i: len(magic) + 1, v: 200},
+		{name: "Size", i: len(magic) + 9, v: 0},
+	} {
+		if err := new(Digest).UnmarshalBinary(modify(test.i, test.v)); err != errStateCorrupt {
+			t.Errorf("%v: got %v", test.name, err)
+		}
+	}
+}
+
+// The KAT files contain the messages of the SHA-3 competition and the
+// published Keccak digests. Only the Keccak-512 short message file has
+// the messages that are not a whole number of bytes long; the others
+// have the byte-aligned ones.
+func TestKAT(t *testing.T) {
+	for _, v := range variants {
+		v := v
+		for _, file := range []string{"ShortMsgKAT", "LongMsgKAT"} {
+			name := file + "_" + v.name
+			t.Run(name, func(t *testing.T) {
+				kat.Run(t, filepath.Join("testdata", name+".txt"), func() kat.BitHash {
+					return v.newHash().(*Digest)
+				})
+			})
+		}
+	}
+}
+
+func BenchmarkWrite(b *testing.B) {
+	hashtest.Benchmark(b, New512)
+}
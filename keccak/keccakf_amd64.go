@@ -0,0 +1,8 @@
+//go:build amd64 && !purego
+
+package keccak
+
+// keccakF1600 is keccakF1600Generic written in assembly.
+//
+//go:noescape
+func keccakF1600(a *[25]uint64)
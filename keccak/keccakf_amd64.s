@@ -0,0 +1,155 @@
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// Each round reads the state from one buffer and writes it to the
+// other, so that ρ, π and χ need no more than the five lanes of one
+// output plane in registers at a time. The rounds alternate between the
+// caller's state and a buffer on the stack, and an even number of them
+// leaves the result in the caller's.
+//
+// The D values of θ are kept in R8 to R12 for the whole round, the five
+// lanes of the plane being computed in AX, BX, CX, DX and R13, and R14
+// is scratch. The pointer to the next round constant and the number of
+// round pairs left are kept on the stack.
+
+#define rc 200(SP)
+#define pairs 208(SP)
+
+// THETA computes the column parities of the state at in and from them
+// D[x] = C[x-1] ^ rot(C[x+1], 1) into R8 to R12.
+#define THETA(in) \
+	MOVQ 0(in), AX \
+	XORQ 40(in), AX \
+	XORQ 80(in), AX \
+	XORQ 120(in), AX \
+	XORQ 160(in), AX \
+	MOVQ 8(in), BX \
+	XORQ 48(in), BX \
+	XORQ 88(in), BX \
+	XORQ 128(in), BX \
+	XORQ 168(in), BX \
+	MOVQ 16(in), CX \
+	XORQ 56(in), CX \
+	XORQ 96(in), CX \
+	XORQ 136(in), CX \
+	XORQ 176(in), CX \
+	MOVQ 24(in), DX \
+	XORQ 64(in), DX \
+	XORQ 104(in), DX \
+	XORQ 144(in), DX \
+	XORQ 184(in), DX \
+	MOVQ 32(in), R13 \
+	XORQ 72(in), R13 \
+	XORQ 112(in), R13 \
+	XORQ 152(in), R13 \
+	XORQ 192(in), R13 \
+	MOVQ BX, R8 \
+	ROLQ $1, R8 \
+	XORQ R13, R8 \
+	MOVQ CX, R9 \
+	ROLQ $1, R9 \
+	XORQ AX, R9 \
+	MOVQ DX, R10 \
+	ROLQ $1, R10 \
+	XORQ BX, R10 \
+	MOVQ R13, R11 \
+	ROLQ $1, R11 \
+	XORQ CX, R11 \
+	MOVQ AX, R12 \
+	ROLQ $1, R12 \
+	XORQ DX, R12
+
+// LOAD loads the lane at off from in into dst, adds d to it for θ and
+// rotates it left by r for ρ.
+#define LOAD(in, off, d, r, dst) \
+	MOVQ off(in), dst \
+	XORQ d, dst \
+	ROLQ $r, dst
+
+// CHI stores b0 ^ (^b1 & b2) to the lane at off in out.
+#define CHI(out, off, b0, b1, b2) \
+	MOVQ b1, R14 \
+	NOTQ R14 \
+	ANDQ b2, R14 \
+	XORQ b0, R14 \
+	MOVQ R14, off(out)
+
+// IOTA adds the next round constant to the first lane of out.
+#define IOTA(out) \
+	MOVQ rc, R14 \
+	MOVQ (R14), R14 \
+	XORQ R14, 0(out) \
+	ADDQ $8, rc
+
+// ROUND applies one round to the state at in and stores the result to
+// out. Where π moves each lane is done by where LOAD takes it from.
+#define ROUND(in, out) \
+	THETA(in) \
+	MOVQ 0(in), AX \
+	XORQ R8, AX \
+	LOAD(in, 48, R9, 44, BX) \
+	LOAD(in, 96, R10, 43, CX) \
+	LOAD(in, 144, R11, 21, DX) \
+	LOAD(in, 192, R12, 14, R13) \
+	CHI(out, 0, AX, BX, CX) \
+	CHI(out, 8, BX, CX, DX) \
+	CHI(out, 16, CX, DX, R13) \
+	CHI(out, 24, DX, R13, AX) \
+	CHI(out, 32, R13, AX, BX) \
+	LOAD(in, 24, R11, 28, AX) \
+	LOAD(in, 72, R12, 20, BX) \
+	LOAD(in, 80, R8, 3, CX) \
+	LOAD(in, 128, R9, 45, DX) \
+	LOAD(in, 176, R10, 61, R13) \
+	CHI(out, 40, AX, BX, CX) \
+	CHI(out, 48, BX, CX, DX) \
+	CHI(out, 56, CX, DX, R13) \
+	CHI(out, 64, DX, R13, AX) \
+	CHI(out, 72, R13, AX, BX) \
+	LOAD(in, 8, R9, 1, AX) \
+	LOAD(in, 56, R10, 6, BX) \
+	LOAD(in, 104, R11, 25, CX) \
+	LOAD(in, 152, R12, 8, DX) \
+	LOAD(in, 160, R8, 18, R13) \
+	CHI(out, 80, AX, BX, CX) \
+	CHI(out, 88, BX, CX, DX) \
+	CHI(out, 96, CX, DX, R13) \
+	CHI(out, 104, DX, R13, AX) \
+	CHI(out, 112, R13, AX, BX) \
+	LOAD(in, 32, R12, 27, AX) \
+	LOAD(in, 40, R8, 36, BX) \
+	LOAD(in, 88, R9, 10, CX) \
+	LOAD(in, 136, R10, 15, DX) \
+	LOAD(in, 184, R11, 56, R13) \
+	CHI(out, 120, AX, BX, CX) \
+	CHI(out, 128, BX, CX, DX) \
+	CHI(out, 136, CX, DX, R13) \
+	CHI(out, 144, DX, R13, AX) \
+	CHI(out, 152, R13, AX, BX) \
+	LOAD(in, 16, R10, 62, AX) \
+	LOAD(in, 64, R11, 55, BX) \
+	LOAD(in, 112, R12, 39, CX) \
+	LOAD(in, 120, R8, 41, DX) \
+	LOAD(in, 168, R9, 2, R13) \
+	CHI(out, 160, AX, BX, CX) \
+	CHI(out, 168, BX, CX, DX) \
+	CHI(out, 176, CX, DX, R13) \
+	CHI(out, 184, DX, R13, AX) \
+	CHI(out, 192, R13, AX, BX) \
+	IOTA(out)
+
+// func keccakF1600(a *[25]uint64)
+TEXT ·keccakF1600(SB), NOSPLIT, $216-8
+	MOVQ a+0(FP), DI
+	LEAQ 0(SP), SI
+	LEAQ ·roundConstants(SB), AX
+	MOVQ AX, rc
+	MOVQ $12, pairs
+
+loop:
+	ROUND(DI, SI)
+	ROUND(SI, DI)
+	DECQ pairs
+	JNZ  loop
+	RET
@@ -0,0 +1,7 @@
+//go:build !amd64 || purego
+
+package keccak
+
+func keccakF1600(a *[25]uint64) {
+	keccakF1600Generic(a)
+}
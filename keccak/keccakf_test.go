@@ -0,0 +1,48 @@
+package keccak
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestKeccakF1600(t *testing.T) {
+	// The first lanes of Keccak-f[1600] applied to the zero state, from
+	// the intermediate values published by the Keccak team.
+	want := [...]uint64{0xF1258F7940E1DDE7, 0x84D5CCF933C0478A, 0xD598261EA65AA9EE, 0xBD1547306F80494D}
+
+	var a, b [25]uint64
+	keccakF1600(&a)
+	keccakF1600Generic(&b)
+	if (a != b) || ([4]uint64(a[:4]) != want) {
+		t.Errorf("Expected %x", want)
+		t.Errorf("Got %x, %x", a[:4], b[:4])
+	}
+
+	r := rand.New(rand.NewSource(5))
+	for i := 0; i < 1000; i++ {
+		for u := range a {
+			a[u] = r.Uint64()
+		}
+		b = a
+
+		keccakF1600(&a)
+		keccakF1600Generic(&b)
+		if a != b {
+			t.Fatalf("Expected %x\nGot %x", b, a)
+		}
+	}
+}
+
+func BenchmarkKeccakF1600(b *testing.B) {
+	var a [25]uint64
+	for i := 0; i < b.N; i++ {
+		keccakF1600(&a)
+	}
+}
+
+func BenchmarkKeccakF1600Generic(b *testing.B) {
+	var a [25]uint64
+	for i := 0; i < b.N; i++ {
+		keccakF1600Generic(&a)
+	}
+}
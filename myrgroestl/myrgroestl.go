@@ -0,0 +1,60 @@
+// Package myrgroestl implements Myriad-Groestl, the Groestl-based
+// proof-of-work hash of Myriadcoin. It is SHA-256 applied to the
+// Groestl-512 hash of the block header.
+//
+// Like other Bitcoin-derived chains, Myriadcoin treats hashes as
+// little-endian numbers when comparing them with targets, so they are
+// conventionally displayed with their bytes reversed.
+package myrgroestl
+
+import (
+	"crypto/sha256"
+	"hash"
+
+	"github.com/DeedleFake/crypto/groestl512"
+)
+
+// Size is the size of a Myriad-Groestl hash in bytes.
+const Size = sha256.Size
+
+// BlockSize is the block size of the underlying Groestl-512 hash.
+const BlockSize = groestl512.BlockSize
+
+type digest struct {
+	groestl *groestl512.Digest
+}
+
+// New returns a new hash.Hash that computes Myriad-Groestl hashes of
+// the data written to it.
+func New() hash.Hash {
+	return &digest{groestl: groestl512.New().(*groestl512.Digest)}
+}
+
+func (d *digest) Write(data []byte) (n int, err error) {
+	return d.groestl.Write(data)
+}
+
+func (d *digest) Sum(prev []byte) []byte {
+	var inner [groestl512.Size]byte
+	d.groestl.Sum(inner[:0])
+	out := sha256.Sum256(inner[:])
+	return append(prev, out[:]...)
+}
+
+func (d *digest) Reset() {
+	d.groestl.Reset()
+}
+
+func (d *digest) Size() int {
+	return Size
+}
+
+func (d *digest) BlockSize() int {
+	return BlockSize
+}
+
+// Sum returns the Myriad-Groestl hash of header.
+func Sum(header []byte) [Size]byte {
+	inner := groestl512.Sum(header)
+	return sha256.Sum256(inner[:])
+}
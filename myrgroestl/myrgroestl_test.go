@@ -0,0 +1,202 @@
+package myrgroestl
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/DeedleFake/crypto/groestlcoin"
+)
+
+type headerVector struct {
+	line   int
+	height string
+	header []byte
+	hash   string
+	pow    string
+}
+
+// readHeaders reads the header vectors in testdata/headers.txt. Each
+// vector is a block of Height, Header, Hash and PoW lines.
+func readHeaders(t *testing.T) []headerVector {
+	file, err := os.Open(filepath.Join("testdata", "headers.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var vectors []headerVector
+	s := bufio.NewScanner(file)
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		if (text == "") || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		key, val, ok := strings.Cut(text, "=")
+		if !ok {
+			t.Fatalf("line %v: expected key = value", line)
+		}
+		key, val = strings.TrimSpace(key), strings.TrimSpace(val)
+
+		if key == "Height" {
+			vectors = append(vectors, headerVector{line: line, height: val})
+			continue
+		}
+		if len(vectors) == 0 {
+			t.Fatalf("line %v: %v before Height", line, key)
+		}
+
+		v := &vectors[len(vectors)-1]
+		switch key {
+		case "Header":
+			v.header, err = hex.DecodeString(val)
+			if err != nil {
+				t.Fatalf("line %v: %v", line, err)
+			}
+		case "Hash":
+			v.hash = val
+		case "PoW":
+			v.pow = val
+		default:
+			t.Fatalf("line %v: unknown key %q", line, key)
+		}
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	return vectors
+}
+
+// Myriadcoin keeps the mining algorithm of a block in bits 9 to 11 of
+// its version.
+const (
+	versionAlgo    = 7 << 9
+	versionGroestl = 2 << 9
+)
+
+func reversed(b []byte) string {
+	b = slices.Clone(b)
+	slices.Reverse(b)
+	return hex.EncodeToString(b)
+}
+
+func TestHeaders(t *testing.T) {
+	vectors := readHeaders(t)
+	if len(vectors) == 0 {
+		t.Fatal("No vectors")
+	}
+
+	for _, v := range vectors {
+		if len(v.header) != 80 {
+			t.Errorf("Block %v (line %v): header is %v bytes", v.height, v.line, len(v.header))
+			continue
+		}
+
+		first := sha256.Sum256(v.header)
+		second := sha256.Sum256(first[:])
+		if hash := reversed(second[:]); hash != v.hash {
+			t.Errorf("Block %v (line %v): block hash is %v, expected %v", v.height, v.line, hash, v.hash)
+		}
+
+		pow := Sum(v.header)
+		if s := reversed(pow[:]); s != v.pow {
+			t.Errorf("Block %v (line %v): expected %v", v.height, v.line, v.pow)
+			t.Errorf("Block %v (line %v): got %v", v.height, v.line, s)
+		}
+
+		if binary.LittleEndian.Uint32(v.header)&versionAlgo == versionGroestl {
+			target, err := groestlcoin.Target(binary.LittleEndian.Uint32(v.header[72:]))
+			if err != nil {
+				t.Errorf("Block %v (line %v): %v", v.height, v.line, err)
+			} else if groestlcoin.Hash(pow).Big().Cmp(target) > 0 {
+				t.Errorf("Block %v (line %v): proof of work does not meet the target", v.height, v.line)
+			}
+		}
+
+		h := New()
+		for _, c := range v.header {
+			h.Write([]byte{c})
+		}
+		if !bytes.Equal(h.Sum(nil), pow[:]) {
+			t.Errorf("Block %v (line %v): streaming hash does not match Sum", v.height, v.line)
+		}
+	}
+}
+
+func TestTarget(t *testing.T) {
+	meets := func(header []byte) bool {
+		target, err := groestlcoin.Target(binary.LittleEndian.Uint32(header[72:]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return groestlcoin.Hash(Sum(header)).Big().Cmp(target) <= 0
+	}
+
+	// The genesis block was mined with SHA-256d, so its Myriad-Groestl
+	// hash is far above its target.
+	for _, v := range readHeaders(t) {
+		if (v.height == "0") && meets(v.header) {
+			t.Errorf("Genesis block unexpectedly meets its target")
+		}
+	}
+
+	header := make([]byte, 80)
+	binary.LittleEndian.PutUint32(header[72:], 0x2100ffff)
+	if !meets(header) {
+		t.Errorf("Hash does not meet the largest possible target")
+	}
+}
+
+// The expected hashes were computed by internal/refgen, with
+//
+//	go run ./internal/refgen myrgroestl
+func TestSum(t *testing.T) {
+	long := make([]byte, 200)
+	for i := range long {
+		long[i] = byte(i)
+	}
+
+	tests := []struct {
+		msg  []byte
+		hash string
+	}{
+		{nil, "99de071d22ba0f7e161f8e9233ef16fe2f571a998b2ca5daf308bfbe3e63c83a"},
+		{[]byte("abc"), "4d4be9637a1514a759a13c7dd3703d00a9501d107b733d0b4841707f0b3d4719"},
+		{long, "cc17519bb63389490c5140865cd96b01fc4965cdc797301003bd854e343ef4db"},
+	}
+
+	for _, test := range tests {
+		sum := Sum(test.msg)
+		if s := hex.EncodeToString(sum[:]); s != test.hash {
+			t.Errorf("Sum(%q) = %v, expected %v", test.msg, s, test.hash)
+		}
+
+		h := New()
+		h.Write(test.msg[:len(test.msg)/2])
+		h.Write(test.msg[len(test.msg)/2:])
+		if s := hex.EncodeToString(h.Sum(nil)); s != test.hash {
+			t.Errorf("Streaming %q = %v, expected %v", test.msg, s, test.hash)
+		}
+
+		// Reset must discard everything written so far.
+		h.Write([]byte("x"))
+		h.Reset()
+		h.Write(test.msg)
+		if s := hex.EncodeToString(h.Sum([]byte("prefix"))[6:]); s != test.hash {
+			t.Errorf("After Reset %q = %v, expected %v", test.msg, s, test.hash)
+		}
+	}
+
+	if (New().Size() != Size) || (New().BlockSize() != BlockSize) {
+		t.Errorf("Wrong Size or BlockSize")
+	}
+}
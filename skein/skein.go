@@ -0,0 +1,428 @@
+// Package skein implements the Skein hash function family, version 1.3
+// as submitted to the final round of the SHA-3 competition, and the
+// Threefish block cipher that it is built on.
+//
+// Besides plain hashing with Skein-256, Skein-512 and Skein-1024 at any
+// digest length, the optional inputs of the specification are
+// supported through Params: a key for Skein-MAC, personalization, a
+// public key, a nonce, and the tree hashing mode.
+package skein
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/DeedleFake/crypto/internal/partial"
+)
+
+const (
+	// Size256 is the size of the state and of a block of Skein-256,
+	// and of its default digest, in bytes.
+	Size256 = 32
+
+	// Size512 is the size of the state and of a block of Skein-512,
+	// and of its default digest, in bytes.
+	Size512 = 64
+
+	// Size1024 is the size of the state and of a block of Skein-1024,
+	// and of its default digest, in bytes.
+	Size1024 = 128
+)
+
+// The types of the UBI invocations, which go in the tweak.
+const (
+	typeKey   = 0
+	typeCfg   = 4
+	typePrs   = 8
+	typePK    = 12
+	typeNonce = 20
+	typeMsg   = 48
+	typeOut   = 63
+)
+
+// Flags and fields in the high word of the tweak.
+const (
+	tweakLevelShift = 48
+	tweakBitPad     = 1 << 55
+	tweakTypeShift  = 56
+	tweakFirst      = 1 << 62
+	tweakFinal      = 1 << 63
+)
+
+// Params holds the optional inputs of Skein. The zero value computes a
+// plain hash with a digest as long as the state.
+type Params struct {
+	// Bits is the length of the digest in bits. It must be a multiple
+	// of 8. If it is zero, the digest is as long as the state.
+	Bits int
+
+	// Key, if not empty, turns the hash into Skein-MAC.
+	Key []byte
+
+	// Personalization is an application-specific string, such as
+	// "20090101 user@example.com myapp/v1".
+	Personalization []byte
+
+	// PublicKey is the public key of a signature that the message is
+	// hashed for.
+	PublicKey []byte
+
+	// Nonce is a nonce for randomized hashing or for use in a stream
+	// cipher.
+	Nonce []byte
+
+	// Tree, if not nil, selects the tree hashing mode.
+	Tree *Tree
+}
+
+// Tree holds the parameters of the tree hashing mode. Sizes are given
+// as base-2 logarithms of a number of blocks.
+type Tree struct {
+	// LeafSize is the size of a leaf node. It must be at least 1.
+	LeafSize uint8
+
+	// FanOut is the size of every other node. It must be at least 1.
+	FanOut uint8
+
+	// MaxHeight is the maximum height of the tree. It must be at
+	// least 2.
+	MaxHeight uint8
+}
+
+// maxTreeSize is the largest tree node size, so that the number of
+// bytes in a node fits in a uint64.
+const maxTreeSize = 56
+
+// Digest is the state of a Skein hash. It implements hash.Hash. The
+// zero value is ready to use and computes Skein-512-512 hashes. A
+// Digest for any other state size, digest length or Params must come
+// from its constructor.
+type Digest struct {
+	// words is the size of the state in 64-bit words and size the
+	// length of the digest in bytes. Both are zero until the zero
+	// Digest is first used.
+	words int
+	size  int
+
+	// iv is the chaining value after all of the inputs that come
+	// before the message.
+	iv [16]uint64
+
+	msg ubi
+
+	// tree is only used in the tree hashing mode, in which case msg is
+	// not.
+	tree *tree
+
+	// partial is what WriteBits has left of a partially written byte.
+	partial partial.Byte
+}
+
+var (
+	_ hash.Cloner     = (*Digest)(nil)
+	_ io.StringWriter = (*Digest)(nil)
+	_ io.ByteWriter   = (*Digest)(nil)
+)
+
+// New256 returns a new hash.Hash that computes Skein-256-256 hashes.
+func New256() hash.Hash {
+	return newDigest(Size256, &Params{})
+}
+
+// New512 returns a new hash.Hash that computes Skein-512-512 hashes.
+func New512() hash.Hash {
+	return newDigest(Size512, &Params{})
+}
+
+// New512_256 returns a new hash.Hash that computes Skein-512-256
+// hashes.
+func New512_256() hash.Hash {
+	return newDigest(Size512, &Params{Bits: 256})
+}
+
+// New1024 returns a new hash.Hash that computes Skein-1024-1024
+// hashes.
+func New1024() hash.Hash {
+	return newDigest(Size1024, &Params{})
+}
+
+// NewSize returns a new hash.Hash that computes Skein hashes with a
+// state of the given number of bits, which must be 256, 512 or 1024,
+// and a digest of the given number of bits, which must be a positive
+// multiple of 8.
+func NewSize(state, bits int) (hash.Hash, error) {
+	if bits <= 0 {
+		return nil, fmt.Errorf("skein: invalid digest length: %v bits", bits)
+	}
+	return NewParams(state, &Params{Bits: bits})
+}
+
+// NewMAC returns a new hash.Hash that computes Skein-MAC with the given
+// key. The state and digest lengths are as for NewSize.
+func NewMAC(state, bits int, key []byte) (hash.Hash, error) {
+	if bits <= 0 {
+		return nil, fmt.Errorf("skein: invalid digest length: %v bits", bits)
+	}
+	return NewParams(state, &Params{Bits: bits, Key: key})
+}
+
+// NewParams returns a new hash.Hash that computes Skein hashes with a
+// state of the given number of bits, which must be 256, 512 or 1024,
+// and the optional inputs in p.
+func NewParams(state int, p *Params) (hash.Hash, error) {
+	switch state {
+	case Size256 * 8, Size512 * 8, Size1024 * 8:
+	default:
+		return nil, fmt.Errorf("skein: invalid state size: %v bits", state)
+	}
+	if (p.Bits < 0) || (p.Bits%8 != 0) {
+		return nil, fmt.Errorf("skein: invalid digest length: %v bits", p.Bits)
+	}
+	if t := p.Tree; t != nil {
+		if (t.LeafSize < 1) || (t.LeafSize > maxTreeSize) || (t.FanOut < 1) || (t.FanOut > maxTreeSize) || (t.MaxHeight < 2) {
+			return nil, fmt.Errorf("skein: invalid tree parameters: %+v", *t)
+		}
+	}
+
+	return newDigest(state/8, p), nil
+}
+
+func newDigest(size int, p *Params) *Digest {
+	ctx := &Digest{words: size / 8, size: size}
+	if p.Bits != 0 {
+		ctx.size = p.Bits / 8
+	}
+
+	var u ubi
+	if len(p.Key) > 0 {
+		u.init(ctx.words, &ctx.iv, typeKey, 0, 0)
+		u.write(p.Key)
+		ctx.iv = u.final(false)
+	}
+
+	var cfg [32]byte
+	copy(cfg[:], "SHA3")
+	binary.LittleEndian.PutUint16(cfg[4:], 1)
+	binary.LittleEndian.PutUint64(cfg[8:], uint64(ctx.size)*8)
+	if t := p.Tree; t != nil {
+		cfg[16], cfg[17], cfg[18] = t.LeafSize, t.FanOut, t.MaxHeight
+		ctx.tree = &tree{params: *t}
+	}
+	u.init(ctx.words, &ctx.iv, typeCfg, 0, 0)
+	u.write(cfg[:])
+	ctx.iv = u.final(false)
+
+	for _, arg := range []struct {
+		typ  uint64
+		data []byte
+	}{
+		{typ: typePrs, data: p.Personalization},
+		{typ: typePK, data: p.PublicKey},
+		{typ: typeNonce, data: p.Nonce},
+	} {
+		if len(arg.data) == 0 {
+			continue
+		}
+		u.init(ctx.words, &ctx.iv, arg.typ, 0, 0)
+		u.write(arg.data)
+		ctx.iv = u.final(false)
+	}
+
+	ctx.Reset()
+	return ctx
+}
+
+// init sets up the zero Digest to compute Skein-512-512 hashes. It
+// does nothing to a Digest that is already set up.
+func (ctx *Digest) init() {
+	if ctx.words == 0 {
+		*ctx = *newDigest(Size512, &Params{})
+	}
+}
+
+func (ctx *Digest) Write(data []byte) (n int, err error) {
+	ctx.init()
+	n = len(data)
+
+	var buf [Size1024]byte
+	for len(data) > 0 {
+		var chunk []byte
+		chunk, data = ctx.partial.Next(buf[:], data)
+		ctx.write(chunk)
+	}
+
+	return n, nil
+}
+
+// WriteString adds the bytes of s to the running hash without
+// converting it to a byte slice first. It implements io.StringWriter.
+func (ctx *Digest) WriteString(s string) (n int, err error) {
+	ctx.init()
+	n = len(s)
+
+	var buf [Size1024]byte
+	for len(s) > 0 {
+		var chunk []byte
+		chunk, s = ctx.partial.NextString(buf[:], s)
+		ctx.write(chunk)
+	}
+
+	return n, nil
+}
+
+// WriteByte adds c to the running hash. It implements io.ByteWriter.
+func (ctx *Digest) WriteByte(c byte) error {
+	buf := [1]byte{c}
+	ctx.Write(buf[:])
+	return nil
+}
+
+// WriteBits adds the first bits bits of data to the running hash.
+// Bits are taken from the most significant end of each byte. Unlike
+// Write, the message does not need to be a whole number of bytes long,
+// and calls to WriteBits and Write can be freely interleaved. It
+// panics if bits is greater than 8*len(data).
+func (ctx *Digest) WriteBits(data []byte, bits uint64) {
+	full := bits / 8
+	ctx.Write(data[:full])
+
+	if rem := uint(bits % 8); rem != 0 {
+		if b, ok := ctx.partial.Add(data[full], rem); ok {
+			ctx.write([]byte{b})
+		}
+	}
+}
+
+func (ctx *Digest) write(data []byte) {
+	if ctx.tree != nil {
+		ctx.tree.write(ctx, 1, data)
+		return
+	}
+	ctx.msg.write(data)
+}
+
+func (ctx *Digest) close(dst []byte) {
+	// A message that does not end on a byte boundary is padded with a
+	// one bit and zeros, which is flagged in the tweak.
+	bitPad := ctx.partial.N != 0
+	if bitPad {
+		ctx.write([]byte{ctx.partial.Padded()})
+	}
+
+	var g [16]uint64
+	if ctx.tree != nil {
+		g = ctx.tree.final(ctx, bitPad)
+	} else {
+		g = ctx.msg.final(bitPad)
+	}
+
+	output(ctx.words, &g, dst)
+}
+
+// output fills dst with the output function, which runs UBI in counter
+// mode over the final chaining value g.
+func output(words int, g *[16]uint64, dst []byte) {
+	var ctr [8]byte
+	var out [Size1024]byte
+	for i := uint64(0); len(dst) > 0; i++ {
+		var u ubi
+		u.init(words, g, typeOut, 0, 0)
+		binary.LittleEndian.PutUint64(ctr[:], i)
+		u.write(ctr[:])
+		h := u.final(false)
+
+		for j := range words {
+			binary.LittleEndian.PutUint64(out[j<<3:], h[j])
+		}
+		dst = dst[copy(dst, out[:words*8]):]
+	}
+}
+
+func (ctx *Digest) Sum(prev []byte) []byte {
+	// Finalize a copy so that the running hash can keep being written
+	// to after a call to Sum.
+	c := ctx.clone()
+	c.init()
+	out := append(prev, make([]byte, c.size)...)
+	c.close(out[len(prev):])
+	return out
+}
+
+func (ctx *Digest) clone() *Digest {
+	c := *ctx
+	if c.tree != nil {
+		t := *c.tree
+		t.levels = append([]level(nil), t.levels...)
+		c.tree = &t
+	}
+	return &c
+}
+
+// Clone returns a copy of the running hash that can be written to and
+// summed independently of ctx. It implements hash.Cloner and never
+// returns an error.
+func (ctx *Digest) Clone() (hash.Cloner, error) {
+	return ctx.clone(), nil
+}
+
+// Reset resets the hash to the state after the key and the other
+// optional inputs, which are kept.
+func (ctx *Digest) Reset() {
+	if ctx.words == 0 {
+		return
+	}
+
+	ctx.msg.init(ctx.words, &ctx.iv, typeMsg, 0, 0)
+	if ctx.tree != nil {
+		ctx.tree.levels = ctx.tree.levels[:0]
+	}
+	ctx.partial = partial.Byte{}
+}
+
+func (ctx *Digest) Size() int {
+	if ctx.words == 0 {
+		return Size512
+	}
+	return ctx.size
+}
+
+func (ctx *Digest) BlockSize() int {
+	if ctx.words == 0 {
+		return Size512
+	}
+	return ctx.words * 8
+}
+
+// Sum256 returns the Skein-256-256 hash of data.
+func Sum256(data []byte) (out [Size256]byte) {
+	ctx := newDigest(Size256, &Params{})
+	ctx.Write(data)
+	ctx.close(out[:])
+	return out
+}
+
+// Sum512 returns the Skein-512-512 hash of data.
+func Sum512(data []byte) (out [Size512]byte) {
+	ctx := newDigest(Size512, &Params{})
+	ctx.Write(data)
+	ctx.close(out[:])
+	return out
+}
+
+// Sum512_256 returns the Skein-512-256 hash of data.
+func Sum512_256(data []byte) (out [32]byte) {
+	ctx := newDigest(Size512, &Params{Bits: 256})
+	ctx.Write(data)
+	ctx.close(out[:])
+	return out
+}
+
+// Sum1024 returns the Skein-1024-1024 hash of data.
+func Sum1024(data []byte) (out [Size1024]byte) {
+	ctx := newDigest(Size1024, &Params{})
+	ctx.Write(data)
+	ctx.close(out[:])
+	return out
+}
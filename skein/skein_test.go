@@ -0,0 +1,652 @@
+package skein
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"path/filepath"
+	"testing"
+
+	"github.com/DeedleFake/crypto/internal/hashtest"
+	"github.com/DeedleFake/crypto/internal/kat"
+)
+
+// down returns n bytes counting down from 0xFF, as used by the examples
+// in the specification.
+func down(n int) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(0xFF - i)
+	}
+	return b
+}
+
+// The vectors with messages counting down are from appendix C of the
+// Skein 1.3 specification.
+var sumTests = []struct {
+	name    string
+	newHash func() hash.Hash
+	sum     func([]byte) []byte
+	in      []byte
+	out     string
+}{
+	{
+		name:    "256/Empty",
+		newHash: New256,
+		sum:     func(in []byte) []byte { s := Sum256(in); return s[:] },
+		out:     "c8877087da56e072870daa843f176e9453115929094c3a40c463a196c29bf7ba",
+	},
+	{
+		name:    "256/1",
+		newHash: New256,
+		sum:     func(in []byte) []byte { s := Sum256(in); return s[:] },
+		in:      down(1),
+		out:     "0b98dcd198ea0e50a7a244c444e25c23da30c10fc9a1f270a6637f1f34e67ed2",
+	},
+	{
+		name:    "256/32",
+		newHash: New256,
+		sum:     func(in []byte) []byte { s := Sum256(in); return s[:] },
+		in:      down(32),
+		out:     "8d0fa4ef777fd759dfd4044e6f6a5ac3c774aec943dcfc07927b723b5dbf408b",
+	},
+	{
+		name:    "256/64",
+		newHash: New256,
+		sum:     func(in []byte) []byte { s := Sum256(in); return s[:] },
+		in:      down(64),
+		out:     "df28e916630d0b44c4a849dc9a02f07a07cb30f732318256b15d865ac4ae162f",
+	},
+	{
+		name:    "512-256/Empty",
+		newHash: New512_256,
+		sum:     func(in []byte) []byte { s := Sum512_256(in); return s[:] },
+		out:     "39ccc4554a8b31853b9de7a1fe638a24cce6b35a55f2431009e18780335d2621",
+	},
+	{
+		name:    "512/Empty",
+		newHash: New512,
+		sum:     func(in []byte) []byte { s := Sum512(in); return s[:] },
+		out: "bc5b4c50925519c290cc634277ae3d6257212395cba733bbad37a4af0fa06af4" +
+			"1fca7903d06564fea7a2d3730dbdb80c1f85562dfcc070334ea4d1d9e72cba7a",
+	},
+	{
+		name:    "512/1",
+		newHash: New512,
+		sum:     func(in []byte) []byte { s := Sum512(in); return s[:] },
+		in:      down(1),
+		out: "71b7bce6fe6452227b9ced6014249e5bf9a9754c3ad618ccc4e0aae16b316cc8" +
+			"ca698d864307ed3e80b6ef1570812ac5272dc409b5a012df2a579102f340617a",
+	},
+	{
+		name:    "512/64",
+		newHash: New512,
+		sum:     func(in []byte) []byte { s := Sum512(in); return s[:] },
+		in:      down(64),
+		out: "45863ba3be0c4dfc27e75d358496f4ac9a736a505d9313b42b2f5eada79fc17f" +
+			"63861e947afb1d056aa199575ad3f8c9a3cc1780b5e5fa4cae050e989876625b",
+	},
+	{
+		name:    "512/128",
+		newHash: New512,
+		sum:     func(in []byte) []byte { s := Sum512(in); return s[:] },
+		in:      down(128),
+		out: "91cca510c263c4ddd010530a33073309628631f308747e1bcbaa90e451cab92e" +
+			"5188087af4188773a332303e6667a7a210856f742139000071f48e8ba2a5adb7",
+	},
+	{
+		name:    "1024/1",
+		newHash: New1024,
+		sum:     func(in []byte) []byte { s := Sum1024(in); return s[:] },
+		in:      down(1),
+		out: "e62c05802ea0152407cdd8787fda9e35703de862a4fbc119cff8590afe79250b" +
+			"ccc8b3faf1bd2422ab5c0d263fb2f8afb3f796f048000381531b6f00d85161bc" +
+			"0fff4bef2486b1ebcd3773fabf50ad4ad5639af9040e3f29c6c931301bf79832" +
+			"e9da09857e831e82ef8b4691c235656515d437d2bda33bcec001c67ffde15ba8",
+	},
+	{
+		name:    "1024/128",
+		newHash: New1024,
+		sum:     func(in []byte) []byte { s := Sum1024(in); return s[:] },
+		in:      down(128),
+		out: "1f3e02c46fb80a3fcd2dfbbc7c173800b40c60c2354af551189ebf433c3d85f9" +
+			"ff1803e6d920493179ed7ae7fce69c3581a5a2f82d3e0c7a295574d0cd7d217c" +
+			"484d2f6313d59a7718ead07d0729c24851d7e7d2491b902d489194e6b7d369db" +
+			"0ab7aa106f0ee0a39a42efc54f18d93776080985f907574f995ec6a37153a578",
+	},
+}
+
+func TestSum(t *testing.T) {
+	for _, test := range sumTests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			h := test.newHash()
+			h.Write(test.in)
+			if got := fmt.Sprintf("%x", h.Sum(nil)); got != test.out {
+				t.Errorf("Expected %v", test.out)
+				t.Errorf("Got %v", got)
+			}
+			if got := fmt.Sprintf("%x", test.sum(test.in)); got != test.out {
+				t.Errorf("Sum = %v", got)
+			}
+		})
+	}
+}
+
+// The golden vectors are from skein_golden_kat.txt in the Skein
+// submission package, as reproduced in the tests of
+// github.com/aead/skein. The messages and keys are prefixes of the
+// random data used there, and the vectors that are already in sumTests
+// are left out.
+var (
+	goldenMsg, _ = hex.DecodeString(
+		"fbd17c26b61a82e12e125f0d459b96c91ab4837dff22b39b78439430cdfc5dc8" +
+			"78bb393a1a5f79bef30995a85a12923339ba8ab7d8fc6dc5fec6f4ed22c122bb" +
+			"e7eb61981892966de5cef576f71fc7a80d14dab2d0c03940b95b9fb3a727c66a" +
+			"6e1ff0dc311b9aa21a3054484802154c1826c2a27a0914152aeb76f1168d4410")
+	goldenMACMsg, _ = hex.DecodeString(
+		"d3090c72167517f7c7ad82a70c2fd3f6443f608301591e598eadb195e8357135" +
+			"ba26fede2ee187417f816048d00fc23512737a2113709a77e4170c49a94b7fdf" +
+			"f45ff579a72287743102e7766c35ca5abc5dfe2f63a1e726ce5fbd2926db03a2" +
+			"dd18b03fc1508a9aac45eb362440203a323e09edee6324ee2e37b4432c1867ed")
+	goldenKey, _ = hex.DecodeString(
+		"cb41f1706cde09651203c2d0efbaddf847a0d315cb2e53ff8bac41da0002672e" +
+			"920244c66e02d5f0dad3e94c42bb65f0d14157decf4105ef5609d5b0984457c1" +
+			"935df3061ff06e9f204192ba11e5bb2cac0430c1c370cb3d113fea5ec1021eb8" +
+			"75e5946d7a96ac69a1626c6206b7252736f24253c9ee9b85eb852dfc81463134" +
+			"6c")
+)
+
+var goldenTests = []struct {
+	state, bits int
+	key         []byte
+	in          []byte
+	out         string
+}{
+	{
+		state: 256,
+		bits:  160,
+		in:    goldenMsg[:128],
+		out:   "0cd491b7715704c3a15a45a1ca8d93f8f646d3a1",
+	},
+	{
+		state: 256,
+		bits:  224,
+		in:    goldenMsg[:128],
+		out:   "afd1e2d0f5b6cd4e1f8b3935fa2497d27ee97e72060adac099543487",
+	},
+	{
+		state: 256,
+		bits:  256,
+		in:    goldenMsg[:128],
+		out:   "4de6fe2bfdaa3717a4261030ef0e044ced9225d066354610842a24a3eafd1dcf",
+	},
+	{
+		state: 256,
+		bits:  384,
+		in:    goldenMsg[:128],
+		out: "954620fb31e8b782a2794c6542827026fe069d715df04261629fcbe81d7d529b" +
+			"95ba021fa4239fb00afaa75f5fd8e78b",
+	},
+	{
+		state: 256,
+		bits:  512,
+		in:    goldenMsg[:128],
+		out: "51347e27c7eabba514959f899a6715ef6ad5cf01c23170590e6a8af399470bf9" +
+			"0ea7409960a708c1dbaa90e86389df254abc763639bb8cdf7fb663b29d9557c3",
+	},
+	{
+		state: 256,
+		bits:  256,
+		key:   goldenKey[:16],
+		out:   "886e4efefc15f06aa298963971d7a25398fffe5681c84db39bd00851f64ae29d",
+	},
+	{
+		state: 256,
+		bits:  256,
+		key:   goldenKey[:0],
+		in:    goldenMACMsg[:24],
+		out:   "dcbd5c8bd09021a840b0ea4aaa2f06e67d7eebe882b49de6b74bdc56b60cc48f",
+	},
+	{
+		state: 256,
+		bits:  384,
+		key:   goldenKey[:33],
+		in:    goldenMACMsg[:128],
+		out: "96e6cebb23573d0a70ce36a67aa05d2403148093f25c695e1254887cc97f9771" +
+			"d2518413af4286bf2a06b61a53f7fcec",
+	},
+	{
+		state: 512,
+		bits:  512,
+		in:    goldenMsg[:64],
+		out: "02d01535c2df280fde92146df054b0609273c73056c93b94b82f5e7dcc5be697" +
+			"9978c4be24331caa85d892d2e710c6c9b4904cd056a53547b866bee097c0fb17",
+	},
+	{
+		state: 512,
+		bits:  160,
+		in:    goldenMsg[:128],
+		out:   "ef03079d61b57c6047e15fa2b35b46fa24279539",
+	},
+	{
+		state: 512,
+		bits:  256,
+		in:    goldenMsg[:128],
+		out:   "809dd3f763a11af90912bbb92bc0d94361cbadab10142992000c88b4ceb88648",
+	},
+	{
+		state: 512,
+		bits:  384,
+		in:    goldenMsg[:128],
+		out: "825f5cbd5da8807a7b4d3e7bd9cd089ca3a256bcc064cd73a9355bf3ae67f2bf" +
+			"93ac7074b3b19907a0665ba3a878b262",
+	},
+	{
+		state: 512,
+		bits:  512,
+		in:    goldenMsg[:128],
+		out: "1a0d5abf4432e7c612d658f8dcfa35b0d1ab68b8d6bd4dd115c23cc57b5c5bcd" +
+			"de9bff0ece4208596e499f211bc07594d0cb6f3c12b0e110174b2a9b4b2cb6a9",
+	},
+	{
+		state: 512,
+		bits:  1024,
+		in:    goldenMsg[:128],
+		out: "8c25d314110d1c0d58054c96a19d571e26a45d5362aa8f47547e53e0be4a830a" +
+			"5f2c29ccd88e2185febad024a4696f2dbe8307dc150e7a58b3793b1a93fae252" +
+			"3e2d239c59a23a1cc127b3c481a9809162e60b4cb01c011b9630322c8fe9745d" +
+			"56d0f3aed54b3490578db4692901eafc1960c15359176a9c0990b32b8ca8f94b",
+	},
+	{
+		state: 512,
+		bits:  512,
+		key:   goldenKey[:0],
+		in:    goldenMACMsg[:4],
+		out: "1259afc2cb025eef2f681e128f889bbce57f9a502d57d1a17239a12e71603559" +
+			"16b72223790fd9a8b367ec96212a3ed239331ed72ef3deb17685a8d5fd75158d",
+	},
+	{
+		state: 512,
+		bits:  512,
+		key:   goldenKey[:64],
+		in:    goldenMACMsg[:16],
+		out: "478d7b6c0cc6e35d9ebbdedf39128e5a36585db6222891692d1747d401de34ce" +
+			"3db6fcbab6c968b7f2620f4a844a2903b547775579993736d2493a75ff6752a1",
+	},
+	{
+		state: 1024,
+		bits:  1024,
+		out: "0fff9563bb3279289227ac77d319b6fff8d7e9f09da1247b72a0a265cd6d2a62" +
+			"645ad547ed8193db48cff847c06494a03f55666d3b47eb4c20456c9373c86297" +
+			"d630d5578ebd34cb40991578f9f52b18003efa35d3da6553ff35db91b81ab890" +
+			"bec1b189b7f52cb2a783ebb7d823d725b0b4a71f6824e88f68f982eefc6d19c6",
+	},
+	{
+		state: 1024,
+		bits:  160,
+		in:    goldenMsg[:128],
+		out:   "2e6a4cbf2ef05ea9c24b93e8d1de732ddf2739eb",
+	},
+	{
+		state: 1024,
+		bits:  224,
+		in:    goldenMsg[:128],
+		out:   "1d6de19f37f7a3c265440eecb4b9fbd3300bb5ac60895cfc0d4d3c72",
+	},
+	{
+		state: 1024,
+		bits:  256,
+		in:    goldenMsg[:128],
+		out:   "986a4d472b123e8148731a8eac9db23325f0058c4ccbc44a5bb6fe3a8db672d7",
+	},
+	{
+		state: 1024,
+		bits:  384,
+		in:    goldenMsg[:128],
+		out: "9c3d0648c11f31c18395d5e6c8ebd73f43d189843fc45235e2c35e345e12d62b" +
+			"c21a41f65896ddc6a04969654c2e2ce9",
+	},
+	{
+		state: 1024,
+		bits:  1024,
+		in:    goldenMsg[:128],
+		out: "96ca81f586c825d0360aef5acaec49ad55289e1797072eee198b64f349ce65b6" +
+			"e6ed804fe38f05135fe769cc56240ddda5098f620865ce4a4278c77fa2ec6bc3" +
+			"1c0f354ca78c7ca81665bfcc5dc54258c3b8310ed421d9157f36c093814d9b25" +
+			"103d83e0ddd89c52d0050e13a64c6140e6388431961685734b1f138fe2243086",
+	},
+	{
+		state: 1024,
+		bits:  2056,
+		in:    goldenMsg[:128],
+		out: "56a0cab1ad315859da7a6cfc35807cbfe039af06ca4b8671c053360bda0b17c1" +
+			"4a9eb5eb2abb01b0db3f45c03cd30c69d7c1b70c5c9ef74c06fb3aef0c843ce9" +
+			"b4c1ba2294ddb5c71cab692cedc1e6f908c471b38c0c583418b55aefddfe08ab" +
+			"a4055d0d19edb5ccba16c3e288471efe463e6bf6cc346cc74f6c013e0293e6df" +
+			"d2e4aa66a92242fd395b6d91aaad5a071c449d77ea00e44ecc75073890ac50d4" +
+			"f4210e8c9da45385a46d214a0fccc131db3f842f955e6e76ac311b3bf439dd51" +
+			"9bedd691785adf7540f3163ad1216cf2adb7d4bf40d93be3184aef51b651ca26" +
+			"c7ec44073f43ad689d269ea9ff02f8d2c8932fe6ced0292f97fb5f07ca276d6b" +
+			"43",
+	},
+	{
+		state: 1024,
+		bits:  1024,
+		key:   goldenKey[:128],
+		out: "bcf37b3459c88959d6b6b58b2bfe142cef60c6f4ec56b0702480d7893a2b0595" +
+			"aa354e87102a788b61996b9cbc1eade7dafbf6581135572c09666d844c90f066" +
+			"b800fc4f5fd1737644894ef7d588afc5c38f5d920bdbd3b738aea3a3267d161e" +
+			"d65284d1f57da73b68817e17e381ca169115152b869c66b812bb9a84275303f0",
+	},
+	{
+		state: 1024,
+		bits:  1024,
+		key:   goldenKey[:129],
+		in:    goldenMACMsg[:4],
+		out: "df0596e5808835a3e304aa27923db05f61dac57c0696a1d19abf188e70aa9dbc" +
+			"c659e9510f7c9a37fbc025bd4e5ea293e78ed7838dd0b08864e8ad40ddb3a880" +
+			"31ebefc21572a89960d1916107a7da7ac0c067e34ec46a86a29ca63fa250bd39" +
+			"8eb32ec1ed0f8ac8329f26da018b029e41e2e58d1dfc44de81615e6c987ed9c9",
+	},
+	{
+		state: 1024,
+		bits:  1024,
+		key:   goldenKey[:0],
+		in:    goldenMACMsg[:1],
+		out: "f1fbb54f260d0fb9d49a29eec184b265edc663668a9720aa61661e43659b3cd6" +
+			"97c700ce1e3e535e0c69801220b5da975138e7cb1ec8d8e3018f078a32cae28b" +
+			"c189350b68ee67785623b372ef7811bb06ba6c67e5847596fb72f2b51994eb8e" +
+			"e079b960e228f7026e1bfe8cea0877496f986fd13db82e132cc45f70bb010f27",
+	},
+}
+
+func TestGolden(t *testing.T) {
+	for _, test := range goldenTests {
+		test := test
+		t.Run(fmt.Sprintf("%v-%v/%v/%v", test.state, test.bits, len(test.key), len(test.in)), func(t *testing.T) {
+			h, err := NewParams(test.state, &Params{Bits: test.bits, Key: test.key})
+			if err != nil {
+				t.Fatal(err)
+			}
+			h.Write(test.in)
+			if got := fmt.Sprintf("%x", h.Sum(nil)); got != test.out {
+				t.Errorf("Expected %v", test.out)
+				t.Errorf("Got %v", got)
+			}
+		})
+	}
+}
+
+func TestNewSize(t *testing.T) {
+	for _, state := range []int{256, 512, 1024} {
+		for _, bits := range []int{8, 160, state, 2*state + 8} {
+			h, err := NewSize(state, bits)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if h.Size() != bits/8 {
+				t.Errorf("Skein-%v-%v: Size = %v", state, bits, h.Size())
+			}
+			if h.BlockSize() != state/8 {
+				t.Errorf("Skein-%v-%v: BlockSize = %v", state, bits, h.BlockSize())
+			}
+			if got := len(h.Sum(nil)); got != bits/8 {
+				t.Errorf("Skein-%v-%v: digest is %v bytes", state, bits, got)
+			}
+		}
+	}
+
+	// A longer digest is not an extension of a shorter one, as the
+	// length is part of the configuration.
+	h, _ := NewSize(512, 1024)
+	long := h.Sum(nil)
+	short := Sum512(nil)
+	if bytes.Equal(long[:Size512], short[:]) {
+		t.Errorf("Skein-512-1024 starts with Skein-512-512")
+	}
+
+	for _, test := range []struct{ state, bits int }{
+		{state: 128, bits: 256},
+		{state: 2048, bits: 256},
+		{state: 512, bits: 0},
+		{state: 512, bits: -8},
+		{state: 512, bits: 100},
+	} {
+		if _, err := NewSize(test.state, test.bits); err == nil {
+			t.Errorf("Skein-%v-%v: expected error", test.state, test.bits)
+		}
+	}
+}
+
+func TestParams(t *testing.T) {
+	in := []byte("The quick brown fox jumps over the lazy dog")
+	plain := Sum512(in)
+
+	params := []Params{
+		{Key: []byte("key")},
+		{Personalization: []byte("20240101 user@example.com myapp/v1")},
+		{PublicKey: bytes.Repeat([]byte{1}, 32)},
+		{Nonce: []byte("nonce")},
+		{Key: []byte("key"), Personalization: []byte("p"), PublicKey: []byte("pk"), Nonce: []byte("n")},
+		{Tree: &Tree{LeafSize: 1, FanOut: 1, MaxHeight: 2}},
+	}
+
+	seen := make(map[string]int)
+	for i, p := range params {
+		h, err := NewParams(512, &p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		h.Write([]byte("garbage"))
+		h.Reset()
+		h.Write(in)
+		sum := h.Sum(nil)
+
+		if bytes.Equal(sum, plain[:]) {
+			t.Errorf("Params %v: same as plain hash", i)
+		}
+		if j, ok := seen[string(sum)]; ok {
+			t.Errorf("Params %v: same as params %v", i, j)
+		}
+		seen[string(sum)] = i
+
+		// Reset must keep the inputs that come before the message.
+		h2, _ := NewParams(512, &p)
+		h2.Write(in)
+		if !bytes.Equal(h2.Sum(nil), sum) {
+			t.Errorf("Params %v: Reset lost an input", i)
+		}
+	}
+
+	// An empty key is the same as none.
+	h, err := NewMAC(512, 512, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Write(in)
+	if !bytes.Equal(h.Sum(nil), plain[:]) {
+		t.Errorf("Empty key does not match unkeyed hash")
+	}
+
+	// NewMAC is a shortcut for a key in Params.
+	mac, err := NewMAC(256, 128, []byte("key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	withParams, _ := NewParams(256, &Params{Bits: 128, Key: []byte("key")})
+	mac.Write(in)
+	withParams.Write(in)
+	if !bytes.Equal(mac.Sum(nil), withParams.Sum(nil)) {
+		t.Errorf("NewMAC does not match NewParams")
+	}
+}
+
+func TestParamsErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		state int
+		p     Params
+	}{
+		{name: "State", state: 384},
+		{name: "Bits", state: 512, p: Params{Bits: 12}},
+		{name: "NegativeBits", state: 512, p: Params{Bits: -512}},
+		{name: "LeafSize", state: 512, p: Params{Tree: &Tree{LeafSize: 0, FanOut: 1, MaxHeight: 2}}},
+		{name: "FanOut", state: 512, p: Params{Tree: &Tree{LeafSize: 1, FanOut: 0, MaxHeight: 2}}},
+		{name: "MaxHeight", state: 512, p: Params{Tree: &Tree{LeafSize: 1, FanOut: 1, MaxHeight: 1}}},
+		{name: "Overflow", state: 512, p: Params{Tree: &Tree{LeafSize: 60, FanOut: 1, MaxHeight: 2}}},
+	}
+
+	for _, test := range tests {
+		if _, err := NewParams(test.state, &test.p); err == nil {
+			t.Errorf("%v: expected error", test.name)
+		}
+	}
+}
+
+// treeSum computes a tree hash the way that section 3.5.6 of the
+// specification describes it, one whole level at a time.
+func treeSum(state int, p *Params, msg []byte) []byte {
+	ctx := newDigest(state/8, p)
+	words, nb := ctx.words, ctx.words*8
+
+	var g [16]uint64
+	m := msg
+	for l := 1; ; l++ {
+		size := nb << p.Tree.FanOut
+		if l == 1 {
+			size = nb << p.Tree.LeafSize
+		}
+
+		var next []byte
+		for i := 0; (i == 0) || (i*size < len(m)); i++ {
+			var u ubi
+			u.init(words, &ctx.iv, typeMsg, uint64(l), uint64(i*size))
+			u.write(m[i*size : min(len(m), (i+1)*size)])
+			h := u.final(false)
+			for _, v := range h[:words] {
+				next = binary.LittleEndian.AppendUint64(next, v)
+			}
+		}
+
+		if len(next) == nb {
+			for i := range words {
+				g[i] = binary.LittleEndian.Uint64(next[i<<3:])
+			}
+			break
+		}
+		if l == int(p.Tree.MaxHeight)-1 {
+			var u ubi
+			u.init(words, &ctx.iv, typeMsg, uint64(p.Tree.MaxHeight), 0)
+			u.write(next)
+			g = u.final(false)
+			break
+		}
+		m = next
+	}
+
+	out := make([]byte, ctx.size)
+	output(words, &g, out)
+	return out
+}
+
+func TestTree(t *testing.T) {
+	trees := []struct {
+		state int
+		tree  Tree
+	}{
+		{state: 256, tree: Tree{LeafSize: 1, FanOut: 1, MaxHeight: 2}},
+		{state: 256, tree: Tree{LeafSize: 1, FanOut: 1, MaxHeight: 3}},
+		{state: 512, tree: Tree{LeafSize: 1, FanOut: 1, MaxHeight: 255}},
+		{state: 512, tree: Tree{LeafSize: 2, FanOut: 1, MaxHeight: 4}},
+		{state: 512, tree: Tree{LeafSize: 1, FanOut: 2, MaxHeight: 255}},
+		{state: 1024, tree: Tree{LeafSize: 3, FanOut: 2, MaxHeight: 3}},
+	}
+
+	in := hashtest.Seq(3000)
+	for _, tree := range trees {
+		tree := tree
+		t.Run(fmt.Sprintf("%v/%v-%v-%v", tree.state, tree.tree.LeafSize, tree.tree.FanOut, tree.tree.MaxHeight), func(t *testing.T) {
+			p := &Params{Tree: &tree.tree}
+			h, err := NewParams(tree.state, p)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			nb := tree.state / 8
+			for _, n := range []int{0, 1, nb, 2 * nb, 2*nb + 1, 4 * nb, 7*nb - 1, 8 * nb, 9 * nb, 1000, 3000} {
+				want := treeSum(tree.state, p, in[:n])
+
+				// Write in uneven pieces to cross the node boundaries at
+				// different points.
+				h.Reset()
+				for i := 0; i < n; i += 37 {
+					h.Write(in[i:min(n, i+37)])
+				}
+				if got := h.Sum(nil); !bytes.Equal(got, want) {
+					t.Errorf("%v bytes: expected %x", n, want)
+					t.Errorf("%v bytes: got %x", n, got)
+				}
+			}
+		})
+	}
+}
+
+func TestTreeClone(t *testing.T) {
+	p := &Params{Tree: &Tree{LeafSize: 1, FanOut: 1, MaxHeight: 8}}
+	h, _ := NewParams(256, p)
+	h.Write(hashtest.Seq(500))
+
+	c, err := h.(*Digest).Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.(*Digest).Write(hashtest.Seq(300))
+
+	if want := treeSum(256, p, hashtest.Seq(500)); !bytes.Equal(h.Sum(nil), want) {
+		t.Errorf("Clone modified the original")
+	}
+	if want := treeSum(256, p, append(hashtest.Seq(500), hashtest.Seq(300)...)); !bytes.Equal(c.(*Digest).Sum(nil), want) {
+		t.Errorf("Clone did not continue the hash")
+	}
+}
+
+func TestHash(t *testing.T) {
+	for _, newHash := range []func() hash.Hash{New256, New512, New1024} {
+		h := newHash()
+		t.Run(fmt.Sprint(h.BlockSize()*8), func(t *testing.T) {
+			hashtest.Run(t, func() hashtest.Hash { return newHash().(*Digest) })
+		})
+	}
+}
+
+func TestZeroDigest(t *testing.T) {
+	hashtest.Run(t, func() hashtest.Hash { return new(Digest) })
+
+	var d Digest
+	if (d.Size() != Size512) || (d.BlockSize() != Size512) {
+		t.Errorf("BlockSize = %v, Size = %v", d.BlockSize(), d.Size())
+	}
+
+	d.Reset()
+	d.WriteByte('a')
+	d.Write([]byte("bc"))
+	if got, want := d.Sum(nil), Sum512([]byte("abc")); !bytes.Equal(got, want[:]) {
+		t.Errorf("Expected %x", want)
+		t.Errorf("Got %x", got)
+	}
+}
+
+// The digests in the KAT file are the published Skein-512 short
+// message results.
+func TestKAT(t *testing.T) {
+	kat.Run(t, filepath.Join("testdata", "ShortMsgKAT_512.txt"), func() kat.BitHash {
+		return New512().(*Digest)
+	})
+}
+
+func BenchmarkWrite(b *testing.B) {
+	hashtest.Benchmark(b, New512)
+}
@@ -0,0 +1,130 @@
+package skein
+
+import (
+	"crypto/cipher"
+	"encoding/binary"
+	"fmt"
+)
+
+// TweakSize is the size of a Threefish tweak in bytes.
+const TweakSize = 16
+
+// c240 is the constant that the key schedule XORs into the parity
+// word of the key, as of version 1.3 of the specification.
+const c240 = 0x1BD11BDAA9FC1A22
+
+// Threefish is the Threefish tweakable block cipher with a fixed key.
+// The block size, which is the same as the key size, is 32, 64 or 128
+// bytes for Threefish-256, Threefish-512 and Threefish-1024. It
+// implements cipher.Block.
+type Threefish struct {
+	words int
+	k     [17]uint64
+	t     [3]uint64
+}
+
+var _ cipher.Block = (*Threefish)(nil)
+
+// NewThreefish returns a Threefish cipher with the given key, the
+// length of which selects the variant, and tweak. The tweak must be
+// TweakSize bytes long, or nil to use a tweak of all zeros.
+func NewThreefish(key, tweak []byte) (*Threefish, error) {
+	switch len(key) {
+	case Size256, Size512, Size1024:
+	default:
+		return nil, fmt.Errorf("skein: invalid Threefish key length: %v bytes", len(key))
+	}
+
+	c := &Threefish{words: len(key) / 8}
+	for i := range c.words {
+		c.k[i] = binary.LittleEndian.Uint64(key[i<<3:])
+	}
+	setParity(c.k[:c.words+1])
+
+	if tweak != nil {
+		if err := c.SetTweak(tweak); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// SetTweak changes the tweak used by subsequent calls to Encrypt and
+// Decrypt. The tweak must be TweakSize bytes long.
+func (c *Threefish) SetTweak(tweak []byte) error {
+	if len(tweak) != TweakSize {
+		return fmt.Errorf("skein: invalid Threefish tweak length: %v bytes", len(tweak))
+	}
+
+	c.t[0] = binary.LittleEndian.Uint64(tweak)
+	c.t[1] = binary.LittleEndian.Uint64(tweak[8:])
+	c.t[2] = c.t[0] ^ c.t[1]
+	return nil
+}
+
+func (c *Threefish) BlockSize() int {
+	return c.words * 8
+}
+
+func (c *Threefish) Encrypt(dst, src []byte) {
+	c.crypt(dst, src, encrypt)
+}
+
+func (c *Threefish) Decrypt(dst, src []byte) {
+	c.crypt(dst, src, decrypt)
+}
+
+func (c *Threefish) crypt(dst, src []byte, f func(int, *[17]uint64, *[3]uint64, *[16]uint64)) {
+	n := c.BlockSize()
+	if len(src) < n {
+		panic("skein: input not full block")
+	}
+	if len(dst) < n {
+		panic("skein: output not full block")
+	}
+
+	var x [16]uint64
+	for i := range c.words {
+		x[i] = binary.LittleEndian.Uint64(src[i<<3:])
+	}
+	f(c.words, &c.k, &c.t, &x)
+	for i := range c.words {
+		binary.LittleEndian.PutUint64(dst[i<<3:], x[i])
+	}
+}
+
+// setParity sets the last word of k to the parity word of the key in
+// the words before it.
+func setParity(k []uint64) {
+	p := uint64(c240)
+	for _, v := range k[:len(k)-1] {
+		p ^= v
+	}
+	k[len(k)-1] = p
+}
+
+// encrypt encrypts the first words words of x with the Threefish
+// variant of that width. k must already hold the parity word.
+func encrypt(words int, k *[17]uint64, t *[3]uint64, x *[16]uint64) {
+	switch words {
+	case 4:
+		encrypt256((*[5]uint64)(k[:5]), t, (*[4]uint64)(x[:4]))
+	case 8:
+		encrypt512((*[9]uint64)(k[:9]), t, (*[8]uint64)(x[:8]))
+	default:
+		encrypt1024(k, t, x)
+	}
+}
+
+// decrypt is the inverse of encrypt.
+func decrypt(words int, k *[17]uint64, t *[3]uint64, x *[16]uint64) {
+	switch words {
+	case 4:
+		decrypt256((*[5]uint64)(k[:5]), t, (*[4]uint64)(x[:4]))
+	case 8:
+		decrypt512((*[9]uint64)(k[:9]), t, (*[8]uint64)(x[:8]))
+	default:
+		decrypt1024(k, t, x)
+	}
+}
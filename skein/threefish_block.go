@@ -0,0 +1,751 @@
+package skein
+
+import "math/bits"
+
+// encrypt256 encrypts x in place with Threefish-256. k holds the key
+// followed by its parity word and t the tweak followed by the XOR of
+// its two words.
+func encrypt256(k *[5]uint64, t *[3]uint64, x *[4]uint64) {
+	x0, x1, x2, x3 := x[0], x[1], x[2], x[3]
+
+	for s := uint64(0); s < 18; s += 2 {
+		x0 += k[s%5]
+		x1 += k[(s+1)%5] + t[s%3]
+		x2 += k[(s+2)%5] + t[(s+1)%3]
+		x3 += k[(s+3)%5] + s
+
+		x0 += x1
+		x1 = bits.RotateLeft64(x1, 14) ^ x0
+		x2 += x3
+		x3 = bits.RotateLeft64(x3, 16) ^ x2
+
+		x0 += x3
+		x3 = bits.RotateLeft64(x3, 52) ^ x0
+		x2 += x1
+		x1 = bits.RotateLeft64(x1, 57) ^ x2
+
+		x0 += x1
+		x1 = bits.RotateLeft64(x1, 23) ^ x0
+		x2 += x3
+		x3 = bits.RotateLeft64(x3, 40) ^ x2
+
+		x0 += x3
+		x3 = bits.RotateLeft64(x3, 5) ^ x0
+		x2 += x1
+		x1 = bits.RotateLeft64(x1, 37) ^ x2
+
+		x0 += k[(s+1)%5]
+		x1 += k[(s+2)%5] + t[(s+1)%3]
+		x2 += k[(s+3)%5] + t[(s+2)%3]
+		x3 += k[(s+4)%5] + s + 1
+
+		x0 += x1
+		x1 = bits.RotateLeft64(x1, 25) ^ x0
+		x2 += x3
+		x3 = bits.RotateLeft64(x3, 33) ^ x2
+
+		x0 += x3
+		x3 = bits.RotateLeft64(x3, 46) ^ x0
+		x2 += x1
+		x1 = bits.RotateLeft64(x1, 12) ^ x2
+
+		x0 += x1
+		x1 = bits.RotateLeft64(x1, 58) ^ x0
+		x2 += x3
+		x3 = bits.RotateLeft64(x3, 22) ^ x2
+
+		x0 += x3
+		x3 = bits.RotateLeft64(x3, 32) ^ x0
+		x2 += x1
+		x1 = bits.RotateLeft64(x1, 32) ^ x2
+	}
+
+	x0 += k[3]
+	x1 += k[4] + t[0]
+	x2 += k[0] + t[1]
+	x3 += k[1] + 18
+
+	x[0], x[1], x[2], x[3] = x0, x1, x2, x3
+}
+
+// decrypt256 is the inverse of encrypt256.
+func decrypt256(k *[5]uint64, t *[3]uint64, x *[4]uint64) {
+	x0, x1, x2, x3 := x[0], x[1], x[2], x[3]
+
+	x0 -= k[3]
+	x1 -= k[4] + t[0]
+	x2 -= k[0] + t[1]
+	x3 -= k[1] + 18
+
+	for r := 9; r > 0; r-- {
+		s := uint64(2*r - 2)
+
+		x1 = bits.RotateLeft64(x1^x2, -32)
+		x2 -= x1
+		x3 = bits.RotateLeft64(x3^x0, -32)
+		x0 -= x3
+
+		x3 = bits.RotateLeft64(x3^x2, -22)
+		x2 -= x3
+		x1 = bits.RotateLeft64(x1^x0, -58)
+		x0 -= x1
+
+		x1 = bits.RotateLeft64(x1^x2, -12)
+		x2 -= x1
+		x3 = bits.RotateLeft64(x3^x0, -46)
+		x0 -= x3
+
+		x3 = bits.RotateLeft64(x3^x2, -33)
+		x2 -= x3
+		x1 = bits.RotateLeft64(x1^x0, -25)
+		x0 -= x1
+
+		x0 -= k[(s+1)%5]
+		x1 -= k[(s+2)%5] + t[(s+1)%3]
+		x2 -= k[(s+3)%5] + t[(s+2)%3]
+		x3 -= k[(s+4)%5] + s + 1
+
+		x1 = bits.RotateLeft64(x1^x2, -37)
+		x2 -= x1
+		x3 = bits.RotateLeft64(x3^x0, -5)
+		x0 -= x3
+
+		x3 = bits.RotateLeft64(x3^x2, -40)
+		x2 -= x3
+		x1 = bits.RotateLeft64(x1^x0, -23)
+		x0 -= x1
+
+		x1 = bits.RotateLeft64(x1^x2, -57)
+		x2 -= x1
+		x3 = bits.RotateLeft64(x3^x0, -52)
+		x0 -= x3
+
+		x3 = bits.RotateLeft64(x3^x2, -16)
+		x2 -= x3
+		x1 = bits.RotateLeft64(x1^x0, -14)
+		x0 -= x1
+
+		x0 -= k[s%5]
+		x1 -= k[(s+1)%5] + t[s%3]
+		x2 -= k[(s+2)%5] + t[(s+1)%3]
+		x3 -= k[(s+3)%5] + s
+	}
+
+	x[0], x[1], x[2], x[3] = x0, x1, x2, x3
+}
+
+// encrypt512 encrypts x in place with Threefish-512. k holds the key
+// followed by its parity word and t the tweak followed by the XOR of
+// its two words.
+func encrypt512(k *[9]uint64, t *[3]uint64, x *[8]uint64) {
+	x0, x1, x2, x3, x4, x5, x6, x7 := x[0], x[1], x[2], x[3], x[4], x[5], x[6], x[7]
+
+	for s := uint64(0); s < 18; s += 2 {
+		x0 += k[s%9]
+		x1 += k[(s+1)%9]
+		x2 += k[(s+2)%9]
+		x3 += k[(s+3)%9]
+		x4 += k[(s+4)%9]
+		x5 += k[(s+5)%9] + t[s%3]
+		x6 += k[(s+6)%9] + t[(s+1)%3]
+		x7 += k[(s+7)%9] + s
+
+		x0 += x1
+		x1 = bits.RotateLeft64(x1, 46) ^ x0
+		x2 += x3
+		x3 = bits.RotateLeft64(x3, 36) ^ x2
+		x4 += x5
+		x5 = bits.RotateLeft64(x5, 19) ^ x4
+		x6 += x7
+		x7 = bits.RotateLeft64(x7, 37) ^ x6
+
+		x2 += x1
+		x1 = bits.RotateLeft64(x1, 33) ^ x2
+		x4 += x7
+		x7 = bits.RotateLeft64(x7, 27) ^ x4
+		x6 += x5
+		x5 = bits.RotateLeft64(x5, 14) ^ x6
+		x0 += x3
+		x3 = bits.RotateLeft64(x3, 42) ^ x0
+
+		x4 += x1
+		x1 = bits.RotateLeft64(x1, 17) ^ x4
+		x6 += x3
+		x3 = bits.RotateLeft64(x3, 49) ^ x6
+		x0 += x5
+		x5 = bits.RotateLeft64(x5, 36) ^ x0
+		x2 += x7
+		x7 = bits.RotateLeft64(x7, 39) ^ x2
+
+		x6 += x1
+		x1 = bits.RotateLeft64(x1, 44) ^ x6
+		x0 += x7
+		x7 = bits.RotateLeft64(x7, 9) ^ x0
+		x2 += x5
+		x5 = bits.RotateLeft64(x5, 54) ^ x2
+		x4 += x3
+		x3 = bits.RotateLeft64(x3, 56) ^ x4
+
+		x0 += k[(s+1)%9]
+		x1 += k[(s+2)%9]
+		x2 += k[(s+3)%9]
+		x3 += k[(s+4)%9]
+		x4 += k[(s+5)%9]
+		x5 += k[(s+6)%9] + t[(s+1)%3]
+		x6 += k[(s+7)%9] + t[(s+2)%3]
+		x7 += k[(s+8)%9] + s + 1
+
+		x0 += x1
+		x1 = bits.RotateLeft64(x1, 39) ^ x0
+		x2 += x3
+		x3 = bits.RotateLeft64(x3, 30) ^ x2
+		x4 += x5
+		x5 = bits.RotateLeft64(x5, 34) ^ x4
+		x6 += x7
+		x7 = bits.RotateLeft64(x7, 24) ^ x6
+
+		x2 += x1
+		x1 = bits.RotateLeft64(x1, 13) ^ x2
+		x4 += x7
+		x7 = bits.RotateLeft64(x7, 50) ^ x4
+		x6 += x5
+		x5 = bits.RotateLeft64(x5, 10) ^ x6
+		x0 += x3
+		x3 = bits.RotateLeft64(x3, 17) ^ x0
+
+		x4 += x1
+		x1 = bits.RotateLeft64(x1, 25) ^ x4
+		x6 += x3
+		x3 = bits.RotateLeft64(x3, 29) ^ x6
+		x0 += x5
+		x5 = bits.RotateLeft64(x5, 39) ^ x0
+		x2 += x7
+		x7 = bits.RotateLeft64(x7, 43) ^ x2
+
+		x6 += x1
+		x1 = bits.RotateLeft64(x1, 8) ^ x6
+		x0 += x7
+		x7 = bits.RotateLeft64(x7, 35) ^ x0
+		x2 += x5
+		x5 = bits.RotateLeft64(x5, 56) ^ x2
+		x4 += x3
+		x3 = bits.RotateLeft64(x3, 22) ^ x4
+	}
+
+	x0 += k[0]
+	x1 += k[1]
+	x2 += k[2]
+	x3 += k[3]
+	x4 += k[4]
+	x5 += k[5] + t[0]
+	x6 += k[6] + t[1]
+	x7 += k[7] + 18
+
+	x[0], x[1], x[2], x[3], x[4], x[5], x[6], x[7] = x0, x1, x2, x3, x4, x5, x6, x7
+}
+
+// decrypt512 is the inverse of encrypt512.
+func decrypt512(k *[9]uint64, t *[3]uint64, x *[8]uint64) {
+	x0, x1, x2, x3, x4, x5, x6, x7 := x[0], x[1], x[2], x[3], x[4], x[5], x[6], x[7]
+
+	x0 -= k[0]
+	x1 -= k[1]
+	x2 -= k[2]
+	x3 -= k[3]
+	x4 -= k[4]
+	x5 -= k[5] + t[0]
+	x6 -= k[6] + t[1]
+	x7 -= k[7] + 18
+
+	for r := 9; r > 0; r-- {
+		s := uint64(2*r - 2)
+
+		x3 = bits.RotateLeft64(x3^x4, -22)
+		x4 -= x3
+		x5 = bits.RotateLeft64(x5^x2, -56)
+		x2 -= x5
+		x7 = bits.RotateLeft64(x7^x0, -35)
+		x0 -= x7
+		x1 = bits.RotateLeft64(x1^x6, -8)
+		x6 -= x1
+
+		x7 = bits.RotateLeft64(x7^x2, -43)
+		x2 -= x7
+		x5 = bits.RotateLeft64(x5^x0, -39)
+		x0 -= x5
+		x3 = bits.RotateLeft64(x3^x6, -29)
+		x6 -= x3
+		x1 = bits.RotateLeft64(x1^x4, -25)
+		x4 -= x1
+
+		x3 = bits.RotateLeft64(x3^x0, -17)
+		x0 -= x3
+		x5 = bits.RotateLeft64(x5^x6, -10)
+		x6 -= x5
+		x7 = bits.RotateLeft64(x7^x4, -50)
+		x4 -= x7
+		x1 = bits.RotateLeft64(x1^x2, -13)
+		x2 -= x1
+
+		x7 = bits.RotateLeft64(x7^x6, -24)
+		x6 -= x7
+		x5 = bits.RotateLeft64(x5^x4, -34)
+		x4 -= x5
+		x3 = bits.RotateLeft64(x3^x2, -30)
+		x2 -= x3
+		x1 = bits.RotateLeft64(x1^x0, -39)
+		x0 -= x1
+
+		x0 -= k[(s+1)%9]
+		x1 -= k[(s+2)%9]
+		x2 -= k[(s+3)%9]
+		x3 -= k[(s+4)%9]
+		x4 -= k[(s+5)%9]
+		x5 -= k[(s+6)%9] + t[(s+1)%3]
+		x6 -= k[(s+7)%9] + t[(s+2)%3]
+		x7 -= k[(s+8)%9] + s + 1
+
+		x3 = bits.RotateLeft64(x3^x4, -56)
+		x4 -= x3
+		x5 = bits.RotateLeft64(x5^x2, -54)
+		x2 -= x5
+		x7 = bits.RotateLeft64(x7^x0, -9)
+		x0 -= x7
+		x1 = bits.RotateLeft64(x1^x6, -44)
+		x6 -= x1
+
+		x7 = bits.RotateLeft64(x7^x2, -39)
+		x2 -= x7
+		x5 = bits.RotateLeft64(x5^x0, -36)
+		x0 -= x5
+		x3 = bits.RotateLeft64(x3^x6, -49)
+		x6 -= x3
+		x1 = bits.RotateLeft64(x1^x4, -17)
+		x4 -= x1
+
+		x3 = bits.RotateLeft64(x3^x0, -42)
+		x0 -= x3
+		x5 = bits.RotateLeft64(x5^x6, -14)
+		x6 -= x5
+		x7 = bits.RotateLeft64(x7^x4, -27)
+		x4 -= x7
+		x1 = bits.RotateLeft64(x1^x2, -33)
+		x2 -= x1
+
+		x7 = bits.RotateLeft64(x7^x6, -37)
+		x6 -= x7
+		x5 = bits.RotateLeft64(x5^x4, -19)
+		x4 -= x5
+		x3 = bits.RotateLeft64(x3^x2, -36)
+		x2 -= x3
+		x1 = bits.RotateLeft64(x1^x0, -46)
+		x0 -= x1
+
+		x0 -= k[s%9]
+		x1 -= k[(s+1)%9]
+		x2 -= k[(s+2)%9]
+		x3 -= k[(s+3)%9]
+		x4 -= k[(s+4)%9]
+		x5 -= k[(s+5)%9] + t[s%3]
+		x6 -= k[(s+6)%9] + t[(s+1)%3]
+		x7 -= k[(s+7)%9] + s
+	}
+
+	x[0], x[1], x[2], x[3], x[4], x[5], x[6], x[7] = x0, x1, x2, x3, x4, x5, x6, x7
+}
+
+// encrypt1024 encrypts x in place with Threefish-1024. k holds the key
+// followed by its parity word and t the tweak followed by the XOR of
+// its two words.
+func encrypt1024(k *[17]uint64, t *[3]uint64, x *[16]uint64) {
+	x0, x1, x2, x3, x4, x5, x6, x7, x8, x9, x10, x11, x12, x13, x14, x15 := x[0], x[1], x[2], x[3], x[4], x[5], x[6], x[7], x[8], x[9], x[10], x[11], x[12], x[13], x[14], x[15]
+
+	for s := uint64(0); s < 20; s += 2 {
+		x0 += k[s%17]
+		x1 += k[(s+1)%17]
+		x2 += k[(s+2)%17]
+		x3 += k[(s+3)%17]
+		x4 += k[(s+4)%17]
+		x5 += k[(s+5)%17]
+		x6 += k[(s+6)%17]
+		x7 += k[(s+7)%17]
+		x8 += k[(s+8)%17]
+		x9 += k[(s+9)%17]
+		x10 += k[(s+10)%17]
+		x11 += k[(s+11)%17]
+		x12 += k[(s+12)%17]
+		x13 += k[(s+13)%17] + t[s%3]
+		x14 += k[(s+14)%17] + t[(s+1)%3]
+		x15 += k[(s+15)%17] + s
+
+		x0 += x1
+		x1 = bits.RotateLeft64(x1, 24) ^ x0
+		x2 += x3
+		x3 = bits.RotateLeft64(x3, 13) ^ x2
+		x4 += x5
+		x5 = bits.RotateLeft64(x5, 8) ^ x4
+		x6 += x7
+		x7 = bits.RotateLeft64(x7, 47) ^ x6
+		x8 += x9
+		x9 = bits.RotateLeft64(x9, 8) ^ x8
+		x10 += x11
+		x11 = bits.RotateLeft64(x11, 17) ^ x10
+		x12 += x13
+		x13 = bits.RotateLeft64(x13, 22) ^ x12
+		x14 += x15
+		x15 = bits.RotateLeft64(x15, 37) ^ x14
+
+		x0 += x9
+		x9 = bits.RotateLeft64(x9, 38) ^ x0
+		x2 += x13
+		x13 = bits.RotateLeft64(x13, 19) ^ x2
+		x6 += x11
+		x11 = bits.RotateLeft64(x11, 10) ^ x6
+		x4 += x15
+		x15 = bits.RotateLeft64(x15, 55) ^ x4
+		x10 += x7
+		x7 = bits.RotateLeft64(x7, 49) ^ x10
+		x12 += x3
+		x3 = bits.RotateLeft64(x3, 18) ^ x12
+		x14 += x5
+		x5 = bits.RotateLeft64(x5, 23) ^ x14
+		x8 += x1
+		x1 = bits.RotateLeft64(x1, 52) ^ x8
+
+		x0 += x7
+		x7 = bits.RotateLeft64(x7, 33) ^ x0
+		x2 += x5
+		x5 = bits.RotateLeft64(x5, 4) ^ x2
+		x4 += x3
+		x3 = bits.RotateLeft64(x3, 51) ^ x4
+		x6 += x1
+		x1 = bits.RotateLeft64(x1, 13) ^ x6
+		x12 += x15
+		x15 = bits.RotateLeft64(x15, 34) ^ x12
+		x14 += x13
+		x13 = bits.RotateLeft64(x13, 41) ^ x14
+		x8 += x11
+		x11 = bits.RotateLeft64(x11, 59) ^ x8
+		x10 += x9
+		x9 = bits.RotateLeft64(x9, 17) ^ x10
+
+		x0 += x15
+		x15 = bits.RotateLeft64(x15, 5) ^ x0
+		x2 += x11
+		x11 = bits.RotateLeft64(x11, 20) ^ x2
+		x6 += x13
+		x13 = bits.RotateLeft64(x13, 48) ^ x6
+		x4 += x9
+		x9 = bits.RotateLeft64(x9, 41) ^ x4
+		x14 += x1
+		x1 = bits.RotateLeft64(x1, 47) ^ x14
+		x8 += x5
+		x5 = bits.RotateLeft64(x5, 28) ^ x8
+		x10 += x3
+		x3 = bits.RotateLeft64(x3, 16) ^ x10
+		x12 += x7
+		x7 = bits.RotateLeft64(x7, 25) ^ x12
+
+		x0 += k[(s+1)%17]
+		x1 += k[(s+2)%17]
+		x2 += k[(s+3)%17]
+		x3 += k[(s+4)%17]
+		x4 += k[(s+5)%17]
+		x5 += k[(s+6)%17]
+		x6 += k[(s+7)%17]
+		x7 += k[(s+8)%17]
+		x8 += k[(s+9)%17]
+		x9 += k[(s+10)%17]
+		x10 += k[(s+11)%17]
+		x11 += k[(s+12)%17]
+		x12 += k[(s+13)%17]
+		x13 += k[(s+14)%17] + t[(s+1)%3]
+		x14 += k[(s+15)%17] + t[(s+2)%3]
+		x15 += k[(s+16)%17] + s + 1
+
+		x0 += x1
+		x1 = bits.RotateLeft64(x1, 41) ^ x0
+		x2 += x3
+		x3 = bits.RotateLeft64(x3, 9) ^ x2
+		x4 += x5
+		x5 = bits.RotateLeft64(x5, 37) ^ x4
+		x6 += x7
+		x7 = bits.RotateLeft64(x7, 31) ^ x6
+		x8 += x9
+		x9 = bits.RotateLeft64(x9, 12) ^ x8
+		x10 += x11
+		x11 = bits.RotateLeft64(x11, 47) ^ x10
+		x12 += x13
+		x13 = bits.RotateLeft64(x13, 44) ^ x12
+		x14 += x15
+		x15 = bits.RotateLeft64(x15, 30) ^ x14
+
+		x0 += x9
+		x9 = bits.RotateLeft64(x9, 16) ^ x0
+		x2 += x13
+		x13 = bits.RotateLeft64(x13, 34) ^ x2
+		x6 += x11
+		x11 = bits.RotateLeft64(x11, 56) ^ x6
+		x4 += x15
+		x15 = bits.RotateLeft64(x15, 51) ^ x4
+		x10 += x7
+		x7 = bits.RotateLeft64(x7, 4) ^ x10
+		x12 += x3
+		x3 = bits.RotateLeft64(x3, 53) ^ x12
+		x14 += x5
+		x5 = bits.RotateLeft64(x5, 42) ^ x14
+		x8 += x1
+		x1 = bits.RotateLeft64(x1, 41) ^ x8
+
+		x0 += x7
+		x7 = bits.RotateLeft64(x7, 31) ^ x0
+		x2 += x5
+		x5 = bits.RotateLeft64(x5, 44) ^ x2
+		x4 += x3
+		x3 = bits.RotateLeft64(x3, 47) ^ x4
+		x6 += x1
+		x1 = bits.RotateLeft64(x1, 46) ^ x6
+		x12 += x15
+		x15 = bits.RotateLeft64(x15, 19) ^ x12
+		x14 += x13
+		x13 = bits.RotateLeft64(x13, 42) ^ x14
+		x8 += x11
+		x11 = bits.RotateLeft64(x11, 44) ^ x8
+		x10 += x9
+		x9 = bits.RotateLeft64(x9, 25) ^ x10
+
+		x0 += x15
+		x15 = bits.RotateLeft64(x15, 9) ^ x0
+		x2 += x11
+		x11 = bits.RotateLeft64(x11, 48) ^ x2
+		x6 += x13
+		x13 = bits.RotateLeft64(x13, 35) ^ x6
+		x4 += x9
+		x9 = bits.RotateLeft64(x9, 52) ^ x4
+		x14 += x1
+		x1 = bits.RotateLeft64(x1, 23) ^ x14
+		x8 += x5
+		x5 = bits.RotateLeft64(x5, 31) ^ x8
+		x10 += x3
+		x3 = bits.RotateLeft64(x3, 37) ^ x10
+		x12 += x7
+		x7 = bits.RotateLeft64(x7, 20) ^ x12
+	}
+
+	x0 += k[3]
+	x1 += k[4]
+	x2 += k[5]
+	x3 += k[6]
+	x4 += k[7]
+	x5 += k[8]
+	x6 += k[9]
+	x7 += k[10]
+	x8 += k[11]
+	x9 += k[12]
+	x10 += k[13]
+	x11 += k[14]
+	x12 += k[15]
+	x13 += k[16] + t[2]
+	x14 += k[0] + t[0]
+	x15 += k[1] + 20
+
+	x[0], x[1], x[2], x[3], x[4], x[5], x[6], x[7], x[8], x[9], x[10], x[11], x[12], x[13], x[14], x[15] = x0, x1, x2, x3, x4, x5, x6, x7, x8, x9, x10, x11, x12, x13, x14, x15
+}
+
+// decrypt1024 is the inverse of encrypt1024.
+func decrypt1024(k *[17]uint64, t *[3]uint64, x *[16]uint64) {
+	x0, x1, x2, x3, x4, x5, x6, x7, x8, x9, x10, x11, x12, x13, x14, x15 := x[0], x[1], x[2], x[3], x[4], x[5], x[6], x[7], x[8], x[9], x[10], x[11], x[12], x[13], x[14], x[15]
+
+	x0 -= k[3]
+	x1 -= k[4]
+	x2 -= k[5]
+	x3 -= k[6]
+	x4 -= k[7]
+	x5 -= k[8]
+	x6 -= k[9]
+	x7 -= k[10]
+	x8 -= k[11]
+	x9 -= k[12]
+	x10 -= k[13]
+	x11 -= k[14]
+	x12 -= k[15]
+	x13 -= k[16] + t[2]
+	x14 -= k[0] + t[0]
+	x15 -= k[1] + 20
+
+	for r := 10; r > 0; r-- {
+		s := uint64(2*r - 2)
+
+		x7 = bits.RotateLeft64(x7^x12, -20)
+		x12 -= x7
+		x3 = bits.RotateLeft64(x3^x10, -37)
+		x10 -= x3
+		x5 = bits.RotateLeft64(x5^x8, -31)
+		x8 -= x5
+		x1 = bits.RotateLeft64(x1^x14, -23)
+		x14 -= x1
+		x9 = bits.RotateLeft64(x9^x4, -52)
+		x4 -= x9
+		x13 = bits.RotateLeft64(x13^x6, -35)
+		x6 -= x13
+		x11 = bits.RotateLeft64(x11^x2, -48)
+		x2 -= x11
+		x15 = bits.RotateLeft64(x15^x0, -9)
+		x0 -= x15
+
+		x9 = bits.RotateLeft64(x9^x10, -25)
+		x10 -= x9
+		x11 = bits.RotateLeft64(x11^x8, -44)
+		x8 -= x11
+		x13 = bits.RotateLeft64(x13^x14, -42)
+		x14 -= x13
+		x15 = bits.RotateLeft64(x15^x12, -19)
+		x12 -= x15
+		x1 = bits.RotateLeft64(x1^x6, -46)
+		x6 -= x1
+		x3 = bits.RotateLeft64(x3^x4, -47)
+		x4 -= x3
+		x5 = bits.RotateLeft64(x5^x2, -44)
+		x2 -= x5
+		x7 = bits.RotateLeft64(x7^x0, -31)
+		x0 -= x7
+
+		x1 = bits.RotateLeft64(x1^x8, -41)
+		x8 -= x1
+		x5 = bits.RotateLeft64(x5^x14, -42)
+		x14 -= x5
+		x3 = bits.RotateLeft64(x3^x12, -53)
+		x12 -= x3
+		x7 = bits.RotateLeft64(x7^x10, -4)
+		x10 -= x7
+		x15 = bits.RotateLeft64(x15^x4, -51)
+		x4 -= x15
+		x11 = bits.RotateLeft64(x11^x6, -56)
+		x6 -= x11
+		x13 = bits.RotateLeft64(x13^x2, -34)
+		x2 -= x13
+		x9 = bits.RotateLeft64(x9^x0, -16)
+		x0 -= x9
+
+		x15 = bits.RotateLeft64(x15^x14, -30)
+		x14 -= x15
+		x13 = bits.RotateLeft64(x13^x12, -44)
+		x12 -= x13
+		x11 = bits.RotateLeft64(x11^x10, -47)
+		x10 -= x11
+		x9 = bits.RotateLeft64(x9^x8, -12)
+		x8 -= x9
+		x7 = bits.RotateLeft64(x7^x6, -31)
+		x6 -= x7
+		x5 = bits.RotateLeft64(x5^x4, -37)
+		x4 -= x5
+		x3 = bits.RotateLeft64(x3^x2, -9)
+		x2 -= x3
+		x1 = bits.RotateLeft64(x1^x0, -41)
+		x0 -= x1
+
+		x0 -= k[(s+1)%17]
+		x1 -= k[(s+2)%17]
+		x2 -= k[(s+3)%17]
+		x3 -= k[(s+4)%17]
+		x4 -= k[(s+5)%17]
+		x5 -= k[(s+6)%17]
+		x6 -= k[(s+7)%17]
+		x7 -= k[(s+8)%17]
+		x8 -= k[(s+9)%17]
+		x9 -= k[(s+10)%17]
+		x10 -= k[(s+11)%17]
+		x11 -= k[(s+12)%17]
+		x12 -= k[(s+13)%17]
+		x13 -= k[(s+14)%17] + t[(s+1)%3]
+		x14 -= k[(s+15)%17] + t[(s+2)%3]
+		x15 -= k[(s+16)%17] + s + 1
+
+		x7 = bits.RotateLeft64(x7^x12, -25)
+		x12 -= x7
+		x3 = bits.RotateLeft64(x3^x10, -16)
+		x10 -= x3
+		x5 = bits.RotateLeft64(x5^x8, -28)
+		x8 -= x5
+		x1 = bits.RotateLeft64(x1^x14, -47)
+		x14 -= x1
+		x9 = bits.RotateLeft64(x9^x4, -41)
+		x4 -= x9
+		x13 = bits.RotateLeft64(x13^x6, -48)
+		x6 -= x13
+		x11 = bits.RotateLeft64(x11^x2, -20)
+		x2 -= x11
+		x15 = bits.RotateLeft64(x15^x0, -5)
+		x0 -= x15
+
+		x9 = bits.RotateLeft64(x9^x10, -17)
+		x10 -= x9
+		x11 = bits.RotateLeft64(x11^x8, -59)
+		x8 -= x11
+		x13 = bits.RotateLeft64(x13^x14, -41)
+		x14 -= x13
+		x15 = bits.RotateLeft64(x15^x12, -34)
+		x12 -= x15
+		x1 = bits.RotateLeft64(x1^x6, -13)
+		x6 -= x1
+		x3 = bits.RotateLeft64(x3^x4, -51)
+		x4 -= x3
+		x5 = bits.RotateLeft64(x5^x2, -4)
+		x2 -= x5
+		x7 = bits.RotateLeft64(x7^x0, -33)
+		x0 -= x7
+
+		x1 = bits.RotateLeft64(x1^x8, -52)
+		x8 -= x1
+		x5 = bits.RotateLeft64(x5^x14, -23)
+		x14 -= x5
+		x3 = bits.RotateLeft64(x3^x12, -18)
+		x12 -= x3
+		x7 = bits.RotateLeft64(x7^x10, -49)
+		x10 -= x7
+		x15 = bits.RotateLeft64(x15^x4, -55)
+		x4 -= x15
+		x11 = bits.RotateLeft64(x11^x6, -10)
+		x6 -= x11
+		x13 = bits.RotateLeft64(x13^x2, -19)
+		x2 -= x13
+		x9 = bits.RotateLeft64(x9^x0, -38)
+		x0 -= x9
+
+		x15 = bits.RotateLeft64(x15^x14, -37)
+		x14 -= x15
+		x13 = bits.RotateLeft64(x13^x12, -22)
+		x12 -= x13
+		x11 = bits.RotateLeft64(x11^x10, -17)
+		x10 -= x11
+		x9 = bits.RotateLeft64(x9^x8, -8)
+		x8 -= x9
+		x7 = bits.RotateLeft64(x7^x6, -47)
+		x6 -= x7
+		x5 = bits.RotateLeft64(x5^x4, -8)
+		x4 -= x5
+		x3 = bits.RotateLeft64(x3^x2, -13)
+		x2 -= x3
+		x1 = bits.RotateLeft64(x1^x0, -24)
+		x0 -= x1
+
+		x0 -= k[s%17]
+		x1 -= k[(s+1)%17]
+		x2 -= k[(s+2)%17]
+		x3 -= k[(s+3)%17]
+		x4 -= k[(s+4)%17]
+		x5 -= k[(s+5)%17]
+		x6 -= k[(s+6)%17]
+		x7 -= k[(s+7)%17]
+		x8 -= k[(s+8)%17]
+		x9 -= k[(s+9)%17]
+		x10 -= k[(s+10)%17]
+		x11 -= k[(s+11)%17]
+		x12 -= k[(s+12)%17]
+		x13 -= k[(s+13)%17] + t[s%3]
+		x14 -= k[(s+14)%17] + t[(s+1)%3]
+		x15 -= k[(s+15)%17] + s
+	}
+
+	x[0], x[1], x[2], x[3], x[4], x[5], x[6], x[7], x[8], x[9], x[10], x[11], x[12], x[13], x[14], x[15] = x0, x1, x2, x3, x4, x5, x6, x7, x8, x9, x10, x11, x12, x13, x14, x15
+}
@@ -0,0 +1,153 @@
+package skein
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// The vectors are from appendix B of the Skein 1.3 specification.
+func TestThreefish(t *testing.T) {
+	tests := []struct {
+		name  string
+		size  int
+		zero  string
+		count string
+	}{
+		{
+			name:  "256",
+			size:  32,
+			zero:  "84da2a1f8beaee947066ae3e3103f1ad536db1f4a1192495116b9f3ce6133fd8",
+			count: "e0d091ff0eea8fdfc98192e62ed80ad59d865d08588df476657056b5955e97df",
+		},
+		{
+			name: "512",
+			size: 64,
+			zero: "b1a2bbc6ef6025bc40eb3822161f36e375d1bb0aee3186fbd19e47c5d479947b" +
+				"7bc2f8586e35f0cff7e7f03084b0b7b1f1ab3961a580a3e97eb41ea14a6d7bbe",
+			count: "e304439626d45a2cb401cad8d636249a6338330eb06d45dd8b36b90e97254779" +
+				"272a0a8d99463504784420ea18c9a725af11dffea10162348927673d5c1caf3d",
+		},
+		{
+			name: "1024",
+			size: 128,
+			zero: "f05c3d0a3d05b304f785ddc7d1e036015c8aa76e2f217b06c6e1544c0bc1a90d" +
+				"f0accb9473c24e0fd54fea68057f43329cb454761d6df5cf7b2e9b3614fbd5a2" +
+				"0b2e4760b40603540d82eabc5482c171c832afbe68406bc39500367a592943fa" +
+				"9a5b4a43286ca3c4cf46104b443143d560a4b230488311df4feef7e1dfe8391e",
+			count: "a6654ddbd73cc3b05dd777105aa849bce49372eaaffc5568d254771bab85531c" +
+				"94f780e7ffaae430d5d8af8c70eebbe1760f3b42b737a89cb363490d670314bd" +
+				"8aa41ee63c2e1f45fbd477922f8360b388d6125ea6c7af0ad7056d01796e90c8" +
+				"3313f4150a5716b30ed5f569288ae974ce2b4347926fce57de44512177dd7cde",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			// The second vector counts up from 0x10 in the key, from zero
+			// in the tweak and down from 0xFF in the plaintext.
+			key, tweak, pt := make([]byte, test.size), make([]byte, TweakSize), make([]byte, test.size)
+			for i := range key {
+				key[i] = byte(0x10 + i)
+				pt[i] = byte(0xFF - i)
+			}
+			for i := range tweak {
+				tweak[i] = byte(i)
+			}
+
+			for _, v := range []struct {
+				key, tweak, pt []byte
+				ct             string
+			}{
+				{key: make([]byte, test.size), pt: make([]byte, test.size), ct: test.zero},
+				{key: key, tweak: tweak, pt: pt, ct: test.count},
+			} {
+				c, err := NewThreefish(v.key, v.tweak)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if c.BlockSize() != test.size {
+					t.Errorf("BlockSize = %v", c.BlockSize())
+				}
+
+				ct := make([]byte, test.size)
+				c.Encrypt(ct, v.pt)
+				if got := hex.EncodeToString(ct); got != v.ct {
+					t.Errorf("Expected %v", v.ct)
+					t.Errorf("Got %v", got)
+				}
+
+				// Encrypt and Decrypt work in place too.
+				c.Decrypt(ct, ct)
+				if !bytes.Equal(ct, v.pt) {
+					t.Errorf("Decrypt = %x", ct)
+				}
+			}
+		})
+	}
+}
+
+func TestThreefishTweak(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, Size512)
+	tweak := []byte("0123456789abcdef")
+
+	a, err := NewThreefish(key, tweak)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewThreefish(key, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pt := make([]byte, Size512)
+	ca, cb := make([]byte, Size512), make([]byte, Size512)
+	a.Encrypt(ca, pt)
+	b.Encrypt(cb, pt)
+	if bytes.Equal(ca, cb) {
+		t.Fatalf("Tweak did not change the ciphertext")
+	}
+
+	if err := b.SetTweak(tweak); err != nil {
+		t.Fatal(err)
+	}
+	b.Encrypt(cb, pt)
+	if !bytes.Equal(ca, cb) {
+		t.Errorf("SetTweak: expected %x", ca)
+		t.Errorf("SetTweak: got %x", cb)
+	}
+}
+
+func TestThreefishErrors(t *testing.T) {
+	for _, n := range []int{0, 16, 48, 65, 256} {
+		if _, err := NewThreefish(make([]byte, n), nil); err == nil {
+			t.Errorf("Expected error for %v byte key", n)
+		}
+	}
+	if _, err := NewThreefish(make([]byte, Size256), make([]byte, TweakSize-1)); err == nil {
+		t.Errorf("Expected error for short tweak")
+	}
+
+	c, _ := NewThreefish(make([]byte, Size256), nil)
+	if err := c.SetTweak(make([]byte, TweakSize+1)); err == nil {
+		t.Errorf("Expected error for long tweak")
+	}
+
+	defer func() {
+		if r := recover(); (r == nil) || !strings.Contains(r.(string), "full block") {
+			t.Errorf("Expected panic for short input, got %v", r)
+		}
+	}()
+	c.Encrypt(make([]byte, Size256), make([]byte, Size256-1))
+}
+
+func BenchmarkThreefish512(b *testing.B) {
+	c, _ := NewThreefish(make([]byte, Size512), nil)
+	buf := make([]byte, Size512)
+	b.SetBytes(Size512)
+	for i := 0; i < b.N; i++ {
+		c.Encrypt(buf, buf)
+	}
+}
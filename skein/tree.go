@@ -0,0 +1,119 @@
+package skein
+
+import "encoding/binary"
+
+// tree is the state of the tree hashing mode. Each level of the tree
+// has at most one node in progress at any time.
+type tree struct {
+	params Tree
+	levels []level
+}
+
+// level is the state of one level of a tree. levels[0] holds the
+// leaves, which are at level 1 of the tree.
+type level struct {
+	// node is the node in progress and n the number of bytes written
+	// to it.
+	node ubi
+	n    uint64
+
+	// nodes is the number of nodes that have been finished.
+	nodes uint64
+
+	// first is the chaining value of the first node. Whether it is the
+	// result or belongs in the level above is not known until a second
+	// node is started.
+	first [16]uint64
+}
+
+// nodeSize returns the number of bytes in a node at level l, or zero
+// for the top level, the single node of which takes any number.
+func (t *tree) nodeSize(words int, l int) uint64 {
+	switch {
+	case l == int(t.params.MaxHeight):
+		return 0
+	case l == 1:
+		return uint64(words*8) << t.params.LeafSize
+	default:
+		return uint64(words*8) << t.params.FanOut
+	}
+}
+
+// level returns the state of level l, adding it if needed.
+func (t *tree) level(l int) *level {
+	for len(t.levels) < l {
+		t.levels = append(t.levels, level{})
+	}
+	return &t.levels[l-1]
+}
+
+// write adds data to the nodes at level l.
+func (t *tree) write(ctx *Digest, l int, data []byte) {
+	lv := t.level(l)
+	size := t.nodeSize(ctx.words, l)
+
+	for len(data) > 0 {
+		if (size != 0) && (lv.n == size) {
+			// Finishing a node can add a level and move the others.
+			t.finish(ctx, l, false)
+			lv = t.level(l)
+		}
+		if lv.n == 0 {
+			lv.node.init(ctx.words, &ctx.iv, typeMsg, uint64(l), lv.nodes*size)
+		}
+
+		c := uint64(len(data))
+		if size != 0 {
+			c = min(c, size-lv.n)
+		}
+		lv.node.write(data[:c])
+		lv.n += c
+		data = data[c:]
+	}
+}
+
+// finish finishes the node in progress at level l and passes its
+// chaining value on to the level above.
+func (t *tree) finish(ctx *Digest, l int, bitPad bool) {
+	lv := t.level(l)
+	h := lv.node.final(bitPad)
+	lv.n = 0
+	lv.nodes++
+
+	if lv.nodes == 1 {
+		lv.first = h
+		return
+	}
+	if lv.nodes == 2 {
+		t.writeChain(ctx, l+1, &lv.first)
+	}
+	t.writeChain(ctx, l+1, &h)
+}
+
+func (t *tree) writeChain(ctx *Digest, l int, h *[16]uint64) {
+	var buf [Size1024]byte
+	for i := range ctx.words {
+		binary.LittleEndian.PutUint64(buf[i<<3:], h[i])
+	}
+	t.write(ctx, l, buf[:ctx.words*8])
+}
+
+// final finishes the nodes in progress from the leaves up and returns
+// the chaining value of the root.
+func (t *tree) final(ctx *Digest, bitPad bool) [16]uint64 {
+	for l := 1; ; l++ {
+		lv := t.level(l)
+		if (lv.n == 0) && (lv.nodes == 0) {
+			// Only an empty message leaves nothing in the leaves.
+			lv.node.init(ctx.words, &ctx.iv, typeMsg, uint64(l), 0)
+		}
+		if (lv.n > 0) || (lv.nodes == 0) {
+			t.finish(ctx, l, bitPad && (l == 1))
+			lv = t.level(l)
+		}
+
+		if lv.nodes == 1 {
+			return lv.first
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package skein
+
+import "encoding/binary"
+
+// ubi is the state of one invocation of the Unique Block Iteration
+// chaining mode.
+type ubi struct {
+	words int
+	h     [16]uint64
+	buf   [Size1024]byte
+	off   int
+
+	// pos is the position of the end of the last processed block,
+	// which is the low word of the tweak.
+	pos uint64
+
+	// tweak is the high word of the tweak of the next block, without
+	// the Final and BitPad flags.
+	tweak uint64
+}
+
+// init starts a UBI invocation of the given type and tree level with
+// the chaining value h, as if start bytes had already been processed.
+func (u *ubi) init(words int, h *[16]uint64, typ, level, start uint64) {
+	*u = ubi{
+		words: words,
+		h:     *h,
+		pos:   start,
+		tweak: typ<<tweakTypeShift | level<<tweakLevelShift | tweakFirst,
+	}
+}
+
+func (u *ubi) write(data []byte) {
+	bs := u.words * 8
+
+	// The last block needs the Final flag, so a full buffer is only
+	// processed once more data arrives.
+	for len(data) > 0 {
+		if u.off == bs {
+			u.block(u.buf[:bs], bs, 0)
+			u.off = 0
+		}
+		for (u.off == 0) && (len(data) > bs) {
+			u.block(data[:bs], bs, 0)
+			data = data[bs:]
+		}
+
+		c := copy(u.buf[u.off:bs], data)
+		u.off += c
+		data = data[c:]
+	}
+}
+
+// final processes the buffered data, padded with zeros, as the last
+// block and returns the resulting chaining value.
+func (u *ubi) final(bitPad bool) [16]uint64 {
+	bs := u.words * 8
+	clear(u.buf[u.off:bs])
+
+	var flags uint64 = tweakFinal
+	if bitPad {
+		flags |= tweakBitPad
+	}
+	u.block(u.buf[:bs], u.off, flags)
+	return u.h
+}
+
+// block processes a single block containing n message bytes.
+func (u *ubi) block(b []byte, n int, flags uint64) {
+	u.pos += uint64(n)
+	t := [3]uint64{u.pos, u.tweak | flags}
+	t[2] = t[0] ^ t[1]
+
+	var k [17]uint64
+	copy(k[:], u.h[:u.words])
+	setParity(k[:u.words+1])
+
+	var m, x [16]uint64
+	for i := range u.words {
+		m[i] = binary.LittleEndian.Uint64(b[i<<3:])
+	}
+	x = m
+	encrypt(u.words, &k, &t, &x)
+
+	for i := range u.words {
+		u.h[i] = x[i] ^ m[i]
+	}
+	u.tweak &^= tweakFirst
+}